@@ -0,0 +1,43 @@
+#!/bin/bash
+
+function usage {
+  echo "usage:"
+  echo "  `basename "$0"` create [name] [variant] [number of players]"
+  echo "  `basename "$0"` register [tournament ID] [team name] [player1,player2,...]"
+  echo "  `basename "$0"` startRound [tournament ID]"
+  echo "  `basename "$0"` closeRound [tournament ID]"
+  echo "  `basename "$0"` finish [tournament ID]"
+  exit 1
+}
+
+if [[ $# -lt 1 ]]; then
+  usage
+fi
+
+# Get the directory of this script
+# https://stackoverflow.com/questions/59895/getting-the-source-directory-of-a-bash-script-from-within
+DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" >/dev/null 2>&1 && pwd )"
+
+source "$DIR/common.sh"
+
+# Tournament and team names can contain spaces, so each field is encoded separately
+URL="http://localhost:$LOCALHOST_PORT/tournament"
+if [[ $1 == "create" && $# -eq 4 ]]; then
+  curl --silent "$URL" \
+    --data-urlencode "action=create" \
+    --data-urlencode "name=$2" \
+    --data-urlencode "variant=$3" \
+    --data-urlencode "numPlayers=$4"
+elif [[ $1 == "register" && $# -eq 4 ]]; then
+  curl --silent "$URL" \
+    --data-urlencode "action=register" \
+    --data-urlencode "tournamentID=$2" \
+    --data-urlencode "team=$3" \
+    --data-urlencode "players=$4"
+elif [[ ($1 == "startRound" || $1 == "closeRound" || $1 == "finish") && $# -eq 2 ]]; then
+  curl --silent "$URL" \
+    --data-urlencode "action=$1" \
+    --data-urlencode "tournamentID=$2"
+else
+  usage
+fi
@@ -0,0 +1,22 @@
+#!/bin/bash
+
+# With no arguments, every game in the database is verified (check the server log for the results)
+# Otherwise, only the specified game is verified
+if [[ $# -gt 1 ]]; then
+  echo "usage: `basename "$0"` [game ID]"
+  exit 1
+fi
+
+# Get the directory of this script
+# https://stackoverflow.com/questions/59895/getting-the-source-directory-of-a-bash-script-from-within
+DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" >/dev/null 2>&1 && pwd )"
+
+# Get the name of the script and trim the ".sh"
+COMMAND=$(basename "$0" | cut -f 1 -d '.')
+
+source "$DIR/common.sh"
+if [[ $# -eq 1 ]]; then
+  admin_command "$COMMAND?gameID=$1"
+else
+  admin_command "$COMMAND"
+fi
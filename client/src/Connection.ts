@@ -17,6 +17,11 @@ export default class Connection {
   ws: WebSocket;
   callbacks: WebSocketCallbacks = {};
   debug: boolean;
+  // The sequence number of the most recent message from the server
+  // (this is used to resume the session after a disconnect)
+  lastSeq: number = 0;
+  // True if the connection was closed on purpose (e.g. after receiving an error)
+  closedByClient: boolean = false;
 
   constructor(addr: string, debug: boolean) {
     this.ws = new WebSocket(addr);
@@ -43,15 +48,19 @@ export default class Connection {
   onMessage(evt: MessageEvent) {
     const data = unpack(evt.data);
     const command = data[0];
+    const seq = data[1];
+    if (seq > this.lastSeq) {
+      this.lastSeq = seq;
+    }
     if (this.callbacks[command] !== undefined) {
-      const obj = unmarshal(data[1]);
+      const obj = unmarshal(data[2]);
       if (this.debug) {
         console.log(`%cReceived ${command}:`, 'color: blue;');
         console.log(obj);
       }
       this.callbacks[command](obj);
     } else {
-      console.error('Received WebSocket message with no callback:', command, JSON.parse(data[1]));
+      console.error('Received WebSocket message with no callback:', command, JSON.parse(data[2]));
     }
   }
 
@@ -75,14 +84,19 @@ export default class Connection {
   }
 
   close() {
+    this.closedByClient = true;
     this.ws.close();
   }
 }
 
+// Messages from the server have a sequence number between the command and the data
+// (the sequence number is 0 for messages that are not replayed after a reconnect)
 const separator = ' ';
-const unpack = (data: string) => {
+const unpack = (data: string): [string, number, string] => {
   const name = data.split(separator)[0];
-  return [name, data.substring(name.length + 1, data.length)];
+  const rest = data.substring(name.length + 1, data.length);
+  const seq = rest.split(separator)[0];
+  return [name, parseInt(seq, 10), rest.substring(seq.length + 1, rest.length)];
 };
 const unmarshal = (data: string) => JSON.parse(data) as unknown;
 const marshalAndPack = (name: string, data: any) => name + separator + JSON.stringify(data);
@@ -2,6 +2,7 @@ import * as chat from './chat';
 import { VARIANTS } from './game/data/gameData';
 import globals from './globals';
 import * as createGame from './lobby/createGame';
+import { parseIntSafe } from './misc';
 import * as modals from './modals';
 
 // Define a command handler map
@@ -138,6 +139,48 @@ const setVariant = (_room: string, args: string[]) => {
 chatCommands.set('setvariant', setVariant);
 chatCommands.set('changevariant', setVariant);
 
+// /setcustomvariant [suits]
+// /setcustomvariant [JSON]
+chatCommands.set('setcustomvariant', (_room: string, args: string[]) => {
+  if (globals.tableID === -1) {
+    modals.warningShow('You are not currently at a table, so you cannot use that command.');
+    return;
+  }
+
+  const format = 'The format of the /setcustomvariant command is: '
+    + '<code>/setcustomvariant Red, Blue, Rainbow</code> or '
+    + '<code>/setcustomvariant {"suits": ["Red", "Blue"], "clueRanks": [1, 5]}</code>';
+  const text = args.join(' ').trim();
+  if (text === '') {
+    modals.warningShow(format);
+    return;
+  }
+
+  // The clue colors, clue ranks, and special rank can only be specified with JSON
+  // (in the same format as the "variants.json" file)
+  let customVariant;
+  if (text.startsWith('{')) {
+    try {
+      customVariant = JSON.parse(text);
+    } catch (err) {
+      modals.warningShow(format);
+      return;
+    }
+  } else {
+    customVariant = {
+      suits: text.split(',').map((suit) => suit.trim()),
+    };
+  }
+
+  // The server will validate the rest of the variant
+  globals.conn!.send('tableSetVariant', {
+    tableID: globals.tableID,
+    options: {
+      customVariant,
+    },
+  });
+});
+
 // /tag [tag]
 chatCommands.set('tag', (_room: string, args: string[]) => {
   if (globals.tableID === -1) {
@@ -166,6 +209,45 @@ chatCommands.set('tagdelete', (_room: string, args: string[]) => {
   });
 });
 
+// /annotate [turn] [text]
+chatCommands.set('annotate', (_room: string, args: string[]) => {
+  if (globals.tableID === -1) {
+    modals.warningShow('You are not currently at a table, so you cannot use that command.');
+    return;
+  }
+
+  const turn = args.length > 0 ? parseIntSafe(args[0]) : NaN;
+  if (Number.isNaN(turn) || args.length < 2) {
+    modals.warningShow('The format of the /annotate command is: <code>/annotate 14 finesse</code>');
+    return;
+  }
+
+  globals.conn!.send('annotation', {
+    tableID: globals.tableID,
+    turn,
+    msg: args.slice(1).join(' '),
+  });
+});
+
+// /unannotate [turn]
+chatCommands.set('unannotate', (_room: string, args: string[]) => {
+  if (globals.tableID === -1) {
+    modals.warningShow('You are not currently at a table, so you cannot use that command.');
+    return;
+  }
+
+  const turn = args.length > 0 ? parseIntSafe(args[0]) : NaN;
+  if (Number.isNaN(turn)) {
+    modals.warningShow('The format of the /unannotate command is: <code>/unannotate 14</code>');
+    return;
+  }
+
+  globals.conn!.send('annotationDelete', {
+    tableID: globals.tableID,
+    turn,
+  });
+});
+
 // /tagsearch
 chatCommands.set('tagsearch', (room: string, args: string[]) => {
   const tag = args.join(' ');
@@ -1,3 +1,7 @@
 // Time constants
 export const FADE_TIME = 350; // In milliseconds
 export const SHUTDOWN_TIMEOUT = 30; // In minutes
+
+// The version of the WebSocket protocol that this client speaks
+// (this must be supported by the server; see "protocol.go")
+export const PROTOCOL_VERSION = 3;
@@ -1,8 +1,9 @@
+import Options from '../../types/Options';
 import { START_CARD_RANK } from '../types/constants';
 import charactersInit from './charactersInit';
 import colorsInit from './colorsInit';
 import suitsInit from './suitsInit';
-import variantsInit from './variantsInit';
+import variantsInit, { variantFromJSON } from './variantsInit';
 
 // Objects representing JSON files
 export const COLORS = colorsInit();
@@ -26,6 +27,16 @@ export const getVariant = (variantName: string) => {
   return variant;
 };
 
+// Custom variants are not in the "variants.json" file,
+// so they are added to the map when the server sends us their definition
+export const addCustomVariant = (options: Options) => {
+  if (options.customVariant === undefined || VARIANTS.has(options.variantName)) {
+    return;
+  }
+  const variant = variantFromJSON(options.customVariant, COLORS, SUITS, START_CARD_RANK);
+  VARIANTS.set(variant.name, variant);
+};
+
 export const getCharacter = (characterID: number) => {
   const character = CHARACTERS.get(characterID);
   if (character === undefined) {
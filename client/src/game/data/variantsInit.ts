@@ -6,7 +6,7 @@ import Variant from '../types/Variant';
 
 // "VariantJSON" is very similar to "Variant",
 // but the latter is comprised of some more complicated objects
-interface VariantJSON {
+export interface VariantJSON {
   name: string;
   id: number;
   suits: string[];
@@ -21,236 +21,324 @@ interface VariantJSON {
   specialNoClueColors?: boolean;
   specialNoClueRanks?: boolean;
 
+  alternatingClues?: boolean;
+  clueStarved?: boolean;
+  cowAndPig?: boolean;
+  duck?: boolean;
+  throwItInAHole?: boolean;
+  upOrDown?: boolean;
+
   showSuitNames?: boolean;
   spacing?: boolean;
 }
 
-export default function variantsInit(
+// variantFromJSON converts a variant from the JSON format into a variant object
+// This is used both for the variants in the "variants.json" file and for custom variants
+export function variantFromJSON(
+  variantJSON: VariantJSON,
   COLORS: Map<string, Color>,
   SUITS: Map<string, Suit>,
   START_CARD_RANK: number,
-) {
-  const VARIANTS = new Map<string, Variant>();
+): Variant {
+  // Validate the name
+  const name: string = variantJSON.name;
+  if (name === '') {
+    throw new Error('There is a variant with an empty name.');
+  }
 
-  for (const variantJSON of variantsJSON as VariantJSON[]) {
-    // Validate the name
-    const name: string = variantJSON.name;
-    if (name === '') {
-      throw new Error('There is a variant with an empty name in the "variants.json" file.');
-    }
+  // Custom variants built by the server have negative IDs
+  const id: number = variantJSON.id;
 
-    // Validate the ID
-    const id: number = variantJSON.id;
-    if (id < 0) { // The first variant has an ID of 0
-      throw new Error(`The "${name}" variant has an invalid ID.`);
-    }
+  // Validate the suits
+  if (!Object.hasOwnProperty.call(variantJSON, 'suits')) {
+    throw new Error(`The "${name}" variant does not have suits.`);
+  }
+  if (!Array.isArray(variantJSON.suits)) {
+    throw new Error(`The suits for the variant "${name}" were not specified as an array.`);
+  }
+  if (variantJSON.suits.length === 0) {
+    throw new Error(`The suits for the variant "${name}" is empty.`);
+  }
 
-    // Validate the suits
-    if (!Object.hasOwnProperty.call(variantJSON, 'suits')) {
-      throw new Error(`The "${name}" variant does not have suits.`);
+  // The suits are specified as an array of strings
+  // Convert the strings to objects
+  const suits: Suit[] = [];
+  for (const suitString of variantJSON.suits) {
+    if (typeof suitString !== 'string') {
+      throw new Error(`One of the suits for the variant "${name}" was not specified as a string.`);
     }
-    if (!Array.isArray(variantJSON.suits)) {
-      throw new Error(`The suits for the variant "${name}" were not specified as an array.`);
+
+    const suitObject = SUITS.get(suitString);
+    if (suitObject !== undefined) {
+      suits.push(suitObject);
+    } else {
+      throw new Error(`The suit "${suitString}" in the variant "${name}" does not exist.`);
     }
-    if (variantJSON.suits.length === 0) {
-      throw new Error(`The suits for the variant "${name}" is empty.`);
+  }
+
+  // Derive the ranks (the ranks that the cards of each suit will be)
+  // By default, assume ranks 1 through 5
+  const ranks = [1, 2, 3, 4, 5];
+  if (variantJSON.upOrDown === true) {
+    // The "Up or Down" variants have START cards
+    ranks.push(START_CARD_RANK);
+  }
+
+  // Validate the clue colors (the colors available to clue in this variant)
+  const clueColors: Color[] = [];
+  if (Object.hasOwnProperty.call(variantJSON, 'clueColors')) {
+    if (!Array.isArray(variantJSON.clueColors)) {
+      throw new Error(`The clue colors for the variant "${name}" were not specified as an array.`);
     }
 
-    // The suits are specified as an array of strings
+    // The clue colors are specified as an array of strings
     // Convert the strings to objects
-    const suits: Suit[] = [];
-    for (const suitString of variantJSON.suits) {
-      if (typeof suitString !== 'string') {
-        throw new Error(`One of the suits for the variant "${name}" was not specified as a string.`);
+    for (const colorString of variantJSON.clueColors!) {
+      if (typeof colorString !== 'string') {
+        throw new Error(`One of the clue colors for the variant "${name}" was not specified as a string.`);
       }
 
-      const suitObject = SUITS.get(suitString);
-      if (suitObject !== undefined) {
-        suits.push(suitObject);
+      const colorObject = COLORS.get(colorString);
+      if (colorObject !== undefined) {
+        clueColors.push(colorObject);
       } else {
-        throw new Error(`The suit "${suitString}" in the variant "${name}" does not exist.`);
+        throw new Error(`The color "${colorString}" in the variant "${name}" does not exist.`);
       }
     }
-
-    // Derive the ranks (the ranks that the cards of each suit will be)
-    // By default, assume ranks 1 through 5
-    const ranks = [1, 2, 3, 4, 5];
-    if (name.startsWith('Up or Down')) {
-      // The "Up or Down" variants have START cards
-      ranks.push(START_CARD_RANK);
-    }
-
-    // Validate the clue colors (the colors available to clue in this variant)
-    const clueColors: Color[] = [];
-    if (Object.hasOwnProperty.call(variantJSON, 'clueColors')) {
-      if (!Array.isArray(variantJSON.clueColors)) {
-        throw new Error(`The clue colors for the variant "${name}" were not specified as an array.`);
-      }
-
-      // The clue colors are specified as an array of strings
-      // Convert the strings to objects
-      for (const colorString of variantJSON.clueColors!) {
-        if (typeof colorString !== 'string') {
-          throw new Error(`One of the clue colors for the variant "${name}" was not specified as a string.`);
-        }
-
-        const colorObject = COLORS.get(colorString);
-        if (colorObject !== undefined) {
-          clueColors.push(colorObject);
-        } else {
-          throw new Error(`The color "${colorString}" in the variant "${name}" does not exist.`);
-        }
+  } else {
+    // The clue colors were not specified in the JSON, so derive them from the suits
+    for (const suit of suits) {
+      if (suit.allClueColors) {
+        // If a suit is touched by all colors, then we don't want to add
+        // every single clue color to the variant clue list
+        continue;
       }
-    } else {
-      // The clue colors were not specified in the JSON, so derive them from the suits
-      for (const suit of suits) {
-        if (suit.allClueColors) {
-          // If a suit is touched by all colors, then we don't want to add
-          // every single clue color to the variant clue list
-          continue;
-        }
-        for (const color of suit.clueColors) {
-          if (!clueColors.includes(color)) {
-            clueColors.push(color);
-          }
+      for (const color of suit.clueColors) {
+        if (!clueColors.includes(color)) {
+          clueColors.push(color);
         }
       }
     }
+  }
 
-    // Validate the clue ranks (the ranks available to clue in this variant)
-    // If it is not specified, assume that players can clue ranks 1 through 5
-    const clueRanks: number[] = variantJSON.clueRanks || [1, 2, 3, 4, 5];
-
-    // Validate the "colorCluesTouchNothing" property
-    // If it is not specified, assume false (e.g. cluing colors in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'colorCluesTouchNothing')
-      && variantJSON.colorCluesTouchNothing !== true
-    ) {
-      throw new Error(`The "colorCluesTouchNothing" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const colorCluesTouchNothing: boolean = variantJSON.colorCluesTouchNothing ?? false;
-
-    // Validate the "rankCluesTouchNothing" property
-    // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'rankCluesTouchNothing')
-      && variantJSON.rankCluesTouchNothing !== true
-    ) {
-      throw new Error(`The "rankCluesTouchNothing" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const rankCluesTouchNothing: boolean = variantJSON.rankCluesTouchNothing ?? false;
-
-    // Validate the "specialRank" property (e.g. for "Rainbow-Ones")
-    // If it is not specified, assume -1 (e.g. there are no special ranks)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'specialRank')
-      && (variantJSON.specialRank! < 1 || variantJSON!.specialRank! > 5)
-    ) {
-      throw new Error(`The "specialRank" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const specialRank: number = variantJSON.specialRank ?? -1;
-
-    // Validate the "specialAllClueColors" property
-    // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'specialAllClueColors')
-      && variantJSON.specialAllClueColors !== true
-    ) {
-      throw new Error(`The "specialAllClueColors" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const specialAllClueColors: boolean = variantJSON.specialAllClueColors ?? false;
-
-    // Validate the "specialAllClueRanks" property
-    // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'specialAllClueRanks')
-      && variantJSON.specialAllClueRanks !== true
-    ) {
-      throw new Error(`The "specialAllClueRanks" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const specialAllClueRanks: boolean = variantJSON.specialAllClueRanks ?? false;
-
-    // Validate the "specialNoClueColors" property
-    // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'specialNoClueColors')
-      && variantJSON.specialNoClueColors !== true
-    ) {
-      throw new Error(`The "specialNoClueColors" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const specialNoClueColors: boolean = variantJSON.specialNoClueColors ?? false;
-
-    // Validate the "specialNoClueRanks" property
-    // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'specialNoClueRanks')
-      && variantJSON.specialNoClueRanks !== true
-    ) {
-      throw new Error(`The "specialNoClueRanks" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    const specialNoClueRanks: boolean = variantJSON.specialNoClueRanks ?? false;
-
-    // Validate the "showSuitNames" property
-    // If it is not specified, assume that we are not showing the suit names
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'showSuitNames')
-      && variantJSON.showSuitNames !== true
-    ) {
-      throw new Error(`The "showSuitNames" property for the variant "${variantJSON.name}" must be set to true.`);
-    }
-    let showSuitNames: boolean = variantJSON.showSuitNames ?? false;
+  // Validate the clue ranks (the ranks available to clue in this variant)
+  // If it is not specified, assume that players can clue ranks 1 through 5
+  const clueRanks: number[] = variantJSON.clueRanks || [1, 2, 3, 4, 5];
 
-    // Always set "showSuitNames" to true if it has one or more reversed suits
-    for (const suit of suits) {
-      if (suit.reversed) {
-        showSuitNames = true;
-        break;
-      }
-    }
+  // Validate the "colorCluesTouchNothing" property
+  // If it is not specified, assume false (e.g. cluing colors in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'colorCluesTouchNothing')
+    && variantJSON.colorCluesTouchNothing !== true
+  ) {
+    throw new Error(`The "colorCluesTouchNothing" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const colorCluesTouchNothing: boolean = variantJSON.colorCluesTouchNothing ?? false;
 
-    // Validate the "spacing" property
-    // If it is not specified, assume that there is no spacing
-    if (
-      Object.hasOwnProperty.call(variantJSON, 'spacing')
-      && variantJSON.spacing !== true
-    ) {
-      throw new Error(`The "spacing" property for the variant "${variantJSON.name}" must be set to true.`);
+  // Validate the "rankCluesTouchNothing" property
+  // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'rankCluesTouchNothing')
+    && variantJSON.rankCluesTouchNothing !== true
+  ) {
+    throw new Error(`The "rankCluesTouchNothing" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const rankCluesTouchNothing: boolean = variantJSON.rankCluesTouchNothing ?? false;
+
+  // Validate the "specialRank" property (e.g. for "Rainbow-Ones")
+  // If it is not specified, assume -1 (e.g. there are no special ranks)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'specialRank')
+    && (variantJSON.specialRank! < 1 || variantJSON!.specialRank! > 5)
+  ) {
+    throw new Error(`The "specialRank" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const specialRank: number = variantJSON.specialRank ?? -1;
+
+  // Validate the "specialAllClueColors" property
+  // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'specialAllClueColors')
+    && variantJSON.specialAllClueColors !== true
+  ) {
+    throw new Error(`The "specialAllClueColors" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const specialAllClueColors: boolean = variantJSON.specialAllClueColors ?? false;
+
+  // Validate the "specialAllClueRanks" property
+  // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'specialAllClueRanks')
+    && variantJSON.specialAllClueRanks !== true
+  ) {
+    throw new Error(`The "specialAllClueRanks" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const specialAllClueRanks: boolean = variantJSON.specialAllClueRanks ?? false;
+
+  // Validate the "specialNoClueColors" property
+  // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'specialNoClueColors')
+    && variantJSON.specialNoClueColors !== true
+  ) {
+    throw new Error(`The "specialNoClueColors" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const specialNoClueColors: boolean = variantJSON.specialNoClueColors ?? false;
+
+  // Validate the "specialNoClueRanks" property
+  // If it is not specified, assume false (e.g. cluing ranks in this variant works normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'specialNoClueRanks')
+    && variantJSON.specialNoClueRanks !== true
+  ) {
+    throw new Error(`The "specialNoClueRanks" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const specialNoClueRanks: boolean = variantJSON.specialNoClueRanks ?? false;
+
+  // Validate the "alternatingClues" property
+  // If it is not specified, assume false (e.g. the same type of clue can be given twice in a row)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'alternatingClues')
+    && variantJSON.alternatingClues !== true
+  ) {
+    throw new Error(`The "alternatingClues" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const alternatingClues: boolean = variantJSON.alternatingClues ?? false;
+
+  // Validate the "clueStarved" property
+  // If it is not specified, assume false (e.g. each discard gives back a whole clue)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'clueStarved')
+    && variantJSON.clueStarved !== true
+  ) {
+    throw new Error(`The "clueStarved" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const clueStarved: boolean = variantJSON.clueStarved ?? false;
+
+  // Validate the "cowAndPig" property
+  // If it is not specified, assume false (e.g. clues work normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'cowAndPig')
+    && variantJSON.cowAndPig !== true
+  ) {
+    throw new Error(`The "cowAndPig" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const cowAndPig: boolean = variantJSON.cowAndPig ?? false;
+
+  // Validate the "duck" property
+  // If it is not specified, assume false (e.g. clues work normally)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'duck')
+    && variantJSON.duck !== true
+  ) {
+    throw new Error(`The "duck" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const duck: boolean = variantJSON.duck ?? false;
+
+  // Validate the "throwItInAHole" property
+  // If it is not specified, assume false (e.g. played cards are shown to the players)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'throwItInAHole')
+    && variantJSON.throwItInAHole !== true
+  ) {
+    throw new Error(`The "throwItInAHole" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const throwItInAHole: boolean = variantJSON.throwItInAHole ?? false;
+
+  // Validate the "upOrDown" property
+  // If it is not specified, assume false (e.g. there are no START cards)
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'upOrDown')
+    && variantJSON.upOrDown !== true
+  ) {
+    throw new Error(`The "upOrDown" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const upOrDown: boolean = variantJSON.upOrDown ?? false;
+
+  // Validate the "showSuitNames" property
+  // If it is not specified, assume that we are not showing the suit names
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'showSuitNames')
+    && variantJSON.showSuitNames !== true
+  ) {
+    throw new Error(`The "showSuitNames" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  let showSuitNames: boolean = variantJSON.showSuitNames ?? false;
+
+  // Always set "showSuitNames" to true if it has one or more reversed suits
+  for (const suit of suits) {
+    if (suit.reversed) {
+      showSuitNames = true;
+      break;
     }
-    const spacing: boolean = variantJSON.spacing ?? false;
+  }
 
-    // Assume 5 cards per stack
-    const maxScore = suits.length * 5;
+  // Validate the "spacing" property
+  // If it is not specified, assume that there is no spacing
+  if (
+    Object.hasOwnProperty.call(variantJSON, 'spacing')
+    && variantJSON.spacing !== true
+  ) {
+    throw new Error(`The "spacing" property for the variant "${variantJSON.name}" must be set to true.`);
+  }
+  const spacing: boolean = variantJSON.spacing ?? false;
 
-    // Variants with dual-color suits need to adjust the positions of elements in the corner
-    // of the card (e.g. the note indicator) because it will overlap with the triangle that
-    // shows the color composition of the suit
-    const offsetCornerElements = suits.some((suit: Suit) => suit.clueColors.length > 1);
+  // Assume 5 cards per stack
+  const maxScore = suits.length * 5;
+
+  // Variants with dual-color suits need to adjust the positions of elements in the corner
+  // of the card (e.g. the note indicator) because it will overlap with the triangle that
+  // shows the color composition of the suit
+  const offsetCornerElements = suits.some((suit: Suit) => suit.clueColors.length > 1);
+
+  // Prepare the abbreviations for each suit
+  const abbreviations = abbreviationsRules.makeAll(name, suits);
+
+  const variant: Variant = {
+    name,
+    id,
+    suits,
+    ranks,
+    clueColors,
+    clueRanks,
+    colorCluesTouchNothing,
+    rankCluesTouchNothing,
+    specialRank,
+    specialAllClueColors,
+    specialAllClueRanks,
+    specialNoClueColors,
+    specialNoClueRanks,
+    alternatingClues,
+    clueStarved,
+    cowAndPig,
+    duck,
+    throwItInAHole,
+    upOrDown,
+    showSuitNames,
+    spacing,
+    maxScore,
+    offsetCornerElements,
+    abbreviations,
+  };
+
+  return variant;
+}
 
-    // Prepare the abbreviations for each suit
-    const abbreviations = abbreviationsRules.makeAll(name, suits);
+export default function variantsInit(
+  COLORS: Map<string, Color>,
+  SUITS: Map<string, Suit>,
+  START_CARD_RANK: number,
+) {
+  const VARIANTS = new Map<string, Variant>();
+
+  for (const variantJSON of variantsJSON as VariantJSON[]) {
+    // Validate the ID
+    if (variantJSON.id < 0) { // The first variant has an ID of 0
+      throw new Error(`The "${variantJSON.name}" variant has an invalid ID.`);
+    }
 
     // Add it to the map
-    const variant: Variant = {
-      name,
-      id,
-      suits,
-      ranks,
-      clueColors,
-      clueRanks,
-      colorCluesTouchNothing,
-      rankCluesTouchNothing,
-      specialRank,
-      specialAllClueColors,
-      specialAllClueRanks,
-      specialNoClueColors,
-      specialNoClueRanks,
-      showSuitNames,
-      spacing,
-      maxScore,
-      offsetCornerElements,
-      abbreviations,
-    };
+    const variant = variantFromJSON(variantJSON, COLORS, SUITS, START_CARD_RANK);
     VARIANTS.set(variantJSON.name, variant);
   }
 
@@ -7,7 +7,7 @@ import { // Direct import instead of namespace import for compactness
   strike,
 } from '../../../test/testActions';
 import testMetadata from '../../../test/testMetadata';
-import { MAX_CLUE_NUM } from '../types/constants';
+import { DEFAULT_VARIANT_NAME, MAX_CLUE_NUM, MAX_STRIKES } from '../types/constants';
 import gameStateReducer from './gameStateReducer';
 import initialGameState from './initialStates/initialGameState';
 import initialGameStateTest from './initialStates/initialGameStateTest';
@@ -15,6 +15,11 @@ import initialGameStateTest from './initialStates/initialGameStateTest';
 const numPlayers = 3;
 const defaultMetadata = testMetadata(numPlayers);
 const clueStarvedMetadata = testMetadata(numPlayers, 'Clue Starved (6 Suits)');
+const clueLimitsMetadata = testMetadata(numPlayers, DEFAULT_VARIANT_NAME, {
+  startingClues: 2,
+  maxClues: 3,
+  maxStrikes: 5,
+});
 
 describe('gameStateReducer', () => {
   test('does not mutate state', () => {
@@ -304,4 +309,40 @@ describe('gameStateReducer', () => {
       expect(state.score).toBe(1);
     });
   });
+
+  describe('custom limits', () => {
+    test('start with the starting clues of the table', () => {
+      const state = initialGameState(clueLimitsMetadata);
+      expect(state.clueTokens).toBe(2);
+    });
+
+    test('do not gain clues past the maximum of the table', () => {
+      let state = initialGameState(clueLimitsMetadata);
+
+      // Draw three red 1s and discard all of them
+      for (let i = 0; i < 3; i++) {
+        const drawAction = draw(0, i, 0, 1);
+        state = gameStateReducer(state, drawAction, true, clueLimitsMetadata);
+      }
+      for (let i = 0; i < 3; i++) {
+        const discardAction = discard(0, i, 0, 1, false);
+        state = gameStateReducer(state, discardAction, true, clueLimitsMetadata);
+      }
+
+      expect(state.clueTokens).toBe(3);
+    });
+
+    test('record strikes past the default strike limit', () => {
+      let state = initialGameState(clueLimitsMetadata);
+
+      for (let i = 0; i < MAX_STRIKES + 1; i++) {
+        const drawAction = draw(0, i, 0, 5);
+        state = gameStateReducer(state, drawAction, true, clueLimitsMetadata);
+        const strikeAction = strike(i + 1, i, i);
+        state = gameStateReducer(state, strikeAction, true, clueLimitsMetadata);
+      }
+
+      expect(state.strikes.length).toBe(MAX_STRIKES + 1);
+    });
+  });
 });
@@ -89,7 +89,12 @@ const gameStateReducer = produce((
         state.discardStacks[action.suitIndex].push(action.order);
 
         // Discarding cards grants clue tokens under certain circumstances
-        state.clueTokens = clueTokensRules.gain(action, state.clueTokens, variant);
+        state.clueTokens = clueTokensRules.gain(
+          action,
+          state.clueTokens,
+          variant,
+          metadata.options.maxClues,
+        );
       }
 
       const touched = cardRules.isClued(state.deck[action.order]);
@@ -170,6 +175,7 @@ const gameStateReducer = produce((
           action,
           state.clueTokens,
           variant,
+          metadata.options.maxClues,
           playStack.length === 5,
         );
       }
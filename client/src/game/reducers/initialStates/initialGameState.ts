@@ -9,7 +9,6 @@ import {
   clueTokensRules,
 } from '../../rules';
 import CardStatus from '../../types/CardStatus';
-import { MAX_CLUE_NUM } from '../../types/constants';
 import GameMetadata from '../../types/GameMetadata';
 import GameState from '../../types/GameState';
 import SoundType from '../../types/SoundType';
@@ -55,7 +54,7 @@ export default function initialGameState(metadata: GameMetadata): GameState {
     cardStatus,
     score: 0,
     numAttemptedCardsPlayed: 0,
-    clueTokens: clueTokensRules.getAdjusted(MAX_CLUE_NUM, variant),
+    clueTokens: clueTokensRules.getAdjusted(options.startingClues, variant),
     strikes: [],
     hands,
     playStacks,
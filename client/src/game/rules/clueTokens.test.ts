@@ -1,40 +1,76 @@
 import { discard, play } from '../../../test/testActions';
 import { getVariant } from '../data/gameData';
 import { DEFAULT_VARIANT_NAME, MAX_CLUE_NUM } from '../types/constants';
-import { gain } from './clueTokens';
+import { atMax, gain } from './clueTokens';
 
 const discardAction = discard(0, 0, 0, 1, false);
 const defaultVariant = getVariant(DEFAULT_VARIANT_NAME);
+const clueStarvedVariant = getVariant('Clue Starved (6 Suits)');
 const throwItInAHoleVariant = getVariant('Throw It in a Hole (6 Suits)');
 
 describe('gain', () => {
   test.each([...Array(8).keys()])('adds a clue when there are %i clues', (n) => {
-    const clueTokens = gain(discardAction, n, defaultVariant);
+    const clueTokens = gain(discardAction, n, defaultVariant, MAX_CLUE_NUM);
     expect(clueTokens).toBe(n + 1);
   });
 
   test('does not add clues when maxed out', () => {
-    const clueTokens = gain(discardAction, MAX_CLUE_NUM, defaultVariant);
+    const clueTokens = gain(discardAction, MAX_CLUE_NUM, defaultVariant, MAX_CLUE_NUM);
     expect(clueTokens).toBe(MAX_CLUE_NUM);
   });
 
+  test('adds clues past the default maximum when the maximum is higher', () => {
+    const clueTokens = gain(discardAction, MAX_CLUE_NUM, defaultVariant, 12);
+    expect(clueTokens).toBe(MAX_CLUE_NUM + 1);
+  });
+
+  test('does not add clues when a lower maximum is reached', () => {
+    const clueTokens = gain(discardAction, 4, defaultVariant, 4);
+    expect(clueTokens).toBe(4);
+  });
+
   test('does not add a clue when a stack is not finished', () => {
     const playAction = play(0, 0, 0, 5);
-    const clueTokens = gain(playAction, 0, defaultVariant, false);
+    const clueTokens = gain(playAction, 0, defaultVariant, MAX_CLUE_NUM, false);
     expect(clueTokens).toBe(0);
   });
 
   test('adds a clue when a stack is finished', () => {
     const playAction = play(0, 0, 0, 5);
     const startingClueTokens = 0;
-    const clueTokens = gain(playAction, 0, defaultVariant, true);
+    const clueTokens = gain(playAction, 0, defaultVariant, MAX_CLUE_NUM, true);
     expect(clueTokens).toBe(startingClueTokens + 1);
   });
 
   test('does not add a clue when a stack is finished in Throw It in a Hole variants', () => {
     const playAction = play(0, 0, 0, 5);
     const startingClueTokens = 0;
-    const clueTokens = gain(playAction, startingClueTokens, throwItInAHoleVariant, true);
+    const clueTokens = gain(
+      playAction,
+      startingClueTokens,
+      throwItInAHoleVariant,
+      MAX_CLUE_NUM,
+      true,
+    );
     expect(clueTokens).toBe(startingClueTokens);
   });
 });
+
+describe('atMax', () => {
+  test('is true at the default maximum', () => {
+    expect(atMax(MAX_CLUE_NUM, defaultVariant, MAX_CLUE_NUM)).toBe(true);
+    expect(atMax(MAX_CLUE_NUM - 1, defaultVariant, MAX_CLUE_NUM)).toBe(false);
+  });
+
+  test('uses the maximum of the table', () => {
+    expect(atMax(MAX_CLUE_NUM, defaultVariant, 12)).toBe(false);
+    expect(atMax(12, defaultVariant, 12)).toBe(true);
+    expect(atMax(4, defaultVariant, 4)).toBe(true);
+  });
+
+  test('adjusts the maximum in Clue Starved variants', () => {
+    expect(atMax(MAX_CLUE_NUM, clueStarvedVariant, MAX_CLUE_NUM)).toBe(false);
+    expect(atMax(MAX_CLUE_NUM * 2, clueStarvedVariant, MAX_CLUE_NUM)).toBe(true);
+    expect(atMax(8, clueStarvedVariant, 4)).toBe(true);
+  });
+});
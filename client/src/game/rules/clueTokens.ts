@@ -2,7 +2,6 @@
 
 import { clueTokensRules } from '../rules';
 import { ActionPlay, ActionDiscard } from '../types/actions';
-import { MAX_CLUE_NUM } from '../types/constants';
 import Variant from '../types/Variant';
 import * as variantRules from './variant';
 
@@ -11,9 +10,10 @@ export const gain = (
   action: ActionPlay | ActionDiscard,
   clueTokens: number,
   variant: Variant,
+  maxClues: number,
   playStackComplete: boolean = false,
 ) => {
-  if (shouldGenerateClue(action, clueTokens, variant, playStackComplete)) {
+  if (shouldGenerateClue(action, clueTokens, variant, maxClues, playStackComplete)) {
     return clueTokens + 1;
   }
   return clueTokens;
@@ -23,9 +23,10 @@ const shouldGenerateClue = (
   action: ActionPlay | ActionDiscard,
   clueTokens: number,
   variant: Variant,
+  maxClues: number,
   playStackComplete: boolean,
 ) => {
-  if (clueTokensRules.atMax(clueTokens, variant)) {
+  if (clueTokensRules.atMax(clueTokens, variant, maxClues)) {
     return false;
   }
 
@@ -59,10 +60,13 @@ export const getAdjusted = (clueTokens: number, variant: Variant) => {
   return clueTokens;
 };
 
+// The maximum amount of clues is normally equal to "MAX_CLUE_NUM",
+// but it can be changed by the table creator
 export const atMax = (
   clueTokens: number,
   variant: Variant,
-) => clueTokens >= getAdjusted(MAX_CLUE_NUM, variant);
+  maxClues: number,
+) => clueTokens >= getAdjusted(maxClues, variant);
 
 // The value of clues gained when discarding or finishing a suit
 // This function is *only* used in efficiency calculations
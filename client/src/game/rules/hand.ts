@@ -19,7 +19,9 @@ export const cardsPerHandNatural = (numPlayers: number) => {
     case 5: {
       return 4;
     }
-    case 6: {
+    case 6:
+    case 7:
+    case 8: {
       return 3;
     }
     default: {
@@ -67,6 +67,14 @@ describe('minEfficiency', () => {
   test('returns about 1.58 for 4-player Clue Starved (6 Suits)', () => {
     expect(minEfficiency(4, clueStarvedVariant, false, false)).toBeCloseTo(1.58);
   });
+
+  test('returns about 1 for 2-player No Variant with 4 starting clues', () => {
+    expect(minEfficiency(2, defaultVariant, false, false, 4)).toBeCloseTo(1);
+  });
+
+  test('returns about 0.78 for 2-player No Variant with 11 starting clues', () => {
+    expect(minEfficiency(2, defaultVariant, false, false, 11)).toBeCloseTo(0.78);
+  });
 });
 
 describe('pace', () => {
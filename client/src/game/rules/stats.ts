@@ -161,6 +161,7 @@ export const minEfficiency = (
   variant: Variant,
   oneExtraCard: boolean,
   oneLessCard: boolean,
+  startingClues: number = MAX_CLUE_NUM,
 ): number => {
   // First, calculate the starting pace:
   const cardsPerHand = handRules.cardsPerHand(numPlayers, oneExtraCard, oneLessCard);
@@ -190,7 +191,7 @@ export const minEfficiency = (
   if (variantRules.isClueStarved(variant)) {
     discardsPerClue = 2;
   }
-  const minEfficiencyDenominator = MAX_CLUE_NUM + Math.floor(
+  const minEfficiencyDenominator = startingClues + Math.floor(
     (initialPace + cluesGainedAfterCompletingSuits - unusableClues) / discardsPerClue,
   );
 
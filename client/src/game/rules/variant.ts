@@ -4,17 +4,17 @@ import Variant from '../types/Variant';
 
 export const isDualColor = (variant: Variant) => variant.name.startsWith('Dual-Color');
 
-export const isAlternatingClues = (variant: Variant) => variant.name.startsWith('Alternating Clues');
+export const isAlternatingClues = (variant: Variant) => variant.alternatingClues;
 
-export const isClueStarved = (variant: Variant) => variant.name.startsWith('Clue Starved');
+export const isClueStarved = (variant: Variant) => variant.clueStarved;
 
-export const isCowAndPig = (variant: Variant) => variant.name.startsWith('Cow & Pig');
+export const isCowAndPig = (variant: Variant) => variant.cowAndPig;
 
-export const isDuck = (variant: Variant) => variant.name.startsWith('Duck');
+export const isDuck = (variant: Variant) => variant.duck;
 
-export const isThrowItInAHole = (variant: Variant) => variant.name.startsWith('Throw It in a Hole');
+export const isThrowItInAHole = (variant: Variant) => variant.throwItInAHole;
 
-export const isUpOrDown = (variant: Variant) => variant.name.startsWith('Up or Down');
+export const isUpOrDown = (variant: Variant) => variant.upOrDown;
 
 export const hasReversedSuits = (variant: Variant) => {
   const suits = variant.suits;
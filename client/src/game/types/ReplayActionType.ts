@@ -7,5 +7,8 @@ enum ReplayActionType {
   HypoAction,
   HypoBack,
   HypoToggleRevealed,
+  HypoSave,
+  HypoLoad,
+  HypoDelete,
 }
 export default ReplayActionType;
@@ -13,5 +13,7 @@ enum ReplayArrowOrder {
   Pace = -9,
   Efficiency = -10,
   MinEfficiency = -11,
+  Strike4 = -12,
+  Strike5 = -13,
 }
 export default ReplayArrowOrder;
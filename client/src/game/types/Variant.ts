@@ -17,6 +17,13 @@ export default interface Variant {
   readonly specialNoClueColors: boolean;
   readonly specialNoClueRanks: boolean;
 
+  readonly alternatingClues: boolean;
+  readonly clueStarved: boolean;
+  readonly cowAndPig: boolean;
+  readonly duck: boolean;
+  readonly throwItInAHole: boolean;
+  readonly upOrDown: boolean;
+
   readonly showSuitNames: boolean;
   readonly spacing: boolean;
   readonly maxScore: number;
@@ -4,4 +4,6 @@ export const UNKNOWN_CARD_RANK = 6;
 export const START_CARD_RANK = 7;
 export const MAX_CLUE_NUM = 8;
 export const MAX_STRIKES = 3;
+export const MIN_PLAYERS = 2;
+export const MAX_PLAYERS = 8;
 export const DEFAULT_VARIANT_NAME = 'No Variant';
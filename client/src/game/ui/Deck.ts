@@ -1,7 +1,8 @@
 import Konva from 'konva';
-import { timerFormatter, dateTimeFormatter, millisecondsToClockString } from '../../misc';
+import { timeControlFormatter, dateTimeFormatter, millisecondsToClockString } from '../../misc';
 import { deckRules } from '../rules';
 import ActionType from '../types/ActionType';
+import { MAX_CLUE_NUM, MAX_STRIKES } from '../types/constants';
 import ReplayArrowOrder from '../types/ReplayArrowOrder';
 import * as arrows from './arrows';
 import { TOOLTIP_DELAY, CARD_ANIMATION_LENGTH } from './constants';
@@ -238,9 +239,12 @@ const getTooltipContent = () => {
   if (globals.options.timed) {
     content += '<li><span class="game-tooltips-icon"><i class="fas fa-clock"></i></span>';
     content += '&nbsp; Timed: ';
-    content += timerFormatter(globals.options.timeBase);
-    content += ' + ';
-    content += timerFormatter(globals.options.timePerTurn);
+    content += timeControlFormatter(
+      globals.options.timeBase,
+      globals.options.timePerTurn,
+      globals.options.timeControl,
+      globals.options.turnTimeLimit,
+    );
     content += '</li>';
   }
 
@@ -287,6 +291,16 @@ const getTooltipContent = () => {
     content += '&nbsp; Detrimental Characters</li>';
   }
 
+  if (
+    globals.options.startingClues !== MAX_CLUE_NUM
+    || globals.options.maxClues !== MAX_CLUE_NUM
+    || globals.options.maxStrikes !== MAX_STRIKES
+  ) {
+    content += '<li><span class="game-tooltips-icon"><i class="fas fa-bomb"></i></span>';
+    content += `&nbsp; ${globals.options.startingClues} / ${globals.options.maxClues} Clues, `;
+    content += `${globals.options.maxStrikes} Strikes</li>`;
+  }
+
   content += '</ul>';
 
   return content;
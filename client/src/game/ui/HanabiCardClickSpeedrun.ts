@@ -108,7 +108,11 @@ const clickRight = (card: HanabiCard, event: MouseEvent) => {
     && !event.metaKey
   ) {
     // Prevent discarding while at the maximum amount of clues
-    if (clueTokensRules.atMax(globals.state.ongoingGame.clueTokens, globals.variant)) {
+    if (clueTokensRules.atMax(
+      globals.state.ongoingGame.clueTokens,
+      globals.variant,
+      globals.options.maxClues,
+    )) {
       return;
     }
 
@@ -49,6 +49,10 @@ export default class HanabiUI {
     let text = '💬';
     if (globals.lobby.chatUnread > 0) {
       text += ` (${globals.lobby.chatUnread})`;
+
+      // The chat is normally hidden in solo replays,
+      // but it is also used to display the annotations for the game
+      globals.elements.chatButton.visible(true);
     }
     globals.elements.chatButton.text(text);
     globals.layers.UI.batchDraw();
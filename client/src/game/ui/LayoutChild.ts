@@ -120,7 +120,11 @@ export default class LayoutChild extends Konva.Group {
     let draggedTo = cursor.getElementDragLocation(this);
     if (
       draggedTo === 'discardArea'
-      && clueTokensRules.atMax(globals.state.ongoingGame.clueTokens, globals.variant)
+      && clueTokensRules.atMax(
+        globals.state.ongoingGame.clueTokens,
+        globals.variant,
+        globals.options.maxClues,
+      )
     ) {
       sounds.play('error');
       globals.elements.cluesNumberLabelPulse!.play();
@@ -165,8 +169,11 @@ export default class LayoutChild extends Konva.Group {
     if (
       !globals.options.speedrun
       && !variantRules.isThrowItInAHole(globals.variant)
-      // Don't use warnings for preplays unless we are at 2 strikes
-      && (currentPlayerIndex === ourPlayerIndex || ongoingGame.strikes.length === 2)
+      // Don't use warnings for preplays unless we are one strike away from losing
+      && (
+        currentPlayerIndex === ourPlayerIndex
+        || ongoingGame.strikes.length === globals.options.maxStrikes - 1
+      )
       && !cardRules.isPotentiallyPlayable(
         this.card.state,
         ongoingGame.deck,
@@ -113,6 +113,33 @@ export default function drawHands(winW: number, winH: number) {
     handPos[6][4].y += adjustedYOther;
   }
 
+  // 7-player and 8-player games use the same layout as 6-player games,
+  // but with the extra hands squeezed into the top row
+  const topRowLeftX = handPos[6][2].x;
+  const topRowRightX = handPos[6][4].x + handPos[6][4].w;
+  const topRowSpacing = 0.03;
+  for (let i = 7; i <= 8; i++) {
+    const numTopHands = i - 3;
+    const topHandW = (
+      topRowRightX - topRowLeftX - (topRowSpacing * (numTopHands - 1))
+    ) / numTopHands;
+    const topHandH = Math.min(topHandW / (handPos6Ratio * 0.75), handPos[6][2].h);
+    handPos[i] = [
+      { ...handPos[6][0] },
+      { ...handPos[6][1] },
+    ];
+    for (let j = 0; j < numTopHands; j++) {
+      handPos[i].push({
+        x: topRowLeftX + (j * (topHandW + topRowSpacing)),
+        y: handPos[6][2].y,
+        w: topHandW,
+        h: topHandH,
+        rot: 0,
+      });
+    }
+    handPos[i].push({ ...handPos[6][5] });
+  }
+
   // In Board Game Arena mode, the hands are all in a line,
   // so they do not have to be hard coded
   const handPosBGA: HandConfig[][] = [];
@@ -269,6 +296,21 @@ export default function drawHands(winW: number, winH: number) {
       h: namePosValues.h,
     },
   ];
+  for (let i = 7; i <= 8; i++) {
+    namePos[i] = [
+      { ...namePos[6][0] },
+      { ...namePos[6][1] },
+    ];
+    for (let j = 2; j < i - 1; j++) {
+      namePos[i].push({
+        x: handPos[i][j].x - 0.005,
+        y: handPos[i][j].y + handPos[i][j].h + 0.02,
+        w: handPos[i][j].w + 0.01,
+        h: namePosValues.h,
+      });
+    }
+    namePos[i].push({ ...namePos[6][5] });
+  }
 
   /* eslint-enable object-curly-newline */
 
@@ -351,7 +393,7 @@ export default function drawHands(winW: number, winH: number) {
         blackLineX = -0.01;
       } else if (numPlayers === 5) {
         blackLineX = -0.001;
-      } else if (numPlayers === 6) {
+      } else if (numPlayers >= 6) {
         blackLineX = -0.0025;
       } else {
         blackLineX = 0;
@@ -362,7 +404,7 @@ export default function drawHands(winW: number, winH: number) {
           blackLineX = handValues.w + 0.002;
         } else if (numPlayers === 5) {
           blackLineX = handValues.w - 0.005;
-        } else if (numPlayers === 6) {
+        } else if (numPlayers >= 6) {
           blackLineX = handValues.w - 0.005;
         } else {
           blackLineX = handValues.w;
@@ -402,7 +444,7 @@ export default function drawHands(winW: number, winH: number) {
           turnRectValues.w += handValues.w * 0.03;
           turnRectValues.offsetX += handValues.w * 0.015;
         }
-      } else if (numPlayers === 6) {
+      } else if (numPlayers >= 6) {
         turnRectValues.h += 0.005;
       }
       const turnRect = new Konva.Rect({
@@ -8,7 +8,7 @@ import * as deck from '../rules/deck';
 import * as stats from '../rules/stats';
 import * as variantRules from '../rules/variant';
 import { colorClue, rankClue } from '../types/Clue';
-import { STACK_BASE_RANK } from '../types/constants';
+import { MAX_STRIKES, STACK_BASE_RANK } from '../types/constants';
 import ReplayArrowOrder from '../types/ReplayArrowOrder';
 import * as arrows from './arrows';
 import backToLobby from './backToLobby';
@@ -841,6 +841,18 @@ const drawScoreArea = () => {
   globals.elements.cluesNumberLabelPulse.anim.addLayer(globals.layers.UI);
 
   // Draw the 3 strike (bomb) black squares / X's
+  // (the table creator can change the amount of strikes;
+  // if there are more than 3, the squares are shrunk to fit in the same space)
+  const numStrikes = globals.options.maxStrikes;
+  const strikeScale = MAX_STRIKES / Math.max(numStrikes, MAX_STRIKES);
+  const strikeY = 0.115 + (((1 - strikeScale) * 0.053) / 2);
+  const strikeOrders = [
+    ReplayArrowOrder.Strike1,
+    ReplayArrowOrder.Strike2,
+    ReplayArrowOrder.Strike3,
+    ReplayArrowOrder.Strike4,
+    ReplayArrowOrder.Strike5,
+  ];
   function strikeClick(this: StrikeSquare | StrikeX, event: Konva.KonvaEventObject<MouseEvent>) {
     switch (event.evt.button) {
       case 0: { // Left-click
@@ -863,14 +875,8 @@ const drawScoreArea = () => {
 
       case 2: { // Right-click
         // Right-clicking a strike X or a strike square shows an arrow over the strike square
-        let order;
-        if (this.num === 0) {
-          order = ReplayArrowOrder.Strike1;
-        } else if (this.num === 1) {
-          order = ReplayArrowOrder.Strike2;
-        } else if (this.num === 2) {
-          order = ReplayArrowOrder.Strike3;
-        } else {
+        const order = strikeOrders[this.num];
+        if (order === undefined) {
           throw new Error(`Unknown strike number of ${this.num}".`);
         }
 
@@ -885,15 +891,15 @@ const drawScoreArea = () => {
       }
     }
   }
-  for (let i = 0; i < 3; i++) {
+  for (let i = 0; i < numStrikes; i++) {
     // Draw the background square
     const strikeSquare = new StrikeSquare({
-      x: (0.01 + (0.04 * i)) * winW,
-      y: 0.115 * winH,
-      width: 0.03 * winW,
-      height: 0.053 * winH,
+      x: (0.01 + (0.04 * i * strikeScale)) * winW,
+      y: strikeY * winH,
+      width: 0.03 * strikeScale * winW,
+      height: 0.053 * strikeScale * winH,
       stroke: 'black',
-      cornerRadius: 0.005 * winW,
+      cornerRadius: 0.005 * strikeScale * winW,
       listening: true,
     }, i);
     globals.elements.scoreArea.add(strikeSquare);
@@ -901,10 +907,10 @@ const drawScoreArea = () => {
 
     // Draw the red X that indicates the strike
     const strikeX = new StrikeX({
-      x: (0.015 + (0.04 * i)) * winW,
-      y: 0.125 * winH,
-      width: 0.02 * winW,
-      height: 0.036 * winH,
+      x: (0.01 + (((0.04 * i) + 0.005) * strikeScale)) * winW,
+      y: (strikeY + (0.01 * strikeScale)) * winH,
+      width: 0.02 * strikeScale * winW,
+      height: 0.036 * strikeScale * winH,
       image: globals.imageLoader!.get('x')!,
       opacity: 0,
       listening: true,
@@ -916,9 +922,9 @@ const drawScoreArea = () => {
     if (variantRules.isThrowItInAHole(globals.variant) && globals.state.playing) {
       const questionMarkLabel = basicTextLabel.clone({
         text: '?',
-        fontSize: 0.032 * winH,
-        x: (0.0205 + (0.04 * i)) * winW,
-        y: 0.128 * winH,
+        fontSize: 0.032 * strikeScale * winH,
+        x: (0.01 + (((0.04 * i) + 0.0105) * strikeScale)) * winW,
+        y: (strikeY + (0.013 * strikeScale)) * winH,
         listening: false,
       }) as Konva.Text;
       globals.elements.scoreArea.add(questionMarkLabel);
@@ -940,20 +946,21 @@ const drawScoreArea = () => {
   }
 
   // The terminate button (which immediately ends the current game)
-  // This is placed on top of the 3rd strike
+  // This is placed on top of the final strike
   if (globals.state.playing) {
-    globals.elements.strikeSquares[2].hide();
-    globals.elements.strikeXs[2].hide();
-    const questionMarkLabel = globals.elements.questionMarkLabels[2];
+    const lastStrike = numStrikes - 1;
+    globals.elements.strikeSquares[lastStrike].hide();
+    globals.elements.strikeXs[lastStrike].hide();
+    const questionMarkLabel = globals.elements.questionMarkLabels[lastStrike];
     if (questionMarkLabel !== undefined) {
       questionMarkLabel.hide();
     }
 
     const terminateButton = new Button({
-      x: (0.01 + (0.04 * 2)) * winW,
-      y: 0.115 * winH,
-      width: 0.03 * winW,
-      height: 0.053 * winH,
+      x: (0.01 + (0.04 * lastStrike * strikeScale)) * winW,
+      y: strikeY * winH,
+      width: 0.03 * strikeScale * winW,
+      height: 0.053 * strikeScale * winH,
       visible: globals.state.playing,
     }, [globals.imageLoader!.get('skull')!]);
     globals.elements.scoreArea.add(terminateButton as any);
@@ -1315,6 +1322,7 @@ const drawStatistics = () => {
     globals.variant,
     globals.options.oneExtraCard,
     globals.options.oneLessCard,
+    globals.options.startingClues,
   );
   const efficiencyNumberLabelMinNeeded = basicNumberLabel.clone({
     text: minEfficiency.toFixed(2), // Convert it to a string and round to 2 decimal places
@@ -1574,6 +1582,10 @@ const drawClueArea = () => {
     if (totalPlayerButtons >= 5) {
       playerButtonW -= 0.01;
     }
+    if (totalPlayerButtons >= 6) {
+      // In 7-player and 8-player games, the buttons need to be even smaller to fit
+      playerButtonW -= 0.01 * (totalPlayerButtons - 5);
+    }
     let totalPlayerWidth = playerButtonW * totalPlayerButtons;
     totalPlayerWidth += playerButtonSpacing * (totalPlayerButtons - 1);
     let playerX = (clueAreaValues.w! * 0.5) - (totalPlayerWidth * 0.5);
@@ -6,7 +6,7 @@ import { createStore } from 'redux';
 import { initArray, trimReplaySuffixFromURL, parseIntSafe } from '../../misc';
 import * as sentry from '../../sentry';
 import Options from '../../types/Options';
-import { getVariant } from '../data/gameData';
+import { addCustomVariant, getVariant } from '../data/gameData';
 import initialState from '../reducers/initialStates/initialState';
 import stateReducer from '../reducers/stateReducer';
 import { GameAction, ActionIncludingHypothetical } from '../types/actions';
@@ -107,6 +107,39 @@ commands.set('hypothetical', (data: HypotheticalData) => {
   });
 });
 
+interface HypoLoadData {
+  segment: number;
+  drawnCardsShown: boolean;
+  actions: string[];
+}
+commands.set('hypoLoad', (data: HypoLoadData) => {
+  // The shared replay leader has switched to a saved hypothetical line
+  // Discard the current hypothetical (if any), go to the turn that the saved line starts from,
+  // and then play all of its actions
+  if (globals.state.replay.hypothetical !== null) {
+    globals.store!.dispatch({
+      type: 'hypoEnd',
+    });
+  }
+
+  globals.store!.dispatch({
+    type: 'replaySharedSegment',
+    segment: data.segment,
+  });
+
+  const actions: ActionIncludingHypothetical[] = [];
+  for (let i = 0; i < data.actions.length; i++) {
+    const action = JSON.parse(data.actions[i]) as ActionIncludingHypothetical;
+    actions.push(action);
+  }
+
+  globals.store!.dispatch({
+    type: 'hypoStart',
+    drawnCardsShown: data.drawnCardsShown,
+    actions,
+  });
+});
+
 commands.set('hypoAction', (data: string) => {
   const action = JSON.parse(data) as ActionIncludingHypothetical;
   globals.store!.dispatch({
@@ -287,7 +320,7 @@ interface GameActionListData {
 }
 commands.set('gameActionList', (data: GameActionListData) => {
   // The server has sent us the list of the game actions that have occurred in the game thus far
-  // (in response to the "getGameInfo2" command)
+  // (in response to the "getGameInfo2" command or after the players vote to take back a move)
   // Send this list to the reducers
   globals.store!.dispatch({
     type: 'gameActionList',
@@ -428,6 +461,7 @@ commands.set('spectators', (data: SpectatorsData) => {
 
 const initStateStore = (data: InitData) => {
   // Set the variant (as a helper reference)
+  addCustomVariant(data.options);
   globals.variant = getVariant(data.options.variantName);
 
   // Handle the special case of when players can be given assignments of "-1" during debugging
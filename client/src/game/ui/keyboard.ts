@@ -268,7 +268,11 @@ const keydown = (event: JQuery.KeyDownEvent) => {
   if (globals.state.ongoingGame.clueTokens >= clueTokensRules.getAdjusted(1, globals.variant)) {
     hotkeyFunction = hotkeyClueMap.get(event.key);
   }
-  if (!clueTokensRules.atMax(globals.state.ongoingGame.clueTokens, globals.variant)) {
+  if (!clueTokensRules.atMax(
+    globals.state.ongoingGame.clueTokens,
+    globals.variant,
+    globals.options.maxClues,
+  )) {
     hotkeyFunction = hotkeyFunction || hotkeyDiscardMap.get(event.key);
   }
   hotkeyFunction = hotkeyFunction || hotkeyPlayMap.get(event.key);
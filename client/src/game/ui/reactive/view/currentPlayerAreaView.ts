@@ -65,7 +65,7 @@ export const onChanged = (data: {
     if (clueTokens < clueTokensRules.getAdjusted(1, globals.variant)) {
       specialText = `(cannot clue; ${cluesTokensText} clues left)`;
       text3.fill('red');
-    } else if (clueTokensRules.atMax(clueTokens, globals.variant)) {
+    } else if (clueTokensRules.atMax(clueTokens, globals.variant, globals.options.maxClues)) {
       specialText = `(cannot discard; at ${cluesTokensText} clues)`;
       text3.fill(LABEL_COLOR);
     } else if (isLocked && globals.lobby.settings.hyphenatedConventions) {
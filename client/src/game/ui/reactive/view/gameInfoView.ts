@@ -1,5 +1,4 @@
 import { variantRules, clueTokensRules } from '../../../rules';
-import { MAX_STRIKES } from '../../../types/constants';
 import { StateStrike } from '../../../types/GameState';
 import { LABEL_COLOR, OFF_BLACK, STRIKE_FADE } from '../../constants';
 import globals from '../../globals';
@@ -109,7 +108,11 @@ export const onClueTokensChanged = (clueTokens: number) => {
   if (!globals.lobby.settings.realLifeMode) {
     const noCluesAvailable = clueTokens < clueTokensRules.getAdjusted(1, globals.variant);
     const oneClueAvailable = clueTokens === clueTokensRules.getAdjusted(1, globals.variant);
-    const maxCluesAvailable = clueTokensRules.atMax(clueTokens, globals.variant);
+    const maxCluesAvailable = clueTokensRules.atMax(
+      clueTokens,
+      globals.variant,
+      globals.options.maxClues,
+    );
 
     let fill;
     if (noCluesAvailable) {
@@ -145,7 +148,7 @@ export const onClueTokensOrDoubleDiscardChanged = (data: {
     return;
   }
 
-  if (clueTokensRules.atMax(data.clueTokens, globals.variant)) {
+  if (clueTokensRules.atMax(data.clueTokens, globals.variant, globals.options.maxClues)) {
     // Show the red border around the discard pile
     // (to reinforce that the current player cannot discard)
     globals.elements.noDiscardBorder?.show();
@@ -172,7 +175,7 @@ export const onOngoingOrVisibleStrikesChanged = (data: {
     return;
   }
 
-  for (let i = 0; i < MAX_STRIKES; i++) {
+  for (let i = 0; i < globals.options.maxStrikes; i++) {
     const strikeX = globals.elements.strikeXs[i];
     if (strikeX === undefined) {
       continue;
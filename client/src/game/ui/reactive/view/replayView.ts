@@ -213,11 +213,12 @@ export const onFinishedChanged = (finished: boolean, previousFinished: boolean |
   // Hide/show some buttons in the bottom-left-hand corner
   globals.elements.replayButton?.hide();
 
-  // Hide the terminate button and show the 3rd strike UI
+  // Hide the terminate button and show the final strike UI
   if (globals.elements.terminateButton !== null) {
+    const lastStrike = globals.elements.strikeSquares.length - 1;
     globals.elements.terminateButton?.hide();
-    globals.elements.strikeSquares[2].show();
-    globals.elements.strikeXs[2].show();
+    globals.elements.strikeSquares[lastStrike].show();
+    globals.elements.strikeXs[lastStrike].show();
   }
 
   // Re-draw the deck tooltip
@@ -29,6 +29,9 @@ export class Globals {
   shuttingDown: boolean = false;
   datetimeShutdownInit: number = 0;
   maintenanceMode: boolean = false;
+  // The ID of the stream of messages from the server, which is used to resume our session after a
+  // disconnect (0 if we have not received the "welcome" message yet)
+  streamID: number = 0;
 
   userMap: Map<number, User> = new Map<number, User>(); // Keys are IDs
   tableMap: Map<number, Table> = new Map<number, Table>(); // Keys are IDs
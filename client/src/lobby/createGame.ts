@@ -3,7 +3,7 @@
 import { SHUTDOWN_TIMEOUT } from '../constants';
 import * as debug from '../debug';
 import { VARIANTS } from '../game/data/gameData';
-import { DEFAULT_VARIANT_NAME } from '../game/types/constants';
+import { DEFAULT_VARIANT_NAME, MAX_CLUE_NUM, MAX_STRIKES } from '../game/types/constants';
 import globals from '../globals';
 import {
   closeAllTooltips,
@@ -13,6 +13,7 @@ import {
   parseIntSafe,
 } from '../misc';
 import * as modals from '../modals';
+import TimeControl from '../types/TimeControl';
 import Settings from './types/Settings';
 
 // Constants
@@ -52,6 +53,7 @@ export const init = () => {
       $('#create-game-timed-option-2').show();
       $('#create-game-timed-option-3').show();
       $('#create-game-timed-option-4').show();
+      $('#create-game-timed-row-2').show();
     } else {
       $('#create-game-timed-label').addClass('col-3');
       $('#create-game-timed-label').removeClass('col-2');
@@ -59,6 +61,7 @@ export const init = () => {
       $('#create-game-timed-option-2').hide();
       $('#create-game-timed-option-3').hide();
       $('#create-game-timed-option-4').hide();
+      $('#create-game-timed-row-2').hide();
     }
 
     // Redraw the tooltip so that the new elements will fit better
@@ -135,6 +138,12 @@ export const init = () => {
   $('#createTableTimePerTurnSeconds').change(() => {
     getTextboxForTimePerTurn('createTableTimePerTurnSeconds');
   });
+  $('#createTableTimeControl').change(() => {
+    getTextboxForInteger('createTableTimeControl', TimeControl.Increment);
+  });
+  $('#createTableTurnTimeLimitSeconds').change(() => {
+    getTextboxForInteger('createTableTurnTimeLimitSeconds', 0);
+  });
   $('#createTableCardCycle').change(() => {
     getCheckbox('createTableCardCycle');
   });
@@ -150,6 +159,15 @@ export const init = () => {
   $('#createTableDetrimentalCharacters').change(() => {
     getCheckbox('createTableDetrimentalCharacters');
   });
+  $('#createTableStartingClues').change(() => {
+    getTextboxForInteger('createTableStartingClues', MAX_CLUE_NUM);
+  });
+  $('#createTableMaxClues').change(() => {
+    getTextboxForInteger('createTableMaxClues', MAX_CLUE_NUM);
+  });
+  $('#createTableMaxStrikes').change(() => {
+    getTextboxForInteger('createTableMaxStrikes', MAX_STRIKES);
+  });
 
   // Pressing enter anywhere will submit the form
   $('#create-game-tooltip').on('keypress', (event) => {
@@ -280,6 +298,8 @@ const submit = () => {
       timed: getCheckbox('createTableTimed'),
       timeBase: timeBaseSeconds,
       timePerTurn: getTextboxForTimePerTurn('createTableTimePerTurnSeconds'),
+      timeControl: getTextboxForInteger('createTableTimeControl', TimeControl.Increment),
+      turnTimeLimit: getTextboxForInteger('createTableTurnTimeLimitSeconds', 0),
       speedrun: getCheckbox('createTableSpeedrun'),
       cardCycle: getCheckbox('createTableCardCycle'),
       deckPlays: getCheckbox('createTableDeckPlays'),
@@ -288,6 +308,9 @@ const submit = () => {
       oneLessCard: getCheckbox('createTableOneLessCard'),
       allOrNothing: getCheckbox('createTableAllOrNothing'),
       detrimentalCharacters: getCheckbox('createTableDetrimentalCharacters'),
+      startingClues: getTextboxForInteger('createTableStartingClues', MAX_CLUE_NUM),
+      maxClues: getTextboxForInteger('createTableMaxClues', MAX_CLUE_NUM),
+      maxStrikes: getTextboxForInteger('createTableMaxStrikes', MAX_STRIKES),
     },
     password,
     gameJSON,
@@ -362,6 +385,26 @@ const getTextboxForTimeBase = (setting: keyof Settings) => {
   return value;
 };
 
+const getTextboxForInteger = (setting: keyof Settings, defaultValue: number) => {
+  const element = $(`#${setting}`);
+  if (element === undefined) {
+    throw new Error(`Failed to get the element of "${setting}".`);
+  }
+
+  const valueString = getTextbox(setting);
+  let value = parseIntSafe(valueString);
+  if (Number.isNaN(value)) {
+    // They have entered an invalid number, so revert to using the default value
+    value = defaultValue;
+
+    // Also change the value of the actual element on the page
+    element.val(value.toString());
+  }
+
+  checkChanged(setting, value);
+  return value;
+};
+
 const getVariant = (setting: keyof Settings) => {
   const element = $(`#${setting}`);
   if (element === undefined) {
@@ -485,6 +528,9 @@ export const ready = () => {
     && !globals.settings.createTableOneLessCard
     && !globals.settings.createTableAllOrNothing
     && !globals.settings.createTableDetrimentalCharacters
+    && globals.settings.createTableStartingClues === MAX_CLUE_NUM
+    && globals.settings.createTableMaxClues === MAX_CLUE_NUM
+    && globals.settings.createTableMaxStrikes === MAX_STRIKES
   ) {
     $('#create-game-extra-options').hide();
     $('#create-game-show-extra-options-row').show();
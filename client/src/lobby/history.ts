@@ -1,9 +1,10 @@
 // The screens that show past games and other scores
 
 import { getVariant, VARIANTS } from '../game/data/gameData';
+import { MAX_CLUE_NUM, MAX_STRIKES } from '../game/types/constants';
 import Variant from '../game/types/Variant';
 import globals from '../globals';
-import { timerFormatter, dateTimeFormatter, parseIntSafe } from '../misc';
+import { timeControlFormatter, dateTimeFormatter, parseIntSafe } from '../misc';
 import Options from '../types/Options';
 import * as nav from './nav';
 import tablesDraw from './tablesDraw';
@@ -365,7 +366,12 @@ const makeOptions = (i: number, options: Options, otherScores: boolean) => {
 
   if (options.timed) {
     tooltipHTML += '<li><i class="fas fa-clock"></i>&nbsp; ';
-    tooltipHTML += `Timed (${timerFormatter(options.timeBase)} + ${timerFormatter(options.timePerTurn)})`;
+    tooltipHTML += `Timed (${timeControlFormatter(
+      options.timeBase,
+      options.timePerTurn,
+      options.timeControl,
+      options.turnTimeLimit,
+    )})`;
     tooltipHTML += '</li>';
   }
 
@@ -409,6 +415,21 @@ const makeOptions = (i: number, options: Options, otherScores: boolean) => {
     tooltipHTML += 'Detrimental Characters</li>';
   }
 
+  if (
+    options.startingClues !== MAX_CLUE_NUM
+    || options.maxClues !== MAX_CLUE_NUM
+    || options.maxStrikes !== MAX_STRIKES
+  ) {
+    tooltipHTML += '<li><i class="fas fa-bomb"></i>&nbsp; ';
+    tooltipHTML += `${options.startingClues} / ${options.maxClues} Clues, `;
+    tooltipHTML += `${options.maxStrikes} Strikes</li>`;
+  }
+
+  if (options.takeback) {
+    tooltipHTML += '<li><i class="fas fa-undo"></i>&nbsp; ';
+    tooltipHTML += 'Takeback Used</li>';
+  }
+
   if (tooltipHTML === '') {
     return '-';
   }
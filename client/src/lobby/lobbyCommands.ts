@@ -1,7 +1,8 @@
 // We will receive WebSocket messages / commands from the server that tell us to do things
 
+import { addCustomVariant } from '../game/data/gameData';
 import * as gameMain from '../game/main';
-import { DEFAULT_VARIANT_NAME } from '../game/types/constants';
+import { DEFAULT_VARIANT_NAME, MAX_CLUE_NUM, MAX_STRIKES } from '../game/types/constants';
 import * as spectatorsView from '../game/ui/reactive/view/spectatorsView';
 import globals from '../globals';
 import { trimReplaySuffixFromURL, parseIntSafe } from '../misc';
@@ -53,6 +54,7 @@ commands.set('friends', (data: FriendsData) => {
 
 commands.set('game', (data: Game) => {
   globals.game = data;
+  addCustomVariant(data.options);
   pregame.draw();
 });
 
@@ -60,6 +62,7 @@ commands.set('gameHistory', (dataArray: GameHistory[]) => {
   // data will be an array of all of the games that we have previously played
   for (const data of dataArray) {
     globals.history[data.id] = data;
+    addCustomVariant(data.options);
 
     if (data.incrementNumGames) {
       globals.totalGames += 1;
@@ -85,6 +88,7 @@ commands.set('gameHistoryFriends', (dataArray: GameHistory[]) => {
   // data will be an array of all of the games that our friends have previously played
   for (const data of dataArray) {
     globals.historyFriends[data.id] = data;
+    addCustomVariant(data.options);
   }
 
   // The server sent us more games because
@@ -263,8 +267,18 @@ interface WelcomeData {
   randomTableName: string;
   shuttingDown: boolean;
   maintenanceMode: boolean;
+  protocolVersion: number;
+  streamID: number;
 }
 commands.set('welcome', (data: WelcomeData) => {
+  // If we tried to resume our session after a disconnect and the server could not do it,
+  // then we have to start over in order to get back in sync
+  if (globals.streamID !== 0) {
+    window.location.reload();
+    return;
+  }
+  globals.streamID = data.streamID;
+
   // Store some variables (mostly relating to our user account)
   globals.userID = data.userID;
   globals.username = data.username; // We might have logged-in with a different stylization
@@ -296,9 +310,15 @@ commands.set('welcome', (data: WelcomeData) => {
     return;
   }
 
+  // Saved hypotheticals can only be viewed in a shared replay
+  // (e.g. "/shared-replay/123?hypothetical=5")
+  const urlParams = new URLSearchParams(window.location.search);
+  const hypotheticalIDString = urlParams.get('hypothetical');
+  const hypotheticalID = hypotheticalIDString === null ? 0 : parseIntSafe(hypotheticalIDString);
+
   // Automatically go into a replay if we are using a "/replay/123" URL
   const match1 = window.location.pathname.match(/\/replay\/(\d+)/);
-  if (match1) {
+  if (match1 && hypotheticalID <= 0) {
     setTimeout(() => {
       const gameID = parseIntSafe(match1[1]); // The server expects the game ID as an integer
       globals.conn!.send('replayCreate', {
@@ -312,7 +332,8 @@ commands.set('welcome', (data: WelcomeData) => {
   }
 
   // Automatically go into a shared replay if we are using a "/shared-replay/123" URL
-  const match2 = window.location.pathname.match(/\/shared-replay\/(\d+)/);
+  // (or a "/replay/123" URL with a hypothetical)
+  const match2 = window.location.pathname.match(/\/shared-replay\/(\d+)/) ?? match1;
   if (match2) {
     setTimeout(() => {
       const gameID = parseIntSafe(match2[1]); // The server expects the game ID as an integer
@@ -321,6 +342,7 @@ commands.set('welcome', (data: WelcomeData) => {
         source: 'id',
         visibility: 'shared',
         shadowingPlayerIndex: -1,
+        hypotheticalID: hypotheticalID > 0 ? hypotheticalID : undefined,
       });
     }, 10);
     return;
@@ -339,6 +361,10 @@ commands.set('welcome', (data: WelcomeData) => {
     const timeBase = parseIntSafe(timeBaseString);
     const timePerTurnString = urlParams.get('timePerTurn') ?? '20';
     const timePerTurn = parseIntSafe(timePerTurnString);
+    const timeControlString = urlParams.get('timeControl') ?? '0';
+    const timeControl = parseIntSafe(timeControlString);
+    const turnTimeLimitString = urlParams.get('turnTimeLimit') ?? '0';
+    const turnTimeLimit = parseIntSafe(turnTimeLimitString);
     const speedrun = urlParams.get('speedrun') === 'true';
     const cardCycle = urlParams.get('cardCycle') === 'true';
     const deckPlays = urlParams.get('deckPlays') === 'true';
@@ -347,6 +373,12 @@ commands.set('welcome', (data: WelcomeData) => {
     const oneLessCard = urlParams.get('oneLessCard') === 'true';
     const allOrNothing = urlParams.get('allOrNothing') === 'true';
     const detrimentalCharacters = urlParams.get('detrimentalCharacters') === 'true';
+    const startingCluesString = urlParams.get('startingClues') ?? MAX_CLUE_NUM.toString();
+    const startingClues = parseIntSafe(startingCluesString);
+    const maxCluesString = urlParams.get('maxClues') ?? MAX_CLUE_NUM.toString();
+    const maxClues = parseIntSafe(maxCluesString);
+    const maxStrikesString = urlParams.get('maxStrikes') ?? MAX_STRIKES.toString();
+    const maxStrikes = parseIntSafe(maxStrikesString);
     const password = urlParams.get('password') ?? '';
 
     setTimeout(() => {
@@ -357,6 +389,8 @@ commands.set('welcome', (data: WelcomeData) => {
           timed,
           timeBase,
           timePerTurn,
+          timeControl,
+          turnTimeLimit,
           speedrun,
           cardCycle,
           deckPlays,
@@ -365,6 +399,9 @@ commands.set('welcome', (data: WelcomeData) => {
           oneLessCard,
           allOrNothing,
           detrimentalCharacters,
+          startingClues,
+          maxClues,
+          maxStrikes,
         },
         password,
       });
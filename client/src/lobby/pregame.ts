@@ -2,8 +2,14 @@
 
 import * as chat from '../chat';
 import { getVariant } from '../game/data/gameData';
+import {
+  MAX_CLUE_NUM,
+  MAX_PLAYERS,
+  MAX_STRIKES,
+  MIN_PLAYERS,
+} from '../game/types/constants';
 import globals from '../globals';
-import { timerFormatter } from '../misc';
+import { timeControlFormatter } from '../misc';
 import * as tooltips from '../tooltips';
 import * as nav from './nav';
 import tablesDraw from './tablesDraw';
@@ -88,7 +94,7 @@ export const draw = () => {
   drawOptions();
 
   // Draw the player boxes
-  for (let i = 0; i <= 5; i++) {
+  for (let i = 0; i < MAX_PLAYERS; i++) {
     drawPlayerBox(i);
   }
 
@@ -120,9 +126,12 @@ const drawOptions = () => {
   if (globals.game.options.timed) {
     html += '<li><i id="lobby-pregame-options-timer" class="fas fa-clock" ';
     html += 'data-tooltip-content="#pregame-tooltip-timer"></i>&nbsp; (';
-    html += timerFormatter(globals.game.options.timeBase);
-    html += ' + ';
-    html += timerFormatter(globals.game.options.timePerTurn);
+    html += timeControlFormatter(
+      globals.game.options.timeBase,
+      globals.game.options.timePerTurn,
+      globals.game.options.timeControl,
+      globals.game.options.turnTimeLimit,
+    );
     html += ')</li>';
     html += `
       <div class="hidden">
@@ -231,6 +240,23 @@ const drawOptions = () => {
     `;
   }
 
+  const customLimits = globals.game.options.startingClues !== MAX_CLUE_NUM
+    || globals.game.options.maxClues !== MAX_CLUE_NUM
+    || globals.game.options.maxStrikes !== MAX_STRIKES;
+  if (customLimits) {
+    html += '<li><i id="lobby-pregame-options-limits" class="fas fa-bomb" ';
+    html += 'data-tooltip-content="#pregame-tooltip-limits"></i></li>';
+    html += `
+      <div class="hidden">
+        <div id="pregame-tooltip-limits" class="lobby-pregame-tooltip-icon">
+          The team starts with <strong>${globals.game.options.startingClues}</strong> clues,
+          can have up to <strong>${globals.game.options.maxClues}</strong> clues,
+          and loses at <strong>${globals.game.options.maxStrikes}</strong> strikes.
+        </div>
+      </div>
+    `;
+  }
+
   // Set the HTML
   const optionsTitleDiv = $('#lobby-pregame-options-title');
   const optionsText = html === '' ? '' : 'Options:';
@@ -270,6 +296,9 @@ const drawOptions = () => {
   if (globals.game.options.detrimentalCharacters) {
     $('#lobby-pregame-options-characters').tooltipster(tooltips.options);
   }
+  if (customLimits) {
+    $('#lobby-pregame-options-limits').tooltipster(tooltips.options);
+  }
 };
 
 const drawPlayerBox = (i: number) => {
@@ -299,8 +328,8 @@ const drawPlayerBox = (i: number) => {
   }
   html += '</strong></p>';
 
-  // There is not enough room to draw the full box for 6 players
-  if (numPlayers === 6) {
+  // There is not enough room to draw the full box for 6 or more players
+  if (numPlayers >= 6) {
     div.removeClass('col-2');
     div.addClass('lobby-pregame-col');
   } else {
@@ -368,7 +397,7 @@ const drawPlayerBox = (i: number) => {
           ${numPlayers}-player best score:
         </div>
         <div class="col-2 align-right padding0">
-          ${variantStats.bestScores[numPlayers - 2].score}
+          ${variantStats.bestScores[numPlayers - MIN_PLAYERS].score}
         </div>
       </div>
     `;
@@ -387,10 +416,10 @@ const drawPlayerBox = (i: number) => {
   `;
   const variant = getVariant(globals.game.options.variantName);
   const { maxScore } = variant;
-  for (let j = 2; j <= 6; j++) {
+  for (let j = MIN_PLAYERS; j <= MAX_PLAYERS; j++) {
     html += '<div class="row">';
     html += `<div class="col-6">${j}-player:</div>`;
-    const bestScoreObject = variantStats.bestScores[j - 2];
+    const bestScoreObject = variantStats.bestScores[j - MIN_PLAYERS];
     const bestScore = bestScoreObject.score;
     const bestScoreMod = bestScoreObject.modifier;
     html += '<div class="col-6">';
@@ -414,6 +443,8 @@ const drawPlayerBox = (i: number) => {
   `;
   if (!player.present) {
     html += '<p class="lobby-pregame-player-away"><strong>AWAY</strong></p>';
+  } else if (player.ready) {
+    html += '<p class="lobby-pregame-player-ready"><strong>READY</strong></p>';
   }
 
   div.html(html);
@@ -425,6 +456,7 @@ const drawPlayerBox = (i: number) => {
 export const enableStartGameButton = () => {
   // Enable or disable the "Start Game" button,
   // depending on if we are the game owner and enough players have joined
+  // (and everyone is ready, if the table requires it)
   $('#nav-buttons-pregame-start').addClass('disabled');
 
   if (globals.game === null) {
@@ -433,8 +465,9 @@ export const enableStartGameButton = () => {
 
   if (
     globals.game.owner === globals.userID
-    && globals.game.players.length >= 2
-    && globals.game.players.length <= 6
+    && globals.game.players.length >= MIN_PLAYERS
+    && globals.game.players.length <= MAX_PLAYERS
+    && (!globals.game.requireReady || globals.game.players.every((p) => p.ready))
   ) {
     $('#nav-buttons-pregame-start').removeClass('disabled');
   }
@@ -1,7 +1,8 @@
 // The lobby area that shows all of the current tables
 
+import { MAX_PLAYERS } from '../game/types/constants';
 import globals from '../globals';
-import { timerFormatter } from '../misc';
+import { timeControlFormatter } from '../misc';
 import * as modals from '../modals';
 import Screen from './types/Screen';
 import Table from './types/Table';
@@ -97,7 +98,12 @@ const tablesDraw = () => {
     // Column 4 - Timed
     let timed = 'No';
     if (table.timed) {
-      timed = `${timerFormatter(table.timeBase)} + ${timerFormatter(table.timePerTurn)}`;
+      timed = timeControlFormatter(
+        table.timeBase,
+        table.timePerTurn,
+        table.timeControl,
+        table.turnTimeLimit,
+      );
     }
     $('<td>').html(timed).appendTo(row);
 
@@ -126,7 +132,7 @@ const tablesDraw = () => {
     } else if (!table.joined) {
       button.html('<i class="fas fa-sign-in-alt lobby-button-icon"></i>');
       button.attr('id', `join-${table.id}`);
-      if (table.numPlayers >= 6) {
+      if (table.numPlayers >= MAX_PLAYERS) {
         button.addClass('disabled');
       }
       button.on('click', () => {
@@ -6,6 +6,7 @@ export default interface Game {
   players: Player[];
   options: Options;
   passwordProtected: boolean;
+  requireReady: boolean;
 }
 
 interface Player {
@@ -13,6 +14,7 @@ interface Player {
   name: string;
   you: boolean;
   present: boolean;
+  ready: boolean;
   stats: Stats;
 }
 
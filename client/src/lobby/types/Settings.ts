@@ -18,6 +18,8 @@ export default class Settings {
   createTableTimed: boolean = false;
   createTableTimeBaseMinutes: number = 2;
   createTableTimePerTurnSeconds: number = 10;
+  createTableTimeControl: number = 0;
+  createTableTurnTimeLimitSeconds: number = 0;
   createTableSpeedrun: boolean = false;
   createTableCardCycle: boolean = false;
   createTableDeckPlays: boolean = false;
@@ -26,4 +28,7 @@ export default class Settings {
   createTableOneLessCard: boolean = false;
   createTableAllOrNothing: boolean = false;
   createTableDetrimentalCharacters: boolean = false;
+  createTableStartingClues: number = 8;
+  createTableMaxClues: number = 8;
+  createTableMaxStrikes: number = 3;
 }
@@ -1,3 +1,5 @@
+import TimeControl from '../../types/TimeControl';
+
 export default interface Table {
   id: number;
   name: string;
@@ -10,6 +12,8 @@ export default interface Table {
   timed: boolean;
   timeBase: number;
   timePerTurn: number;
+  timeControl: TimeControl;
+  turnTimeLimit: number;
   ourTurn: boolean;
   sharedReplay: boolean;
   progress: number;
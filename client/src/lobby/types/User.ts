@@ -7,4 +7,5 @@ export default interface User {
   tableID: number;
   hyphenated: boolean;
   inactive: boolean;
+  bot: boolean;
 }
@@ -85,6 +85,10 @@ const drawUser = (
     nameColumn += 'data-tooltip-content="#hyphenated-tooltip">';
     nameColumn += '<i class="fas fa-heading fa-xs"></i></span>&nbsp; ';
   }
+  if (user.bot) {
+    nameColumn += '<span title="This is a bot account.">';
+    nameColumn += '<i class="fas fa-robot fa-xs"></i></span>&nbsp; ';
+  }
   nameColumn += `<span id="online-users-${userID}">`;
   if (username === globals.username) {
     nameColumn += '<strong>';
@@ -4,6 +4,8 @@
 
 // A collection of miscellaneous functions
 
+import TimeControl from './types/TimeControl';
+
 // init is executed when the document is ready
 export const init = () => {
   // Add a function to the jQuery object to detect if an element is off screen
@@ -118,6 +120,27 @@ export const timerFormatter = (totalSeconds: number) => {
   return `${minutes}:${secondsFormatted}`;
 };
 
+// Describes the time controls of a timed game, e.g. "2:00 + 0:20"
+export const timeControlFormatter = (
+  timeBase: number,
+  timePerTurn: number,
+  timeControl: TimeControl,
+  turnTimeLimit: number,
+) => {
+  let text = timerFormatter(timeBase);
+  if (timeControl === TimeControl.Delay) {
+    text += ` (${timerFormatter(timePerTurn)} delay)`;
+  } else if (timeControl === TimeControl.Hourglass) {
+    text += ' (hourglass)';
+  } else {
+    text += ` + ${timerFormatter(timePerTurn)}`;
+  }
+  if (turnTimeLimit > 0) {
+    text += `, ${timerFormatter(turnTimeLimit)} max per turn`;
+  }
+  return text;
+};
+
 // Remove any replay suffixes from the URL without reloading the page, if any
 export const trimReplaySuffixFromURL = () => {
   let finalCharacterIndex;
@@ -1,4 +1,6 @@
-import { DEFAULT_VARIANT_NAME } from '../game/types/constants';
+import { VariantJSON } from '../game/data/variantsInit';
+import { DEFAULT_VARIANT_NAME, MAX_CLUE_NUM, MAX_STRIKES } from '../game/types/constants';
+import TimeControl from './TimeControl';
 
 export default class Options {
   readonly numPlayers: number = 0;
@@ -7,6 +9,8 @@ export default class Options {
   readonly timed: boolean = false;
   readonly timeBase: number = 0;
   readonly timePerTurn: number = 0;
+  readonly timeControl: TimeControl = TimeControl.Increment;
+  readonly turnTimeLimit: number = 0; // In seconds (0 means that there is no limit)
   readonly speedrun: boolean = false;
   readonly cardCycle: boolean = false;
   readonly deckPlays: boolean = false;
@@ -15,4 +19,11 @@ export default class Options {
   readonly oneLessCard: boolean = false;
   readonly allOrNothing: boolean = false;
   readonly detrimentalCharacters: boolean = false;
+  readonly startingClues: number = MAX_CLUE_NUM;
+  readonly maxClues: number = MAX_CLUE_NUM;
+  readonly maxStrikes: number = MAX_STRIKES;
+  // Set by the server if the players voted to take back a move during the game
+  readonly takeback: boolean = false;
+  // Only specified for variants that were built by the creator of the table
+  readonly customVariant?: VariantJSON;
 }
@@ -0,0 +1,8 @@
+// How players gain time over the course of a timed game
+// (this must be kept in sync with the "TimeControl" constants in the server)
+enum TimeControl {
+  Increment, // Players gain "timePerTurn" seconds after every move
+  Delay, // The first "timePerTurn" seconds of every move are not deducted from the clock
+  Hourglass, // The time that a player spends is split between the other players
+}
+export default TimeControl;
@@ -3,6 +3,7 @@
 
 import commands from './commands';
 import Connection from './Connection';
+import { PROTOCOL_VERSION } from './constants';
 import gameCommands from './game/ui/gameCommands';
 import globals from './globals';
 import lobbyCommands from './lobby/lobbyCommands';
@@ -34,19 +35,47 @@ export default function websocketInit() {
   }
   websocketURL += '/ws';
 
+  // Ask the server to validate every command that we send (see "protocol.go" on the server)
+  websocketURL += `?protocolVersion=${PROTOCOL_VERSION}`;
+
   // Connect to the WebSocket server
   // This will automatically use the cookie that we received earlier from the POST
-  // If the second argument is true, debugging is turned on
   console.log('Connecting to websocket URL:', websocketURL);
+  connect(websocketURL, websocketURL);
+}
+
+// If the connection drops, we try to resume our session a few times before giving up
+// (the server keeps our session for 30 seconds; see "websocket_resume.go" on the server)
+const MAX_RESUME_ATTEMPTS = 10;
+const RESUME_DELAY = 2000; // In milliseconds
+let resumeAttempts = 0;
+
+const connect = (websocketURL: string, baseURL: string, lastSeq: number = 0) => {
+  // If the second argument is true, debugging is turned on
   const conn = new Connection(websocketURL, true);
+  conn.lastSeq = lastSeq;
 
   // Define event handlers
   conn.on('open', () => {
     // We will show the lobby upon receiving the "welcome" command from the server
+    // (or we will continue where we left off upon receiving the "resumed" command)
     console.log('WebSocket connection established.');
   });
   conn.on('close', () => {
     console.log('WebSocket connection disconnected / closed.');
+    if (
+      !conn.closedByClient
+      && globals.streamID !== 0
+      && resumeAttempts < MAX_RESUME_ATTEMPTS
+    ) {
+      resumeAttempts += 1;
+      setTimeout(() => {
+        console.log(`Attempting to resume the session (attempt ${resumeAttempts}).`);
+        const resumeURL = `${baseURL}&resume=${globals.streamID}&lastSeq=${conn.lastSeq}`;
+        connect(resumeURL, baseURL, conn.lastSeq);
+      }, RESUME_DELAY);
+      return;
+    }
     modals.errorShow('Disconnected from the server. Either your Internet hiccuped or the server restarted.');
   });
   conn.on('socketError', (event: Event) => {
@@ -54,9 +83,19 @@ export default function websocketInit() {
     // the WebSocket "onerror" event
     console.error('WebSocket error:', event);
   });
+  conn.on('resumed', (data: ResumedData) => {
+    // The messages that we missed will follow
+    console.log(`Resumed the session and received ${data.numMissed} missed message(s).`);
+    resumeAttempts = 0;
+  });
 
   initCommands(conn);
   globals.conn = conn;
+};
+
+interface ResumedData {
+  streamID: number;
+  numMissed: number;
 }
 
 // We specify a callback for each command/message that we expect to receive from the server
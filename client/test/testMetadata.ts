@@ -6,11 +6,13 @@ import Options from '../src/types/Options';
 export default function testMetadata(
   numPlayers: number,
   variantName: string = DEFAULT_VARIANT_NAME,
+  options: Partial<Options> = {},
 ): GameMetadata {
   return {
     ourUsername: 'Alice',
     options: {
       ...(new Options()),
+      ...options,
       numPlayers,
       variantName,
     },
@@ -39,7 +39,19 @@ CREATE TABLE users (
      */
     password_hash        TEXT         NULL, /* An Argon2id hash */
     old_password_hash    TEXT         NULL, /* A SHA-256 hash */
+    /* Bot accounts do not have a password; they log in with an API token instead */
+    bot                  BOOLEAN      NOT NULL  DEFAULT FALSE,
+    /*
+     * A SHA-256 hash of the API token for bot accounts
+     * (API tokens are randomly generated, so a fast hash is sufficient)
+     */
+    api_token_hash       TEXT         NULL      UNIQUE,
     last_ip              TEXT         NOT NULL,
+    /*
+     * Deleted accounts are anonymized instead of being removed so that the games that they played
+     * in are kept (see the "Anonymize()" function)
+     */
+    deleted              BOOLEAN      NOT NULL  DEFAULT FALSE,
     datetime_created     TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
     datetime_last_login  TIMESTAMPTZ  NOT NULL  DEFAULT NOW()
 );
@@ -65,6 +77,8 @@ CREATE TABLE user_settings (
     create_table_timed                   BOOLEAN   NOT NULL  DEFAULT FALSE,
     create_table_time_base_minutes       FLOAT     NOT NULL  DEFAULT 2,
     create_table_time_per_turn_seconds   INTEGER   NOT NULL  DEFAULT 20,
+    create_table_time_control            SMALLINT  NOT NULL  DEFAULT 0,
+    create_table_turn_time_limit_seconds INTEGER   NOT NULL  DEFAULT 0,
     create_table_speedrun                BOOLEAN   NOT NULL  DEFAULT FALSE,
     create_table_card_cycle              BOOLEAN   NOT NULL  DEFAULT FALSE,
     create_table_deck_plays              BOOLEAN   NOT NULL  DEFAULT FALSE,
@@ -73,6 +87,9 @@ CREATE TABLE user_settings (
     create_table_one_less_card           BOOLEAN   NOT NULL  DEFAULT FALSE,
     create_table_all_or_nothing          BOOLEAN   NOT NULL  DEFAULT FALSE,
     create_table_detrimental_characters  BOOLEAN   NOT NULL  DEFAULT FALSE,
+    create_table_starting_clues          SMALLINT  NOT NULL  DEFAULT 8,
+    create_table_max_clues               SMALLINT  NOT NULL  DEFAULT 8,
+    create_table_max_strikes             SMALLINT  NOT NULL  DEFAULT 3,
     FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
 );
 
@@ -94,12 +111,45 @@ CREATE TABLE user_stats (
     best_score5_mod  SMALLINT  NOT NULL  DEFAULT 0,
     best_score6      SMALLINT  NOT NULL  DEFAULT 0,
     best_score6_mod  SMALLINT  NOT NULL  DEFAULT 0,
+    best_score7      SMALLINT  NOT NULL  DEFAULT 0,
+    best_score7_mod  SMALLINT  NOT NULL  DEFAULT 0,
+    best_score8      SMALLINT  NOT NULL  DEFAULT 0,
+    best_score8_mod  SMALLINT  NOT NULL  DEFAULT 0,
     average_score    FLOAT     NOT NULL  DEFAULT 0,
     num_strikeouts   INTEGER   NOT NULL  DEFAULT 0,
     FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
     PRIMARY KEY (user_id, variant_id)
 );
 
+/*
+ * Ratings are updated at the end of every rated game
+ * They can be recalculated from the game history with the "rebuildRatings.sh" script
+ */
+DROP TABLE IF EXISTS user_ratings CASCADE;
+CREATE TABLE user_ratings (
+    user_id        INTEGER   NOT NULL,
+    /* Each player has a separate rating for each class of variant (see "ratings.go") */
+    variant_class  SMALLINT  NOT NULL,
+    rating         FLOAT     NOT NULL  DEFAULT 1500,
+    /* The number of rated games that they have played in the variant class */
+    num_games      INTEGER   NOT NULL  DEFAULT 0,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, variant_class)
+);
+
+/* A team is a specific group of players, regardless of the order that they sat in */
+DROP TABLE IF EXISTS team_ratings CASCADE;
+CREATE TABLE team_ratings (
+    /* The IDs of the players, sorted from lowest to highest */
+    user_ids       INTEGER[]  NOT NULL,
+    variant_class  SMALLINT   NOT NULL,
+    rating         FLOAT      NOT NULL  DEFAULT 1500,
+    /* The number of rated games that the team has played together in the variant class */
+    num_games      INTEGER    NOT NULL  DEFAULT 0,
+    PRIMARY KEY (user_ids, variant_class)
+);
+CREATE INDEX team_ratings_index_user_ids ON team_ratings USING GIN (user_ids);
+
 DROP TABLE IF EXISTS user_friends CASCADE;
 CREATE TABLE user_friends (
     user_id    INTEGER  NOT NULL,
@@ -118,6 +168,19 @@ CREATE TABLE user_reverse_friends (
     PRIMARY KEY (user_id, friend_id)
 );
 
+/*
+ * Custom variants are built by the creator of a table from the suits in the "suits.json" file
+ * Games that were played on a custom variant have a negative "variant_id" in the "games" table
+ * (e.g. a variant ID of -3 corresponds to the custom variant with an ID of 3)
+ */
+DROP TABLE IF EXISTS custom_variants CASCADE;
+CREATE TABLE custom_variants (
+    id                SERIAL       PRIMARY KEY,
+    /* The JSON definition of the variant (in the same format as the "variants.json" file) */
+    definition        TEXT         NOT NULL  UNIQUE,
+    datetime_created  TIMESTAMPTZ  NOT NULL  DEFAULT NOW()
+);
+
 DROP TABLE IF EXISTS games CASCADE;
 CREATE TABLE games (
     id                      SERIAL       PRIMARY KEY,
@@ -128,11 +191,18 @@ CREATE TABLE games (
      * This field is only needed for legacy games before April 2020
      */
     starting_player         SMALLINT     NOT NULL  DEFAULT 0,
-    /* The ID for a particular variant can be found in the "variants.json" file */
-    variant_id              SMALLINT     NOT NULL,
+    /*
+     * The ID for a particular variant can be found in the "variants.json" file
+     * (or in the "custom_variants" table if it is negative)
+     * (this is not a SMALLINT so that there is room for every custom variant)
+     */
+    variant_id              INTEGER      NOT NULL,
     timed                   BOOLEAN      NOT NULL,
     time_base               INTEGER      NOT NULL, /* in seconds */
     time_per_turn           INTEGER      NOT NULL, /* in seconds */
+    /* See the "TimeControl" values in "constants.go" */
+    time_control            SMALLINT     NOT NULL  DEFAULT 0,
+    turn_time_limit         INTEGER      NOT NULL  DEFAULT 0, /* in seconds (0 means no limit) */
     speedrun                BOOLEAN      NOT NULL,
     card_cycle              BOOLEAN      NOT NULL,
     deck_plays              BOOLEAN      NOT NULL,
@@ -141,13 +211,24 @@ CREATE TABLE games (
     one_less_card           BOOLEAN      NOT NULL,
     all_or_nothing          BOOLEAN      NOT NULL,
     detrimental_characters  BOOLEAN      NOT NULL,
+    /* The clue and strike limits can be changed by the table creator */
+    starting_clues          SMALLINT     NOT NULL  DEFAULT 8,
+    max_clues               SMALLINT     NOT NULL  DEFAULT 8,
+    max_strikes             SMALLINT     NOT NULL  DEFAULT 3,
+    /* Set if the players voted to take back a move, which excludes the game from best scores */
+    takeback                BOOLEAN      NOT NULL  DEFAULT FALSE,
     seed                    TEXT         NOT NULL, /* e.g. "p2v0s1" */
     score                   SMALLINT     NOT NULL,
     num_turns               SMALLINT     NOT NULL,
     /* See the "endCondition" values in "constants.go" */
     end_condition           SMALLINT     NOT NULL,
     datetime_started        TIMESTAMPTZ  NOT NULL,
-    datetime_finished       TIMESTAMPTZ  NOT NULL
+    datetime_finished       TIMESTAMPTZ  NOT NULL,
+    /*
+     * True if at least one of the players was a bot account
+     * (these games are excluded from the stats for humans)
+     */
+    bot                     BOOLEAN      NOT NULL  DEFAULT FALSE
 );
 CREATE INDEX games_index_num_players ON games (num_players);
 CREATE INDEX games_index_variant_id  ON games (variant_id);
@@ -211,12 +292,66 @@ CREATE TABLE game_tags (
     CONSTRAINT game_tags_unique UNIQUE (game_id, tag)
 );
 
+/* Commentary that reviewers left on specific turns of a game from a shared replay */
+DROP TABLE IF EXISTS game_annotations CASCADE;
+CREATE TABLE game_annotations (
+    id                SERIAL       PRIMARY KEY,
+    game_id           INTEGER      NOT NULL,
+    user_id           INTEGER      NOT NULL,
+    turn              SMALLINT     NOT NULL, /* Starts at 1 (in the same way as the client) */
+    annotation        TEXT         NOT NULL,
+    datetime_created  TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
+    FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX game_annotations_index_game_id_turn ON game_annotations (game_id, turn);
+
+/*
+ * Hypothetical lines that were saved during a shared replay
+ * Each line can be forked from another saved line, so the lines for a game form a tree
+ */
+DROP TABLE IF EXISTS game_hypotheticals CASCADE;
+CREATE TABLE game_hypotheticals (
+    id                 SERIAL       PRIMARY KEY,
+    game_id            INTEGER      NOT NULL,
+    /* The saved line that this line was forked from (NULL if it was started from the real game) */
+    parent_id          INTEGER      NULL      DEFAULT NULL,
+    name               TEXT         NOT NULL,
+    /* The shared replay segment that the hypothetical starts from */
+    segment            SMALLINT     NOT NULL,
+    drawn_cards_shown  BOOLEAN      NOT NULL  DEFAULT FALSE,
+    /* A JSON array of the hypothetical actions (in the same format that the client sends them) */
+    actions            TEXT         NOT NULL,
+    datetime_created   TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
+    datetime_updated   TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
+    FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES game_hypotheticals (id) ON DELETE SET NULL,
+    CONSTRAINT game_hypotheticals_unique UNIQUE (game_id, name)
+);
+
 DROP TABLE IF EXISTS seeds CASCADE;
 CREATE TABLE seeds (
     seed       TEXT     NOT NULL  PRIMARY KEY,
     num_games  INTEGER  NOT NULL
 );
 
+/*
+ * The results of the perfect-information solver for a particular seed
+ * (unlike the "seeds" table, a row can exist for a seed that has not been played yet)
+ */
+DROP TABLE IF EXISTS seed_analyses CASCADE;
+CREATE TABLE seed_analyses (
+    seed               TEXT         NOT NULL  PRIMARY KEY,
+    /* The highest score that the solver was able to reach with every card visible */
+    max_score          SMALLINT     NOT NULL,
+    /*
+     * False if the solver gave up before exploring every line of play,
+     * in which case "max_score" is only a lower bound
+     */
+    complete           BOOLEAN      NOT NULL,
+    datetime_analyzed  TIMESTAMPTZ  NOT NULL  DEFAULT NOW()
+);
+
 DROP TABLE IF EXISTS variant_stats CASCADE;
 CREATE TABLE variant_stats (
     /* The ID for a particular variant can be found in the "variants.json" file */
@@ -228,11 +363,100 @@ CREATE TABLE variant_stats (
     best_score4     SMALLINT  NOT NULL  DEFAULT 0,
     best_score5     SMALLINT  NOT NULL  DEFAULT 0,
     best_score6     SMALLINT  NOT NULL  DEFAULT 0,
+    best_score7     SMALLINT  NOT NULL  DEFAULT 0,
+    best_score8     SMALLINT  NOT NULL  DEFAULT 0,
     num_max_scores  INTEGER   NOT NULL  DEFAULT 0,
     average_score   FLOAT     NOT NULL  DEFAULT 0,
     num_strikeouts  INTEGER   NOT NULL  DEFAULT 0
 );
 
+/*
+ * A new challenge is published every day at midnight UTC
+ * The seed for each player count is based on the date (e.g. "p2v0sd20501231")
+ */
+DROP TABLE IF EXISTS daily_challenges CASCADE;
+CREATE TABLE daily_challenges (
+    date        DATE      PRIMARY KEY,
+    /* The ID for a particular variant can be found in the "variants.json" file */
+    variant_id  SMALLINT  NOT NULL
+);
+
+/*
+ * Tournaments are run by the administrators with the "tournament.sh" script
+ * Every team in a tournament plays the same seed in each round
+ */
+DROP TABLE IF EXISTS tournaments CASCADE;
+CREATE TABLE tournaments (
+    id                 SERIAL       PRIMARY KEY,
+    name               TEXT         NOT NULL  UNIQUE,
+    /* The ID for a particular variant can be found in the "variants.json" file */
+    variant_id         SMALLINT     NOT NULL,
+    num_players        SMALLINT     NOT NULL,
+    datetime_created   TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
+    datetime_finished  TIMESTAMPTZ  NULL      DEFAULT NULL
+);
+
+DROP TABLE IF EXISTS tournament_teams CASCADE;
+CREATE TABLE tournament_teams (
+    id             SERIAL   PRIMARY KEY,
+    tournament_id  INTEGER  NOT NULL,
+    name           TEXT     NOT NULL,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE CASCADE,
+    CONSTRAINT tournament_teams_unique UNIQUE (tournament_id, name)
+);
+
+DROP TABLE IF EXISTS tournament_team_members CASCADE;
+CREATE TABLE tournament_team_members (
+    team_id  INTEGER  NOT NULL,
+    user_id  INTEGER  NOT NULL,
+    FOREIGN KEY (team_id) REFERENCES tournament_teams (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, user_id)
+);
+
+DROP TABLE IF EXISTS tournament_rounds CASCADE;
+CREATE TABLE tournament_rounds (
+    tournament_id     INTEGER      NOT NULL,
+    round             SMALLINT     NOT NULL, /* Starts at 1 */
+    seed              TEXT         NOT NULL, /* e.g. "p2v0st1r1" */
+    datetime_started  TIMESTAMPTZ  NOT NULL  DEFAULT NOW(),
+    /* Other participants cannot watch the games on the seed until the round is closed */
+    datetime_closed   TIMESTAMPTZ  NULL      DEFAULT NULL,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE CASCADE,
+    PRIMARY KEY (tournament_id, round)
+);
+CREATE INDEX tournament_rounds_index_seed ON tournament_rounds (seed);
+
+/* Each team gets one game per round */
+DROP TABLE IF EXISTS tournament_games CASCADE;
+CREATE TABLE tournament_games (
+    tournament_id  INTEGER   NOT NULL,
+    round          SMALLINT  NOT NULL,
+    team_id        INTEGER   NOT NULL,
+    game_id        INTEGER   NOT NULL,
+    FOREIGN KEY (tournament_id, round) REFERENCES tournament_rounds (tournament_id, round) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES tournament_teams (id) ON DELETE CASCADE,
+    FOREIGN KEY (game_id) REFERENCES games (id) ON DELETE CASCADE,
+    PRIMARY KEY (tournament_id, round, team_id)
+);
+
+/*
+ * The standings are recalculated from the "tournament_games" table every time that a round closes
+ * Teams are ranked by their total score, with ties broken by the fewest total turns
+ */
+DROP TABLE IF EXISTS tournament_standings CASCADE;
+CREATE TABLE tournament_standings (
+    tournament_id  INTEGER   NOT NULL,
+    team_id        INTEGER   NOT NULL,
+    place          SMALLINT  NOT NULL, /* Starts at 1; tied teams share the same place */
+    num_games      SMALLINT  NOT NULL,
+    total_score    INTEGER   NOT NULL,
+    total_turns    INTEGER   NOT NULL,
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES tournament_teams (id) ON DELETE CASCADE,
+    PRIMARY KEY (tournament_id, team_id)
+);
+
 DROP TABLE IF EXISTS chat_log CASCADE;
 CREATE TABLE chat_log (
     id             SERIAL       PRIMARY KEY,
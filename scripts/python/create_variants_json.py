@@ -623,6 +623,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "alternatingClues": True,
             }
         )
     for [suit_name, suit] in suits.items():
@@ -643,6 +644,7 @@ def main():
                     "name": variant_name,
                     "id": get_variant_id(variant_name),
                     "suits": variant_suits[suit_num - 1] + [suit_name],
+                    "alternatingClues": True,
                 }
             )
 
@@ -654,6 +656,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "clueStarved": True,
             }
         )
     for [suit_name, suit] in suits.items():
@@ -672,6 +675,7 @@ def main():
                     "name": variant_name,
                     "id": get_variant_id(variant_name),
                     "suits": variant_suits[suit_num - 1] + [suit_name],
+                    "clueStarved": True,
                 }
             )
 
@@ -683,6 +687,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "cowAndPig": True,
             }
         )
 
@@ -694,6 +699,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "duck": True,
             }
         )
 
@@ -705,6 +711,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "throwItInAHole": True,
             }
         )
     for [suit_name, suit] in suits.items():
@@ -724,6 +731,7 @@ def main():
                     "name": variant_name,
                     "id": get_variant_id(variant_name),
                     "suits": variant_suits[suit_num - 1] + [suit_name],
+                    "throwItInAHole": True,
                 }
             )
 
@@ -776,6 +784,7 @@ def main():
                 "name": variant_name,
                 "id": get_variant_id(variant_name),
                 "suits": variant_suits[suit_num],
+                "upOrDown": True,
                 "showSuitNames": True,
             }
         )
@@ -796,6 +805,7 @@ def main():
                     "name": variant_name,
                     "id": get_variant_id(variant_name),
                     "suits": variant_suits[suit_num - 1] + [suit_name],
+                    "upOrDown": True,
                     "showSuitNames": True,
                 }
             )
@@ -0,0 +1,57 @@
+package main
+
+// Players can delete their account with the "/deleteaccount" command
+// (or an administrator can delete it for them from the localhost server)
+// Their games are kept so that the replays of their teammates still work,
+// but their seat is shown with a placeholder name instead of their username
+
+import (
+	"os"
+	"strconv"
+)
+
+// getDeletedUserName returns the name that replaces the username of a deleted account
+// Real usernames cannot contain whitespace, so it can never collide with an existing user
+func getDeletedUserName(userID int) string {
+	return "Deleted Player " + strconv.Itoa(userID)
+}
+
+// deleteAccount returns a message explaining why the account could not be deleted,
+// or an empty string if it was deleted
+func deleteAccount(userID int, username string) (string, error) {
+	// The game would be written to the database with the placeholder name in the middle of it,
+	// so we make them finish it first
+	tablesMutex.RLock()
+	for _, t := range tables {
+		if !t.Replay && t.Running && t.GetPlayerIndexFromID(userID) != -1 {
+			tablesMutex.RUnlock()
+			return "The account cannot be deleted while it is playing in a game.", nil
+		}
+	}
+	tablesMutex.RUnlock()
+
+	// The archive would otherwise be written with data that is about to be removed
+	accountExportsMutex.Lock()
+	_, exporting := accountExportsInProgress[userID]
+	accountExportsMutex.Unlock()
+	if exporting {
+		return "The account cannot be deleted while its archive is being generated.", nil
+	}
+
+	if err := models.Users.Anonymize(userID, getDeletedUserName(userID)); err != nil {
+		return "", err
+	}
+
+	filePath := accountExportGetPath(userID)
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		logger.Error("Failed to remove the archive \""+filePath+"\":", err)
+	}
+
+	logger.Info("Deleted the account for user \"" + username + "\" " +
+		"(user ID " + strconv.Itoa(userID) + ").")
+
+	// Their cookie will be rejected when they try to reconnect (see "httpWS()")
+	logoutUser(userID)
+
+	return "", nil
+}
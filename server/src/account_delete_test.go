@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+func TestGetDeletedUserName(t *testing.T) {
+	name := getDeletedUserName(123)
+	if name != getDeletedUserName(123) {
+		t.Error("The placeholder name of a user is not stable.")
+	}
+	if name == getDeletedUserName(124) {
+		t.Error("Two users have the same placeholder name.")
+	}
+
+	// Real usernames cannot contain whitespace (see "httpLogin()")
+	if strings.IndexFunc(name, unicode.IsSpace) == -1 {
+		t.Errorf("The placeholder name of \"%s\" could also be a real username.", name)
+	}
+}
+
+func TestDeleteAccountWhilePlaying(t *testing.T) {
+	// (the database is not needed, since the account must not be touched)
+	table := testAddTable(t)
+	table.Running = true
+	table.Players = append(table.Players, &Player{
+		ID:   123,
+		Name: "Alice",
+	})
+
+	if msg, err := deleteAccount(123, "Alice"); err != nil {
+		t.Fatal("Failed to delete the account:", err)
+	} else if msg == "" {
+		t.Error("An account was deleted while it was playing in a game.")
+	}
+}
+
+func TestDeleteAccountWhileExporting(t *testing.T) {
+	accountExportsMutex.Lock()
+	accountExportsInProgress[123] = struct{}{}
+	accountExportsMutex.Unlock()
+	t.Cleanup(func() {
+		accountExportsMutex.Lock()
+		delete(accountExportsInProgress, 123)
+		accountExportsMutex.Unlock()
+	})
+
+	if msg, err := deleteAccount(123, "Alice"); err != nil {
+		t.Fatal("Failed to delete the account:", err)
+	} else if msg == "" {
+		t.Error("An account was deleted while its archive was being generated.")
+	}
+}
+
+// testInsertUser creates a user with a name that will not collide with other tests
+func testInsertUser(t *testing.T, prefix string) User {
+	t.Helper()
+
+	username := prefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+	var user User
+	if v, err := models.Users.Insert(
+		username,
+		normalizeString(username),
+		"hash",
+		"127.0.0.1",
+	); err != nil {
+		t.Fatal("Failed to insert the user:", err)
+	} else {
+		user = v
+	}
+
+	t.Cleanup(func() {
+		if _, err := db.Exec(context.Background(), `
+			DELETE FROM users WHERE id = $1
+		`, user.ID); err != nil {
+			t.Error("Failed to delete the user:", err)
+		}
+	})
+
+	return user
+}
+
+func TestUsersAnonymize(t *testing.T) {
+	testInitDatabase(t)
+
+	alice := testInsertUser(t, "alice")
+	bob := testInsertUser(t, "bob")
+
+	if err := models.UserSettings.Set(alice.ID, "volume", "10"); err != nil {
+		t.Fatal("Failed to set the setting:", err)
+	}
+	if err := models.UserFriends.Insert(alice.ID, bob.ID); err != nil {
+		t.Fatal("Failed to insert the friend:", err)
+	}
+	if err := models.UserFriends.Insert(bob.ID, alice.ID); err != nil {
+		t.Fatal("Failed to insert the friend:", err)
+	}
+	if err := models.ChatLog.Insert(alice.ID, "hello", "lobby"); err != nil {
+		t.Fatal("Failed to insert the chat message:", err)
+	}
+	if err := models.ChatLogPM.Insert(bob.ID, "hello", alice.ID); err != nil {
+		t.Fatal("Failed to insert the private message:", err)
+	}
+
+	placeholderName := getDeletedUserName(alice.ID)
+	if err := models.Users.Anonymize(alice.ID, placeholderName); err != nil {
+		t.Fatal("Failed to anonymize the user:", err)
+	}
+
+	// The row is kept with the placeholder name so that their games still work
+	if deleted, err := models.Users.IsDeleted(alice.ID); err != nil {
+		t.Fatal("Failed to check to see if the user is deleted:", err)
+	} else if !deleted {
+		t.Error("The user is not marked as deleted.")
+	}
+	if username, err := models.Users.GetUsername(alice.ID); err != nil {
+		t.Fatal("Failed to get the username:", err)
+	} else if username != placeholderName {
+		t.Errorf("The username is \"%s\", expected \"%s\".", username, placeholderName)
+	}
+	if exists, _, err := models.Users.Get(alice.Username); err != nil {
+		t.Fatal("Failed to get the user:", err)
+	} else if exists {
+		t.Error("The old username still exists.")
+	}
+
+	if settings, err := models.UserSettings.Get(alice.ID); err != nil {
+		t.Fatal("Failed to get the settings:", err)
+	} else if settings != defaultSettings {
+		t.Error("The settings were not removed.")
+	}
+
+	// Both directions of the friendship are removed
+	if friends, err := models.UserFriends.GetMap(alice.ID); err != nil {
+		t.Fatal("Failed to get the friends:", err)
+	} else if len(friends) != 0 {
+		t.Error("The friends of the user were not removed.")
+	}
+	if friends, err := models.UserFriends.GetMap(bob.ID); err != nil {
+		t.Fatal("Failed to get the friends:", err)
+	} else if _, ok := friends[alice.ID]; ok {
+		t.Error("The user is still on the friend list of another user.")
+	}
+
+	if messages, err := models.ChatLog.GetAllByUser(alice.ID); err != nil {
+		t.Fatal("Failed to get the chat messages:", err)
+	} else if len(messages) != 0 {
+		t.Error("The chat messages were not removed.")
+	}
+	if messages, err := models.ChatLogPM.GetAllByUser(alice.ID); err != nil {
+		t.Fatal("Failed to get the private messages:", err)
+	} else if len(messages) != 0 {
+		t.Error("The private messages to the user were not removed.")
+	}
+}
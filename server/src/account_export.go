@@ -0,0 +1,289 @@
+package main
+
+// Players can download an archive of everything that the server stores about them
+// The archive is generated in the background after they type the "/exportdata" command and it can
+// then be downloaded from "/account-export" (see "httpAccountExport()")
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Archives are deleted after a week so that we do not keep stale copies of personal data
+	AccountExportExpiration = 7 * 24 * time.Hour
+)
+
+var (
+	accountExportsPath string
+
+	// Generating an archive is expensive, so each user can only have one in progress at a time
+	accountExportsInProgress = make(map[int]struct{})
+	accountExportsMutex      sync.Mutex
+)
+
+type AccountExportInfo struct {
+	Username         string    `json:"username"`
+	DatetimeCreated  time.Time `json:"datetimeCreated"`
+	DatetimeExported time.Time `json:"datetimeExported"`
+	Friends          []string  `json:"friends"`
+	Settings         Settings  `json:"settings"`
+}
+
+type AccountExportStats struct {
+	Profile  *APIProfile            `json:"profile"`
+	Variants []*APIUserVariantStats `json:"variants"` // Only the variants that they have played
+}
+
+type AccountExportTags struct {
+	GameID int      `json:"gameID"`
+	Tags   []string `json:"tags"`
+}
+
+func accountExportGetPath(userID int) string {
+	return path.Join(accountExportsPath, strconv.Itoa(userID)+".zip")
+}
+
+// accountExportStart returns false if an archive is already being generated for this user
+func accountExportStart(userID int, username string) bool {
+	accountExportsMutex.Lock()
+	defer accountExportsMutex.Unlock()
+
+	if _, ok := accountExportsInProgress[userID]; ok {
+		return false
+	}
+	accountExportsInProgress[userID] = struct{}{}
+
+	go accountExportGenerate(userID, username)
+	return true
+}
+
+func accountExportGenerate(userID int, username string) {
+	defer func() {
+		accountExportsMutex.Lock()
+		delete(accountExportsInProgress, userID)
+		accountExportsMutex.Unlock()
+	}()
+
+	logger.Info("Generating the account archive for user \"" + username + "\".")
+
+	// Write to a temporary file so that a partial archive is never served
+	filePath := accountExportGetPath(userID)
+	tempPath := filePath + ".tmp"
+	if err := accountExportWrite(userID, username, tempPath); err != nil {
+		logger.Error("Failed to generate the account archive for user \""+username+"\":", err)
+		if err := os.Remove(tempPath); err != nil && !os.IsNotExist(err) {
+			logger.Error("Failed to remove \""+tempPath+"\":", err)
+		}
+		accountExportNotify(userID, "Something went wrong when generating your archive. "+
+			"Please try again later.")
+		return
+	}
+	if err := os.Rename(tempPath, filePath); err != nil {
+		logger.Error("Failed to rename \""+tempPath+"\" to \""+filePath+"\":", err)
+		accountExportNotify(userID, "Something went wrong when generating your archive. "+
+			"Please try again later.")
+		return
+	}
+
+	logger.Info("Finished generating the account archive for user \"" + username + "\".")
+
+	protocol := "http"
+	if useTLS {
+		protocol += "s"
+	}
+	accountExportNotify(userID, "Your archive is ready to download: "+
+		protocol+"://"+domain+"/account-export")
+}
+
+// accountExportNotify sends a private message to the user if they are still online
+func accountExportNotify(userID int, msg string) {
+	sessionsMutex.RLock()
+	s, ok := sessions[userID]
+	sessionsMutex.RUnlock()
+
+	if ok {
+		chatServerSendPM(s, msg, "lobby")
+	}
+}
+
+func accountExportWrite(userID int, username string, filePath string) error {
+	var file *os.File
+	if v, err := os.Create(filePath); err != nil {
+		return err
+	} else {
+		file = v
+	}
+	defer file.Close()
+
+	zipWriter := zip.NewWriter(file)
+
+	// Account information
+	info := &AccountExportInfo{
+		Username:         username,
+		DatetimeExported: time.Now(),
+	}
+	if v, err := models.Users.GetDatetimeCreated(userID); err != nil {
+		return err
+	} else {
+		info.DatetimeCreated = v
+	}
+	if v, err := models.UserFriends.GetAllUsernames(userID); err != nil {
+		return err
+	} else {
+		info.Friends = v
+	}
+	if v, err := models.UserSettings.Get(userID); err != nil {
+		return err
+	} else {
+		info.Settings = v
+	}
+	if err := accountExportWriteJSON(zipWriter, "account.json", info); err != nil {
+		return err
+	}
+
+	// Stats
+	if stats, err := accountExportGetStats(userID, username); err != nil {
+		return err
+	} else if err := accountExportWriteJSON(zipWriter, "stats.json", stats); err != nil {
+		return err
+	}
+
+	// Chat messages
+	if v, err := models.ChatLog.GetAllByUser(userID); err != nil {
+		return err
+	} else if err := accountExportWriteJSON(zipWriter, "chat.json", v); err != nil {
+		return err
+	}
+	if v, err := models.ChatLogPM.GetAllByUser(userID); err != nil {
+		return err
+	} else if err := accountExportWriteJSON(zipWriter, "private_messages.json", v); err != nil {
+		return err
+	}
+
+	// Tags
+	var tagsMap map[int][]string
+	if v, err := models.GameTags.SearchByUserID(userID); err != nil {
+		return err
+	} else {
+		tagsMap = v
+	}
+	tags := make([]*AccountExportTags, 0)
+	for gameID, gameTags := range tagsMap {
+		tags = append(tags, &AccountExportTags{
+			GameID: gameID,
+			Tags:   gameTags,
+		})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].GameID < tags[j].GameID
+	})
+	if err := accountExportWriteJSON(zipWriter, "tags.json", tags); err != nil {
+		return err
+	}
+
+	// Games
+	var gameIDs []int
+	if v, err := models.Games.GetGameIDsMultiUser([]int{userID}); err != nil {
+		return err
+	} else {
+		gameIDs = v
+	}
+	var gameHistoryList []*GameHistory
+	if v, err := models.Games.GetHistory(gameIDs); err != nil {
+		return err
+	} else {
+		gameHistoryList = v
+	}
+	if err := accountExportWriteJSON(zipWriter, "games.json", gameHistoryList); err != nil {
+		return err
+	}
+	for _, gameHistory := range gameHistoryList {
+		// The deals of tournament rounds and daily challenges are hidden until they are over to
+		// prevent spoilers (in the same way as "httpExport()")
+		if inProgress, err := models.TournamentRounds.IsSeedInProgress(
+			gameHistory.Seed,
+		); err != nil {
+			return err
+		} else if inProgress || isDailyChallengeSeedHidden(gameHistory.Seed) {
+			continue
+		}
+
+		// "getGameJSON()" logs its own errors
+		if gameJSON, ok := getGameJSON(gameHistory.ID, gameHistory.Seed); !ok {
+			continue
+		} else if err := accountExportWriteJSON(
+			zipWriter,
+			path.Join("games", strconv.Itoa(gameHistory.ID)+".json"),
+			gameJSON,
+		); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func accountExportGetStats(userID int, username string) (*AccountExportStats, error) {
+	user := User{
+		ID:       userID,
+		Username: username,
+	}
+
+	var profileStats Stats
+	if v, err := models.Games.GetProfileStats(userID); err != nil {
+		return nil, err
+	} else {
+		profileStats = v
+	}
+
+	var statsMap map[int]*UserStatsRow
+	if v, err := models.UserStats.GetAll(userID); err != nil {
+		return nil, err
+	} else {
+		statsMap = v
+	}
+	variantStatsList := newAPIUserVariantStatsList(statsMap)
+
+	var ratings []*UserRating
+	if v, err := models.UserRatings.GetAll(userID); err != nil {
+		return nil, err
+	} else {
+		ratings = v
+	}
+
+	playedVariantStatsList := make([]*APIUserVariantStats, 0)
+	for _, variantStats := range variantStatsList {
+		if variantStats.NumGames > 0 {
+			playedVariantStatsList = append(playedVariantStatsList, variantStats)
+		}
+	}
+
+	return &AccountExportStats{
+		Profile:  newAPIProfile(user, profileStats, variantStatsList, ratings),
+		Variants: playedVariantStatsList,
+	}, nil
+}
+
+func accountExportWriteJSON(zipWriter *zip.Writer, name string, v interface{}) error {
+	var data []byte
+	if v, err := json.MarshalIndent(v, "", "  "); err != nil {
+		return err
+	} else {
+		data = v
+	}
+
+	if w, err := zipWriter.Create(name); err != nil {
+		return err
+	} else if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	return nil
+}
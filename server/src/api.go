@@ -0,0 +1,445 @@
+package main
+
+// The "/api/v1" routes return the same data as the HTML pages (e.g. "/history/Alice"),
+// but as JSON, so that people can build dashboards and tools without scraping the website
+// Each route is described with an "APIRoute" so that the OpenAPI document at
+// "/api/v1/openapi.json" is always generated from the same list as the router
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIVersion         = "1"
+	APIDefaultPageSize = 50
+	APIMaxPageSize     = 100
+)
+
+type APIRoute struct {
+	Path       string // In the gin format, e.g. "/history/:player"
+	Summary    string
+	Parameters []*APIParameter
+	// Paginated routes accept the "page" and "size" query parameters and wrap their results in an
+	// "APIPage"
+	Paginated bool
+	Response  interface{} // An example value used to generate the response schema
+	Handler   gin.HandlerFunc
+}
+
+type APIParameter struct {
+	Name        string
+	In          string // Either "path" or "query"
+	Description string
+	Type        string // "string" or "integer"
+}
+
+type APIPage struct {
+	Total   int         `json:"total"`
+	Page    int         `json:"page"`
+	Size    int         `json:"size"`
+	Results interface{} `json:"results"`
+}
+
+type APIError struct {
+	Error string `json:"error"`
+}
+
+// apiRoutes is filled in "apiInit()" instead of being statically initialized because the OpenAPI
+// handler refers to it (which would otherwise be an initialization loop)
+var apiRoutes []*APIRoute
+
+// These parameters are shared by every route that returns a list of games
+var apiGameFilterParameters = []*APIParameter{
+	{
+		Name:        "variantID",
+		In:          "query",
+		Description: "Only include games played on this variant",
+		Type:        "integer",
+	},
+	{
+		Name:        "numPlayers",
+		In:          "query",
+		Description: "Only include games with this many players",
+		Type:        "integer",
+	},
+}
+
+func apiInit(httpRouter *gin.Engine) {
+	apiRoutes = []*APIRoute{
+		{
+			Path:    "/history/:player",
+			Summary: "Get the games that a player has played, most recent first",
+			Parameters: append([]*APIParameter{
+				apiPlayerParameter,
+				{
+					Name:        "teammates",
+					In:          "query",
+					Description: "A comma separated list of players that must also be in the game",
+					Type:        "string",
+				},
+			}, apiGameFilterParameters...),
+			Paginated: true,
+			Response:  []*GameHistory{},
+			Handler:   apiHistory,
+		},
+		{
+			Path:       "/scores/:player",
+			Summary:    "Get the profile statistics of a player",
+			Parameters: []*APIParameter{apiPlayerParameter},
+			Response:   &APIProfile{},
+			Handler:    apiScores,
+		},
+		{
+			Path:    "/scores/:player/variants",
+			Summary: "Get the statistics of a player for every variant",
+			Parameters: []*APIParameter{
+				apiPlayerParameter,
+				apiVariantIDParameter,
+			},
+			Paginated: true,
+			Response:  []*APIUserVariantStats{},
+			Handler:   apiScoresVariants,
+		},
+		{
+			Path:    "/missing-scores/:player",
+			Summary: "Get the best scores of a player that are not perfect scores",
+			Parameters: []*APIParameter{
+				apiPlayerParameter,
+				apiVariantIDParameter,
+				{
+					Name:        "numPlayers",
+					In:          "query",
+					Description: "Only include scores for this many players",
+					Type:        "integer",
+				},
+			},
+			Paginated: true,
+			Response:  []*APIMissingScore{},
+			Handler:   apiMissingScores,
+		},
+		{
+			Path:       "/seed/:seed",
+			Summary:    "Get the games played on a seed, best score first",
+			Parameters: append([]*APIParameter{apiSeedParameter}, apiGameFilterParameters...),
+			Paginated:  true,
+			Response:   []*GameHistory{},
+			Handler:    apiSeed,
+		},
+		{
+			Path:       "/seed/:seed/analysis",
+			Summary:    "Get whether a seed is winnable with perfect information",
+			Parameters: []*APIParameter{apiSeedParameter},
+			Response:   &SeedAnalysis{},
+			Handler:    apiSeedAnalysis,
+		},
+		{
+			Path:      "/variants",
+			Summary:   "Get the statistics for every variant",
+			Paginated: true,
+			Response:  []*APIVariantStats{},
+			Handler:   apiVariants,
+		},
+		{
+			Path:    "/variants/:id",
+			Summary: "Get the statistics for a variant",
+			Parameters: []*APIParameter{
+				{
+					Name:        "id",
+					In:          "path",
+					Description: "The ID of the variant",
+					Type:        "integer",
+				},
+			},
+			Response: &APIVariant{},
+			Handler:  apiVariant,
+		},
+		{
+			Path:    "/variants/:id/games",
+			Summary: "Get the games played on a variant, most recent first",
+			Parameters: append([]*APIParameter{
+				{
+					Name:        "id",
+					In:          "path",
+					Description: "The ID of the variant",
+					Type:        "integer",
+				},
+			}, apiGameFilterParameters[1:]...), // The variant is already specified
+			Paginated: true,
+			Response:  []*GameHistory{},
+			Handler:   apiVariantGames,
+		},
+		{
+			Path:    "/tag/:tag",
+			Summary: "Get the games that have a tag, most recent first",
+			Parameters: append([]*APIParameter{
+				{
+					Name:        "tag",
+					In:          "path",
+					Description: "The tag to search for",
+					Type:        "string",
+				},
+			}, apiGameFilterParameters...),
+			Paginated: true,
+			Response:  []*GameHistory{},
+			Handler:   apiTag,
+		},
+		{
+			Path:     "/stats",
+			Summary:  "Get the statistics for the whole website",
+			Response: &APIGlobalStats{},
+			Handler:  apiStats,
+		},
+		{
+			Path:     "/protocol.json",
+			Summary:  "Get the JSON Schema of every WebSocket command",
+			Response: map[string]interface{}{},
+			Handler:  protocolSchema,
+		},
+		{
+			Path:     "/openapi.json",
+			Summary:  "Get the OpenAPI document that describes this API",
+			Response: map[string]interface{}{},
+			Handler:  apiOpenAPI,
+		},
+	}
+
+	apiV1 := httpRouter.Group("/api/v" + APIVersion)
+	apiV1.Use(apiHeaders)
+	for _, route := range apiRoutes {
+		apiV1.GET(route.Path, route.Handler)
+	}
+}
+
+var (
+	apiPlayerParameter = &APIParameter{
+		Name:        "player",
+		In:          "path",
+		Description: "The username of the player",
+		Type:        "string",
+	}
+	apiSeedParameter = &APIParameter{
+		Name:        "seed",
+		In:          "path",
+		Description: "The seed, e.g. \"p2v0s1\"",
+		Type:        "string",
+	}
+	apiVariantIDParameter = &APIParameter{
+		Name:        "variantID",
+		In:          "query",
+		Description: "Only include this variant",
+		Type:        "integer",
+	}
+)
+
+// apiHeaders allows dashboards hosted on other domains to query the API from a browser
+// (the API is read-only and does not use the session cookie)
+func apiHeaders(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Next()
+}
+
+func apiError(c *gin.Context, code int, msg string) {
+	c.AbortWithStatusJSON(code, &APIError{
+		Error: msg,
+	})
+}
+
+func apiInternalError(c *gin.Context) {
+	apiError(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// apiParsePlayer gets the user corresponding to the "player" path parameter
+func apiParsePlayer(c *gin.Context, player string) (User, bool) {
+	if player == "" {
+		apiError(c, http.StatusBadRequest, "You must specify a player.")
+		return User{}, false
+	}
+
+	if exists, v, err := models.Users.GetUserFromNormalizedUsername(
+		normalizeString(player),
+	); err != nil {
+		logger.Error("Failed to check to see if player \""+player+"\" exists:", err)
+		apiInternalError(c)
+		return User{}, false
+	} else if !exists {
+		apiError(c, http.StatusNotFound, "The player of \""+player+"\" does not exist.")
+		return User{}, false
+	} else {
+		return v, true
+	}
+}
+
+// apiParseIntQuery returns 0 if the query parameter was not specified
+func apiParseIntQuery(c *gin.Context, name string) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, true
+	}
+
+	if v, err := strconv.Atoi(value); err != nil || v < 0 {
+		apiError(c, http.StatusBadRequest, "The \""+name+"\" parameter must be a positive number.")
+		return 0, false
+	} else {
+		return v, true
+	}
+}
+
+// apiParseVariantID validates the "variantID" query parameter
+// It returns an empty string if the parameter was not specified
+func apiParseVariantID(c *gin.Context) (string, bool) {
+	var variantID int
+	if v, ok := apiParseIntQuery(c, "variantID"); !ok {
+		return "", false
+	} else {
+		variantID = v
+	}
+
+	if c.Query("variantID") == "" {
+		return "", true
+	}
+
+	if v, ok := variantIDMap[variantID]; !ok {
+		apiError(c, http.StatusBadRequest, "That is not a valid variant ID.")
+		return "", false
+	} else {
+		return v, true
+	}
+}
+
+// apiParsePage validates the "page" and "size" query parameters
+// Pages start at 1
+func apiParsePage(c *gin.Context) (int, int, bool) {
+	page := 1
+	if v, ok := apiParseIntQuery(c, "page"); !ok {
+		return 0, 0, false
+	} else if v > 0 {
+		page = v
+	}
+
+	size := APIDefaultPageSize
+	if v, ok := apiParseIntQuery(c, "size"); !ok {
+		return 0, 0, false
+	} else if v > APIMaxPageSize {
+		apiError(
+			c,
+			http.StatusBadRequest,
+			"The \"size\" parameter can not be more than "+strconv.Itoa(APIMaxPageSize)+".",
+		)
+		return 0, 0, false
+	} else if v > 0 {
+		size = v
+	}
+
+	return page, size, true
+}
+
+// apiPageBounds returns the slice indexes of a page out of a list with the given length
+func apiPageBounds(page int, size int, total int) (int, int) {
+	start := (page - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// apiServeGames filters and paginates a list of game IDs and then sends the corresponding games
+// If the games are sorted by ID, we only need to get the games for the current page from the
+// database; otherwise, all of them must be retrieved so that they can be sorted
+func apiServeGames(c *gin.Context, gameIDs []int, orderBy string) {
+	var page, size int
+	if v1, v2, ok := apiParsePage(c); !ok {
+		return
+	} else {
+		page = v1
+		size = v2
+	}
+
+	var variantName string
+	if v, ok := apiParseVariantID(c); !ok {
+		return
+	} else {
+		variantName = v
+	}
+
+	var numPlayers int
+	if v, ok := apiParseIntQuery(c, "numPlayers"); !ok {
+		return
+	} else {
+		numPlayers = v
+	}
+
+	filtered := variantName != "" || numPlayers != 0
+	if orderBy == "" && !filtered {
+		// The game IDs from the database are not guaranteed to be in any particular order
+		sort.Sort(sort.Reverse(sort.IntSlice(gameIDs)))
+		start, end := apiPageBounds(page, size, len(gameIDs))
+		var gameHistoryList []*GameHistory
+		if v, err := models.Games.GetHistory(gameIDs[start:end]); err != nil {
+			logger.Error("Failed to get the games from the database:", err)
+			apiInternalError(c)
+			return
+		} else {
+			gameHistoryList = v
+		}
+
+		c.JSON(http.StatusOK, &APIPage{
+			Total:   len(gameIDs),
+			Page:    page,
+			Size:    size,
+			Results: gameHistoryList,
+		})
+		return
+	}
+
+	if orderBy == "" {
+		orderBy = "id DESC"
+	}
+	var gameHistoryList []*GameHistory
+	if v, err := models.Games.GetHistoryCustomSort(gameIDs, orderBy); err != nil {
+		logger.Error("Failed to get the games from the database:", err)
+		apiInternalError(c)
+		return
+	} else {
+		gameHistoryList = v
+	}
+
+	results := make([]*GameHistory, 0)
+	for _, gameHistory := range gameHistoryList {
+		if variantName != "" && gameHistory.Options.VariantName != variantName {
+			continue
+		}
+		if numPlayers != 0 && gameHistory.Options.NumPlayers != numPlayers {
+			continue
+		}
+		results = append(results, gameHistory)
+	}
+
+	start, end := apiPageBounds(page, size, len(results))
+	c.JSON(http.StatusOK, &APIPage{
+		Total:   len(results),
+		Page:    page,
+		Size:    size,
+		Results: results[start:end],
+	})
+}
+
+// apiSplitNames splits a comma separated list of names, ignoring empty entries
+func apiSplitNames(list string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
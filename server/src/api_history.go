@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiHistory is the JSON equivalent of "httpHistory()"
+// e.g. "/api/v1/history/Alice?teammates=Bob,Cathy"
+func apiHistory(c *gin.Context) {
+	// Parse the player and their teammates
+	users := make([]User, 0)
+	names := append([]string{c.Param("player")}, apiSplitNames(c.Query("teammates"))...)
+	for _, name := range names {
+		var user User
+		if v, ok := apiParsePlayer(c, name); !ok {
+			return
+		} else {
+			user = v
+		}
+
+		for _, otherUser := range users {
+			if otherUser.ID == user.ID {
+				apiError(c, http.StatusBadRequest, "You can not specify the same player twice.")
+				return
+			}
+		}
+		users = append(users, user)
+	}
+
+	playerIDs := make([]int, 0)
+	playerNames := make([]string, 0)
+	for _, user := range users {
+		playerIDs = append(playerIDs, user.ID)
+		playerNames = append(playerNames, user.Username)
+	}
+
+	// Get the game IDs for this player (or set of players)
+	var gameIDs []int
+	if v, err := models.Games.GetGameIDsMultiUser(playerIDs); err != nil {
+		logger.Error("Failed to get the game IDs for the players of "+
+			"\""+strings.Join(playerNames, ", ")+"\":", err)
+		apiInternalError(c)
+		return
+	} else {
+		gameIDs = v
+	}
+
+	apiServeGames(c, gameIDs, "")
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiOpenAPI serves a description of every route in "apiRoutes"
+// https://swagger.io/specification/
+func apiOpenAPI(c *gin.Context) {
+	paths := make(map[string]interface{})
+	for _, route := range apiRoutes {
+		paths[apiOpenAPIPath(route.Path)] = map[string]interface{}{
+			"get": apiOpenAPIOperation(route),
+		}
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   WebsiteName + " API",
+			"version": APIVersion,
+		},
+		"servers": []map[string]interface{}{
+			{"url": "/api/v" + APIVersion},
+		},
+		"paths": paths,
+	})
+}
+
+// apiOpenAPIPath converts a gin path to an OpenAPI path
+// e.g. "/history/:player" --> "/history/{player}"
+func apiOpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func apiOpenAPIOperation(route *APIRoute) map[string]interface{} {
+	parameters := append([]*APIParameter{}, route.Parameters...)
+	responseSchema := apiOpenAPISchema(reflect.TypeOf(route.Response), nil)
+	if route.Paginated {
+		parameters = append(parameters, &APIParameter{
+			Name:        "page",
+			In:          "query",
+			Description: "The page of results to get, starting at 1",
+			Type:        "integer",
+		}, &APIParameter{
+			Name:        "size",
+			In:          "query",
+			Description: "The number of results per page (the default is 50 and the maximum is 100)",
+			Type:        "integer",
+		})
+
+		pageSchema := apiOpenAPISchema(reflect.TypeOf(APIPage{}), nil)
+		pageSchema["properties"].(map[string]interface{})["results"] = responseSchema
+		responseSchema = pageSchema
+	}
+
+	parametersList := make([]map[string]interface{}, 0)
+	for _, parameter := range parameters {
+		parametersList = append(parametersList, map[string]interface{}{
+			"name":        parameter.Name,
+			"in":          parameter.In,
+			"description": parameter.Description,
+			"required":    parameter.In == "path",
+			"schema": map[string]interface{}{
+				"type": parameter.Type,
+			},
+		})
+	}
+
+	errorResponse := map[string]interface{}{
+		"description": "Error",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": apiOpenAPISchema(reflect.TypeOf(APIError{}), nil),
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"summary":    route.Summary,
+		"parameters": parametersList,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "OK",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": responseSchema,
+					},
+				},
+			},
+			"default": errorResponse,
+		},
+	}
+}
+
+// apiOpenAPISchema generates a JSON schema from the type of a response, following the same rules
+// as "encoding/json"
+// The seen map is used to stop on recursive types
+func apiOpenAPISchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{
+			"type":   "string",
+			"format": "date-time",
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": apiOpenAPISchema(t.Elem(), seen),
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": apiOpenAPISchema(t.Elem(), seen),
+		}
+
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen2 := map[reflect.Type]bool{t: true}
+		for k := range seen {
+			seen2[k] = true
+		}
+
+		properties := make(map[string]interface{})
+		apiOpenAPIProperties(t, seen2, properties)
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+
+	default:
+		// e.g. "interface{}", which can be anything
+		return map[string]interface{}{}
+	}
+}
+
+func apiOpenAPIProperties(
+	t reflect.Type,
+	seen map[reflect.Type]bool,
+	properties map[string]interface{},
+) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs without a name have their fields promoted
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				apiOpenAPIProperties(fieldType, seen, properties)
+				continue
+			}
+		}
+
+		// Unexported fields are not encoded
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema := apiOpenAPISchema(field.Type, seen)
+		if enum := protocolGetEnum(field); enum != nil {
+			schema["enum"] = enum
+		}
+		properties[name] = schema
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/gin-gonic/gin"
+)
+
+type APIProfile struct {
+	Name                string       `json:"name"`
+	DateJoined          time.Time    `json:"dateJoined"`
+	NumGames            int          `json:"numGames"`
+	TimePlayed          int          `json:"timePlayed"` // In seconds
+	NumGamesSpeedrun    int          `json:"numGamesSpeedrun"`
+	TimePlayedSpeedrun  int          `json:"timePlayedSpeedrun"` // In seconds
+	NumMaxScores        int          `json:"numMaxScores"`
+	TotalMaxScores      int          `json:"totalMaxScores"`
+	NumMaxScoresPerType []int        `json:"numMaxScoresPerType"` // For 2-player, 3-player, etc.
+	Ratings             []*APIRating `json:"ratings"`             // For each variant class
+}
+
+type APIRating struct {
+	VariantClass string  `json:"variantClass"`
+	Rating       float64 `json:"rating"`
+	NumGames     int     `json:"numGames"`
+	Provisional  bool    `json:"provisional"`
+}
+
+type APIUserVariantStats struct {
+	VariantID     int          `json:"variantID"`
+	VariantName   string       `json:"variantName"`
+	MaxScore      int          `json:"maxScore"`
+	NumGames      int          `json:"numGames"`
+	BestScores    []*BestScore `json:"bestScores"`
+	AverageScore  float64      `json:"averageScore"`
+	NumStrikeouts int          `json:"numStrikeouts"`
+}
+
+type APIMissingScore struct {
+	VariantID   int            `json:"variantID"`
+	VariantName string         `json:"variantName"`
+	NumPlayers  int            `json:"numPlayers"`
+	Score       int            `json:"score"`
+	MaxScore    int            `json:"maxScore"`
+	Modifier    engine.Bitmask `json:"modifier"` // (see the stats section in "gameEnd.go")
+}
+
+// apiScores is the JSON equivalent of the statistics at the top of "httpScores()"
+// (the variant-specific statistics are in "apiScoresVariants()")
+func apiScores(c *gin.Context) {
+	var user User
+	if v, ok := apiParsePlayer(c, c.Param("player")); !ok {
+		return
+	} else {
+		user = v
+	}
+
+	// Get basic stats for this player
+	var profileStats Stats
+	if v, err := models.Games.GetProfileStats(user.ID); err != nil {
+		logger.Error("Failed to get the profile stats for player \""+user.Username+"\":", err)
+		apiInternalError(c)
+		return
+	} else {
+		profileStats = v
+	}
+
+	var variantStatsList []*APIUserVariantStats
+	if v, ok := apiGetUserVariantStats(c, user); !ok {
+		return
+	} else {
+		variantStatsList = v
+	}
+
+	// Get their ratings
+	var ratings []*UserRating
+	if v, err := models.UserRatings.GetAll(user.ID); err != nil {
+		logger.Error("Failed to get the ratings for player \""+user.Username+"\":", err)
+		apiInternalError(c)
+		return
+	} else {
+		ratings = v
+	}
+
+	c.JSON(http.StatusOK, newAPIProfile(user, profileStats, variantStatsList, ratings))
+}
+
+func newAPIProfile(
+	user User,
+	profileStats Stats,
+	variantStatsList []*APIUserVariantStats,
+	ratings []*UserRating,
+) *APIProfile {
+	numMaxScores := 0
+	numMaxScoresPerType := make([]int, NumBestScores)
+	for _, variantStats := range variantStatsList {
+		for i, bestScore := range variantStats.BestScores {
+			if bestScore.Score == variantStats.MaxScore {
+				numMaxScores++
+				numMaxScoresPerType[i]++
+			}
+		}
+	}
+
+	return &APIProfile{
+		Name:                user.Username,
+		DateJoined:          profileStats.DateJoined,
+		NumGames:            profileStats.NumGames,
+		TimePlayed:          profileStats.TimePlayed,
+		NumGamesSpeedrun:    profileStats.NumGamesSpeedrun,
+		TimePlayedSpeedrun:  profileStats.TimePlayedSpeedrun,
+		NumMaxScores:        numMaxScores,
+		TotalMaxScores:      len(variantNames) * NumBestScores, // For every amount of players
+		NumMaxScoresPerType: numMaxScoresPerType,
+		Ratings:             newAPIRatings(ratings),
+	}
+}
+
+func newAPIRatings(ratings []*UserRating) []*APIRating {
+	apiRatings := make([]*APIRating, 0, len(ratings))
+	for _, rating := range ratings {
+		apiRatings = append(apiRatings, &APIRating{
+			VariantClass: ratingClassNames[rating.VariantClass],
+			Rating:       rating.Rating,
+			NumGames:     rating.NumGames,
+			Provisional:  rating.NumGames < RatingNumProvisionalGames,
+		})
+	}
+	return apiRatings
+}
+
+func apiScoresVariants(c *gin.Context) {
+	var user User
+	if v, ok := apiParsePlayer(c, c.Param("player")); !ok {
+		return
+	} else {
+		user = v
+	}
+
+	var page, size int
+	if v1, v2, ok := apiParsePage(c); !ok {
+		return
+	} else {
+		page = v1
+		size = v2
+	}
+
+	var variantName string
+	if v, ok := apiParseVariantID(c); !ok {
+		return
+	} else {
+		variantName = v
+	}
+
+	var variantStatsList []*APIUserVariantStats
+	if v, ok := apiGetUserVariantStats(c, user); !ok {
+		return
+	} else {
+		variantStatsList = v
+	}
+
+	results := make([]*APIUserVariantStats, 0)
+	for _, variantStats := range variantStatsList {
+		if variantName == "" || variantStats.VariantName == variantName {
+			results = append(results, variantStats)
+		}
+	}
+
+	start, end := apiPageBounds(page, size, len(results))
+	c.JSON(http.StatusOK, &APIPage{
+		Total:   len(results),
+		Page:    page,
+		Size:    size,
+		Results: results[start:end],
+	})
+}
+
+// apiMissingScores is the JSON equivalent of "httpMissingScores()"
+func apiMissingScores(c *gin.Context) {
+	var user User
+	if v, ok := apiParsePlayer(c, c.Param("player")); !ok {
+		return
+	} else {
+		user = v
+	}
+
+	var page, size int
+	if v1, v2, ok := apiParsePage(c); !ok {
+		return
+	} else {
+		page = v1
+		size = v2
+	}
+
+	var variantName string
+	if v, ok := apiParseVariantID(c); !ok {
+		return
+	} else {
+		variantName = v
+	}
+
+	var numPlayers int
+	if v, ok := apiParseIntQuery(c, "numPlayers"); !ok {
+		return
+	} else {
+		numPlayers = v
+	}
+
+	var variantStatsList []*APIUserVariantStats
+	if v, ok := apiGetUserVariantStats(c, user); !ok {
+		return
+	} else {
+		variantStatsList = v
+	}
+
+	// A score is missing if it is not a max score or if it was achieved with a modifier
+	// (the same as the "missing-scores.tmpl" template)
+	results := make([]*APIMissingScore, 0)
+	for _, variantStats := range variantStatsList {
+		if variantName != "" && variantStats.VariantName != variantName {
+			continue
+		}
+		for _, bestScore := range variantStats.BestScores {
+			if bestScore.Score == variantStats.MaxScore && bestScore.Modifier == 0 {
+				continue
+			}
+			if numPlayers != 0 && bestScore.NumPlayers != numPlayers {
+				continue
+			}
+			results = append(results, &APIMissingScore{
+				VariantID:   variantStats.VariantID,
+				VariantName: variantStats.VariantName,
+				NumPlayers:  bestScore.NumPlayers,
+				Score:       bestScore.Score,
+				MaxScore:    variantStats.MaxScore,
+				Modifier:    bestScore.Modifier,
+			})
+		}
+	}
+
+	start, end := apiPageBounds(page, size, len(results))
+	c.JSON(http.StatusOK, &APIPage{
+		Total:   len(results),
+		Page:    page,
+		Size:    size,
+		Results: results[start:end],
+	})
+}
+
+// apiGetUserVariantStats returns the stats of a player for every variant,
+// in the same order as the variants on the "/scores" page
+func apiGetUserVariantStats(c *gin.Context, user User) ([]*APIUserVariantStats, bool) {
+	var statsMap map[int]*UserStatsRow
+	if v, err := models.UserStats.GetAll(user.ID); err != nil {
+		logger.Error("Failed to get all of the variant-specific stats for player "+
+			"\""+user.Username+"\":", err)
+		apiInternalError(c)
+		return nil, false
+	} else {
+		statsMap = v
+	}
+
+	return newAPIUserVariantStatsList(statsMap), true
+}
+
+func newAPIUserVariantStatsList(statsMap map[int]*UserStatsRow) []*APIUserVariantStats {
+	variantStatsList := make([]*APIUserVariantStats, 0)
+	for _, name := range variantNames {
+		variant := variants[name]
+		variantStats := &APIUserVariantStats{
+			VariantID:   variant.ID,
+			VariantName: name,
+			MaxScore:    len(variant.Suits) * engine.PointsPerSuit,
+			BestScores:  NewBestScores(),
+		}
+
+		if stats, ok := statsMap[variant.ID]; ok {
+			variantStats.NumGames = stats.NumGames
+			variantStats.BestScores = stats.BestScores
+			variantStats.AverageScore = stats.AverageScore
+			variantStats.NumStrikeouts = stats.NumStrikeouts
+		}
+
+		variantStatsList = append(variantStatsList, variantStats)
+	}
+
+	return variantStatsList
+}
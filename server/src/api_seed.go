@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiSeed is the JSON equivalent of "httpSeed()"
+func apiSeed(c *gin.Context) {
+	seed := c.Param("seed")
+	if !apiCheckSeed(c, seed) {
+		return
+	}
+
+	// Get the list of game IDs played on this seed
+	var gameIDs []int
+	if v, err := models.Games.GetGameIDsSeed(seed); err != nil {
+		logger.Error("Failed to get the game IDs from the database for seed \""+seed+"\":", err)
+		apiInternalError(c)
+		return
+	} else {
+		gameIDs = v
+	}
+
+	apiServeGames(c, gameIDs, SeedSort)
+}
+
+func apiSeedAnalysis(c *gin.Context) {
+	seed := c.Param("seed")
+	if !apiCheckSeed(c, seed) {
+		return
+	}
+
+	// We only analyze seeds that have been played to prevent people from making the server run the
+	// solver on arbitrary strings
+	if v, err := models.Games.GetGameIDsSeed(seed); err != nil {
+		logger.Error("Failed to get the game IDs from the database for seed \""+seed+"\":", err)
+		apiInternalError(c)
+		return
+	} else if len(v) == 0 {
+		apiError(c, http.StatusNotFound, "No games have been played on that seed.")
+		return
+	}
+
+	// The solver is never run in the request itself
+	if v, ok, err := getCachedSeedAnalysis(seed); err != nil {
+		logger.Error("Failed to get the analysis for seed \""+seed+"\":", err)
+		apiInternalError(c)
+	} else if !ok {
+		apiError(c, http.StatusNotFound, "That seed can not be analyzed.")
+	} else if v == nil {
+		apiError(c, http.StatusAccepted, "That seed is being analyzed. Please try again later.")
+	} else {
+		c.JSON(http.StatusOK, v)
+	}
+}
+
+// apiCheckSeed hides the seeds that are still being played, in the same way as "httpSeed()"
+func apiCheckSeed(c *gin.Context, seed string) bool {
+	if seed == "" {
+		apiError(c, http.StatusBadRequest, "You must specify a seed.")
+		return false
+	}
+
+	if inProgress, err := models.TournamentRounds.IsSeedInProgress(seed); err != nil {
+		logger.Error("Failed to check to see if seed \""+seed+"\" is in a tournament:", err)
+		apiInternalError(c)
+		return false
+	} else if inProgress {
+		apiError(
+			c,
+			http.StatusForbidden,
+			"That seed is being played in a tournament round that is still in progress.",
+		)
+		return false
+	}
+
+	if isDailyChallengeSeedHidden(seed) {
+		apiError(
+			c,
+			http.StatusForbidden,
+			"That seed is a daily challenge, so it will be shown after the day is over.",
+		)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIGlobalStats struct {
+	NumGames            int   `json:"numGames"`
+	TimePlayed          int   `json:"timePlayed"` // In seconds
+	NumGamesSpeedrun    int   `json:"numGamesSpeedrun"`
+	TimePlayedSpeedrun  int   `json:"timePlayedSpeedrun"` // In seconds
+	NumVariants         int   `json:"numVariants"`
+	NumMaxScores        int   `json:"numMaxScores"`
+	TotalMaxScores      int   `json:"totalMaxScores"`
+	NumMaxScoresPerType []int `json:"numMaxScoresPerType"` // For 2-player, 3-player, etc.
+}
+
+// apiStats is the JSON equivalent of the statistics at the top of "httpStats()"
+// (the variant-specific statistics are in "apiVariants()")
+func apiStats(c *gin.Context) {
+	var globalStats Stats
+	if v, err := models.Games.GetGlobalStats(); err != nil {
+		logger.Error("Failed to get the global stats:", err)
+		apiInternalError(c)
+		return
+	} else {
+		globalStats = v
+	}
+
+	var variantStatsList []*APIVariantStats
+	if v, ok := apiGetVariantStats(c); !ok {
+		return
+	} else {
+		variantStatsList = v
+	}
+
+	numMaxScores := 0
+	numMaxScoresPerType := make([]int, NumBestScores)
+	for _, variantStats := range variantStatsList {
+		for i, bestScore := range variantStats.BestScores {
+			if bestScore.Score == variantStats.MaxScore {
+				numMaxScores++
+				numMaxScoresPerType[i]++
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, &APIGlobalStats{
+		NumGames:            globalStats.NumGames,
+		TimePlayed:          globalStats.TimePlayed,
+		NumGamesSpeedrun:    globalStats.NumGamesSpeedrun,
+		TimePlayedSpeedrun:  globalStats.TimePlayedSpeedrun,
+		NumVariants:         len(variantNames),
+		NumMaxScores:        numMaxScores,
+		TotalMaxScores:      len(variantNames) * NumBestScores, // For every amount of players
+		NumMaxScoresPerType: numMaxScoresPerType,
+	})
+}
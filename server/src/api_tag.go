@@ -0,0 +1,31 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiTag is the JSON equivalent of "httpTag()"
+func apiTag(c *gin.Context) {
+	// Sanitize, validate, and normalize the tag
+	var tag string
+	if v, err := sanitizeTag(c.Param("tag")); err != nil {
+		apiError(c, http.StatusBadRequest, err.Error())
+		return
+	} else {
+		tag = v
+	}
+
+	// Get the game IDs that match this tag
+	var gameIDs []int
+	if v, err := models.GameTags.SearchByTag(tag); err != nil {
+		logger.Error("Failed to search for games matching a tag of \""+tag+"\":", err)
+		apiInternalError(c)
+		return
+	} else {
+		gameIDs = v
+	}
+
+	apiServeGames(c, gameIDs, "")
+}
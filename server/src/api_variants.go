@@ -0,0 +1,171 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/gin-gonic/gin"
+)
+
+type APIVariantStats struct {
+	ID            int          `json:"id"`
+	Name          string       `json:"name"`
+	MaxScore      int          `json:"maxScore"`
+	NumGames      int          `json:"numGames"`
+	BestScores    []*BestScore `json:"bestScores"`
+	NumMaxScores  int          `json:"numMaxScores"`
+	AverageScore  float64      `json:"averageScore"`
+	NumStrikeouts int          `json:"numStrikeouts"`
+}
+
+// APIVariant contains the additional stats that are shown on the "/variant" page
+// (they are not part of the "variant_stats" table, so they are not included in the list of all
+// variants)
+type APIVariant struct {
+	APIVariantStats
+	NumGamesNormal     int `json:"numGamesNormal"` // The number of non-speedrun games
+	TimePlayed         int `json:"timePlayed"`     // In seconds
+	NumGamesSpeedrun   int `json:"numGamesSpeedrun"`
+	TimePlayedSpeedrun int `json:"timePlayedSpeedrun"` // In seconds
+}
+
+// apiVariants is the JSON equivalent of the table on the "/stats" page
+func apiVariants(c *gin.Context) {
+	var page, size int
+	if v1, v2, ok := apiParsePage(c); !ok {
+		return
+	} else {
+		page = v1
+		size = v2
+	}
+
+	var variantStatsList []*APIVariantStats
+	if v, ok := apiGetVariantStats(c); !ok {
+		return
+	} else {
+		variantStatsList = v
+	}
+
+	start, end := apiPageBounds(page, size, len(variantStatsList))
+	c.JSON(http.StatusOK, &APIPage{
+		Total:   len(variantStatsList),
+		Page:    page,
+		Size:    size,
+		Results: variantStatsList[start:end],
+	})
+}
+
+// apiVariant is the JSON equivalent of "httpVariant()"
+// (the recent games are in "apiVariantGames()")
+func apiVariant(c *gin.Context) {
+	var variantID int
+	if v, ok := apiParseVariantIDParam(c); !ok {
+		return
+	} else {
+		variantID = v
+	}
+	variant := variants[variantIDMap[variantID]]
+
+	// Get the stats for this variant
+	var variantStats VariantStatsRow
+	if v, err := models.VariantStats.Get(variantID); err != nil {
+		logger.Error("Failed to get the variant stats for variant "+
+			strconv.Itoa(variantID)+":", err)
+		apiInternalError(c)
+		return
+	} else {
+		variantStats = v
+	}
+
+	// Get additional stats (that are not part of the "variant_stats" table)
+	var stats Stats
+	if v, err := models.Games.GetVariantStats(variantID); err != nil {
+		logger.Error("Failed to get the stats for variant "+strconv.Itoa(variantID)+":", err)
+		apiInternalError(c)
+		return
+	} else {
+		stats = v
+	}
+
+	c.JSON(http.StatusOK, &APIVariant{
+		APIVariantStats:    *apiNewVariantStats(variant, variantStats),
+		NumGamesNormal:     stats.NumGames,
+		TimePlayed:         stats.TimePlayed,
+		NumGamesSpeedrun:   stats.NumGamesSpeedrun,
+		TimePlayedSpeedrun: stats.TimePlayedSpeedrun,
+	})
+}
+
+func apiVariantGames(c *gin.Context) {
+	var variantID int
+	if v, ok := apiParseVariantIDParam(c); !ok {
+		return
+	} else {
+		variantID = v
+	}
+
+	// Get every game played on this variant
+	// (the "/variant" page only shows the most recent ones)
+	var gameIDs []int
+	if v, err := models.Games.GetGameIDsVariant(variantID, 0); err != nil {
+		logger.Error("Failed to get the game IDs for variant "+strconv.Itoa(variantID)+":", err)
+		apiInternalError(c)
+		return
+	} else {
+		gameIDs = v
+	}
+
+	apiServeGames(c, gameIDs, "")
+}
+
+func apiParseVariantIDParam(c *gin.Context) (int, bool) {
+	if v, err := strconv.Atoi(c.Param("id")); err != nil {
+		apiError(c, http.StatusBadRequest, "The variant ID must be a number.")
+		return 0, false
+	} else if _, ok := variantIDMap[v]; !ok {
+		apiError(c, http.StatusNotFound, "That is not a valid variant ID.")
+		return 0, false
+	} else {
+		return v, true
+	}
+}
+
+// apiGetVariantStats returns the stats for every variant,
+// in the same order as the variants on the "/stats" page
+func apiGetVariantStats(c *gin.Context) ([]*APIVariantStats, bool) {
+	var statsMap map[int]VariantStatsRow
+	if v, err := models.VariantStats.GetAll(); err != nil {
+		logger.Error("Failed to get the stats for all the variants:", err)
+		apiInternalError(c)
+		return nil, false
+	} else {
+		statsMap = v
+	}
+
+	variantStatsList := make([]*APIVariantStats, 0)
+	for _, name := range variantNames {
+		variant := variants[name]
+		stats, ok := statsMap[variant.ID]
+		if !ok {
+			// There have been no games played in this particular variant
+			stats = NewVariantStatsRow()
+		}
+		variantStatsList = append(variantStatsList, apiNewVariantStats(variant, stats))
+	}
+
+	return variantStatsList, true
+}
+
+func apiNewVariantStats(variant *engine.Variant, stats VariantStatsRow) *APIVariantStats {
+	return &APIVariantStats{
+		ID:            variant.ID,
+		Name:          variant.Name,
+		MaxScore:      len(variant.Suits) * engine.PointsPerSuit,
+		NumGames:      stats.NumGames,
+		BestScores:    stats.BestScores,
+		NumMaxScores:  stats.NumMaxScores,
+		AverageScore:  stats.AverageScore,
+		NumStrikeouts: stats.NumStrikeouts,
+	}
+}
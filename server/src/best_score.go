@@ -1,22 +1,35 @@
 package main
 
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const (
+	// There is a separate best score for each amount of players
+	// (e.g. 2-player is at index 0, 3-player is at index 1, and so forth)
+	NumBestScores = engine.MaxPlayers - engine.MinPlayers + 1
+)
+
 type BestScore struct {
-	NumPlayers   int     `json:"numPlayers"`
-	Score        int     `json:"score"`
-	Modifier     Bitmask `json:"modifier"` // (see the stats section in "gameEnd.go")
-	DeckPlays    bool    `json:"deckPlays"`
-	EmptyClues   bool    `json:"emptyClues"`
-	OneExtraCard bool    `json:"oneExtraCard"`
-	OneLessCard  bool    `json:"oneLessCard"`
-	AllOrNothing bool    `json:"allOrNothing"`
+	NumPlayers   int            `json:"numPlayers"`
+	Score        int            `json:"score"`
+	Modifier     engine.Bitmask `json:"modifier"` // (see the stats section in "gameEnd.go")
+	DeckPlays    bool           `json:"deckPlays"`
+	EmptyClues   bool           `json:"emptyClues"`
+	OneExtraCard bool           `json:"oneExtraCard"`
+	OneLessCard  bool           `json:"oneLessCard"`
+	AllOrNothing bool           `json:"allOrNothing"`
+	ClueLimits   bool           `json:"clueLimits"`
+	StrikeLimit  bool           `json:"strikeLimit"`
+	Takeback     bool           `json:"takeback"`
 }
 
 func NewBestScores() []*BestScore {
-	bestScores := make([]*BestScore, 5) // From 2 to 6 players
+	bestScores := make([]*BestScore, NumBestScores)
 	for i := range bestScores {
 		// This will not work if written as "for i, bestScore :="
 		bestScores[i] = &BestScore{}
-		bestScores[i].NumPlayers = i + 2
+		bestScores[i].NumPlayers = i + engine.MinPlayers
 	}
 	return bestScores
 }
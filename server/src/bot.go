@@ -0,0 +1,231 @@
+// Bots are AI players that are seated at a table by the table owner
+// They live entirely on the server; they are fed the same scrubbed game actions that a human
+// client would receive and they perform their moves through the "commandAction()" function,
+// just like the fake players that are used to emulate replays
+
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const (
+	// The amount of time that a bot waits before performing an action,
+	// so that the humans at the table are able to follow along
+	BotActionDelay = 1500 * time.Millisecond
+)
+
+// Bot is the interface that every server-side AI player must satisfy
+type Bot interface {
+	// Start is called once when the game begins (or when a table is restored from disk),
+	// before any actions are observed
+	Start(info *BotGameInfo)
+
+	// Observe is called for every game action, scrubbed from the perspective of the bot
+	Observe(action interface{})
+
+	// Decide is called when it is the bot's turn
+	// It should return an action that is legal from the perspective of the bot
+	Decide() *engine.GameAction
+}
+
+// BotGameInfo contains the information that a human client would receive from the
+// "getGameInfo1" command
+type BotGameInfo struct {
+	Variant     *engine.Variant
+	Options     *engine.Options
+	PlayerNames []string
+	Seat        int
+}
+
+var (
+	// Used to store all of the functions that create each type of bot
+	// The key is the type of the bot, which is also used as a prefix for its name
+	botConstructors = map[string]func() Bot{
+		"Reference": NewReferenceBot,
+	}
+)
+
+// getBotTypes returns a sorted list of all of the bot types
+func getBotTypes() []string {
+	botTypes := make([]string, 0, len(botConstructors))
+	for botType := range botConstructors {
+		botTypes = append(botTypes, botType)
+	}
+	sort.Strings(botTypes)
+	return botTypes
+}
+
+// getBotType matches a bot type case-insensitively
+func getBotType(name string) (string, bool) {
+	for botType := range botConstructors {
+		if strings.EqualFold(botType, name) {
+			return botType, true
+		}
+	}
+	return "", false
+}
+
+// NewBotPlayer creates a new player object for a bot of the specified type
+// Similar to the fake players that are used for replays, bots are given negative IDs so that they
+// will not overlap with any valid user IDs
+func (t *Table) NewBotPlayer(botType string) *Player {
+	// Find an ID and a name that is not yet used at this table
+	id := -1
+	for t.GetPlayerIndexFromID(id) != -1 {
+		id--
+	}
+	name := botType + " Bot #" + strconv.Itoa(id*-1)
+
+	return &Player{
+		ID:      id,
+		Name:    name,
+		Session: newFakeSession(id, name),
+		Present: true,
+		Stats: PregameStats{
+			Variant: NewUserStatsRow(),
+		},
+		Ready:   true, // Bots are always ready to play
+		BotType: botType,
+		Bot:     botConstructors[botType](),
+	}
+}
+
+// HasBots returns true if any of the seats at the table are occupied by a bot
+func (t *Table) HasBots() bool {
+	for _, p := range t.Players {
+		if p.BotType != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasBotAccounts returns true if any of the players logged in with a bot account
+// (as opposed to a bot that was added with the "tableAddBot" command)
+func (t *Table) HasBotAccounts() bool {
+	for _, p := range t.Players {
+		if p.BotAccount {
+			return true
+		}
+	}
+	return false
+}
+
+// StartBots gives every bot the initial information about the game and the actions that have
+// already happened
+// It is called after the game has started and also after a table is restored from disk
+// (the bot objects are not serialized, so they must be recreated)
+func (t *Table) StartBots() {
+	g := t.Game
+
+	playerNames := make([]string, 0)
+	for _, p := range t.Players {
+		playerNames = append(playerNames, p.Name)
+	}
+
+	for i, p := range t.Players {
+		if p.BotType == "" {
+			continue
+		}
+
+		if p.Bot == nil {
+			p.Bot = botConstructors[p.BotType]()
+		}
+		if p.Session == nil {
+			p.Session = newFakeSession(p.ID, p.Name)
+		}
+
+		// Bots never need to load the UI, so they are always present
+		p.Present = true
+
+		p.Bot.Start(&BotGameInfo{
+			Variant:     g.Variant,
+			Options:     g.Options,
+			PlayerNames: playerNames,
+			Seat:        i,
+		})
+		for _, a := range g.GetScrubbedActions(i) {
+			p.Bot.Observe(a)
+		}
+	}
+
+	// Humans start the timer when they finish loading the UI, but bots do not have a UI
+	if !g.StartedTimer && t.Players[g.ActivePlayerIndex].BotType != "" {
+		g.StartedTimer = true
+		g.DatetimeTurnBegin = time.Now()
+
+		if t.Options.Timed && !t.ExtraOptions.NoWriteToDatabase {
+			g.ScheduleTimer()
+		}
+	}
+
+	t.CheckBotTurn()
+}
+
+// CheckBotTurn schedules a bot to take its turn, if it is currently a bot's turn
+// The table lock must be held when calling this function
+func (t *Table) CheckBotTurn() {
+	g := t.Game
+
+	if !t.Running ||
+		t.Replay ||
+		g.EndCondition > engine.EndConditionInProgress ||
+		g.Paused {
+
+		return
+	}
+
+	p := t.Players[g.ActivePlayerIndex]
+	if p.Bot == nil {
+		return
+	}
+
+	numActions := len(g.Actions)
+	scheduler.Schedule(t, TaskBotTurn, BotActionDelay, func(t *Table) {
+		t.BotTakeTurn(p, numActions)
+	})
+}
+
+// BotTakeTurn is scheduled by "CheckBotTurn()"
+func (t *Table) BotTakeTurn(p *Player, numActions int) {
+	g := t.Game
+
+	// Don't do anything if the game has progressed in the meantime
+	// (e.g. someone paused the game or the bot was already scheduled to act)
+	if !t.Running ||
+		t.Replay ||
+		g.Paused ||
+		len(g.Actions) != numActions ||
+		t.Players[g.ActivePlayerIndex] != p {
+
+		return
+	}
+
+	a := p.Bot.Decide()
+	if a == nil {
+		logger.Error(t.GetName() + "The bot \"" + p.Name + "\" did not decide on an action.")
+		chatServerSend("\""+p.Name+"\" is stuck and cannot decide on a move.", t.GetRoomName())
+		return
+	}
+
+	commandAction(p.Session, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Type:    a.Type,
+		Target:  a.Target,
+		Value:   a.Value,
+		NoLock:  true,
+	})
+
+	if len(g.Actions) == numActions {
+		logger.Error(t.GetName() + "The bot \"" + p.Name + "\" performed an illegal action: " +
+			"type " + strconv.Itoa(a.Type) + ", target " + strconv.Itoa(a.Target) + ", " +
+			"value " + strconv.Itoa(a.Value))
+		chatServerSend("\""+p.Name+"\" is stuck and cannot decide on a move.", t.GetRoomName())
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// ReferenceBot is a simple built-in bot that only ever performs legal moves
+// It follows a single convention: every clue that it gives touches a card that is playable right
+// now, so it assumes that any card in its own hand that gets touched by a clue is playable
+// Otherwise, it discards the oldest unclued card in its hand
+type ReferenceBot struct {
+	Info *BotGameInfo
+
+	Hands             [][]*ReferenceBotCard
+	Stacks            []int // The rank of the last card played on each stack, or 0 if empty
+	ClueTokens        int
+	LastClueTypeGiven int
+}
+
+// ReferenceBotCard is a card in a hand as seen from the perspective of the bot
+// The suit index and the rank are -1 if the bot does not know the identity of the card
+type ReferenceBotCard struct {
+	Order     int
+	SuitIndex int
+	Rank      int
+	Clued     bool
+	// The rank that we have learned from a rank clue, or 0 if no rank clue has touched the card
+	ClueRank int
+}
+
+func NewReferenceBot() Bot {
+	return &ReferenceBot{}
+}
+
+func (b *ReferenceBot) Start(info *BotGameInfo) {
+	b.Info = info
+	b.Hands = make([][]*ReferenceBotCard, len(info.PlayerNames))
+	for i := range b.Hands {
+		b.Hands[i] = make([]*ReferenceBotCard, 0)
+	}
+	b.Stacks = make([]int, len(info.Variant.Suits))
+	b.ClueTokens = info.Variant.GetAdjustedClueTokens(info.Options.StartingClues)
+	b.LastClueTypeGiven = -1
+}
+
+func (b *ReferenceBot) Observe(action interface{}) {
+	switch a := action.(type) {
+	case engine.ActionDraw:
+		b.Hands[a.PlayerIndex] = append(b.Hands[a.PlayerIndex], &ReferenceBotCard{
+			Order:     a.Order,
+			SuitIndex: a.SuitIndex,
+			Rank:      a.Rank,
+		})
+
+	case engine.ActionCardIdentity:
+		if c := b.getCard(a.PlayerIndex, a.Order); c != nil {
+			c.SuitIndex = a.SuitIndex
+			c.Rank = a.Rank
+		}
+
+	case engine.ActionPlay:
+		b.removeCard(a.PlayerIndex, a.Order)
+		if a.SuitIndex >= 0 && a.SuitIndex < len(b.Stacks) {
+			b.Stacks[a.SuitIndex] = a.Rank
+		}
+
+	case engine.ActionDiscard:
+		b.removeCard(a.PlayerIndex, a.Order)
+
+	case engine.ActionClue:
+		b.LastClueTypeGiven = a.Clue.Type
+		for _, order := range a.List {
+			if c := b.getCard(a.Target, order); c != nil {
+				c.Clued = true
+				if a.Clue.Type == engine.ClueTypeRank {
+					c.ClueRank = a.Clue.Value
+				}
+			}
+		}
+
+	case engine.ActionStatus:
+		b.ClueTokens = a.Clues
+	}
+}
+
+func (b *ReferenceBot) Decide() *engine.GameAction {
+	v := b.Info.Variant
+	ourHand := b.Hands[b.Info.Seat]
+
+	// 1) Play a clued card, as long as we do not know it to be unplayable
+	for i := len(ourHand) - 1; i >= 0; i-- {
+		c := ourHand[i]
+		if c.Clued && (c.ClueRank == 0 || b.isRankPlayable(c.ClueRank)) {
+			return &engine.GameAction{
+				Type:   engine.ActionTypePlay,
+				Target: c.Order,
+			}
+		}
+	}
+
+	// 2) Give a clue that touches a playable card in someone else's hand
+	canClue := b.ClueTokens >= v.GetAdjustedClueTokens(1)
+	if canClue {
+		if a := b.findClue(true); a != nil {
+			return a
+		}
+	}
+
+	// 3) Discard the oldest unclued card
+	if !v.AtMaxClueTokens(b.ClueTokens, b.Info.Options.MaxClues) && len(ourHand) > 0 {
+		target := ourHand[0]
+		for _, c := range ourHand {
+			if !c.Clued {
+				target = c
+				break
+			}
+		}
+		return &engine.GameAction{
+			Type:   engine.ActionTypeDiscard,
+			Target: target.Order,
+		}
+	}
+
+	// 4) We are not allowed to discard, so give any clue that is legal
+	if canClue {
+		if a := b.findClue(false); a != nil {
+			return a
+		}
+	}
+
+	// 5) There is nothing else that we can do, so play the oldest card
+	if len(ourHand) > 0 {
+		return &engine.GameAction{
+			Type:   engine.ActionTypePlay,
+			Target: ourHand[0].Order,
+		}
+	}
+
+	return nil
+}
+
+// findClue returns the first legal clue that touches at least one card that we can see
+// If "onlyPlayable" is true, the clue must touch a playable card and must not touch any unplayable
+// cards (so that the receiver will not misplay)
+func (b *ReferenceBot) findClue(onlyPlayable bool) *engine.GameAction {
+	v := b.Info.Variant
+	numPlayers := len(b.Info.PlayerNames)
+
+	clues := make([]engine.Clue, 0)
+	for _, rank := range v.ClueRanks {
+		clues = append(clues, engine.Clue{
+			Type:  engine.ClueTypeRank,
+			Value: rank,
+		})
+	}
+	for i := range v.ClueColors {
+		clues = append(clues, engine.Clue{
+			Type:  engine.ClueTypeColor,
+			Value: i,
+		})
+	}
+
+	// Start with the next player, since they will be the first to act on the clue
+	for offset := 1; offset < numPlayers; offset++ {
+		target := (b.Info.Seat + offset) % numPlayers
+		for _, clue := range clues {
+			// Some variants forbid giving two clues of the same type in a row
+			if v.IsAlternatingClues() && clue.Type == b.LastClueTypeGiven {
+				continue
+			}
+
+			touchedPlayable := false
+			touchedUnplayable := false
+			touchedAny := false
+			for _, c := range b.Hands[target] {
+				if c.SuitIndex == -1 || c.Rank == -1 {
+					// We cannot see this card, so we cannot know whether the clue would touch it
+					touchedUnplayable = true
+					continue
+				}
+				if !v.IsCardTouched(clue, engine.NewCard(c.SuitIndex, c.Rank)) {
+					continue
+				}
+				touchedAny = true
+				if c.Clued {
+					// Re-cluing a card that is already clued gives the receiver no new information
+					continue
+				}
+				if b.isPlayable(c.SuitIndex, c.Rank) {
+					touchedPlayable = true
+				} else {
+					touchedUnplayable = true
+				}
+			}
+
+			if (onlyPlayable && touchedPlayable && !touchedUnplayable) ||
+				(!onlyPlayable && touchedAny) {
+
+				actionType := engine.ActionTypeColorClue
+				if clue.Type == engine.ClueTypeRank {
+					actionType = engine.ActionTypeRankClue
+				}
+				return &engine.GameAction{
+					Type:   actionType,
+					Target: target,
+					Value:  clue.Value,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *ReferenceBot) isPlayable(suitIndex int, rank int) bool {
+	suit := b.Info.Variant.Suits[suitIndex]
+	stackRank := b.Stacks[suitIndex]
+
+	if suit.Reversed {
+		if stackRank == 0 {
+			return rank == engine.PointsPerSuit
+		}
+		return rank == stackRank-1
+	}
+	return rank == stackRank+1
+}
+
+// isRankPlayable returns true if a card of the given rank would be playable on at least one stack
+func (b *ReferenceBot) isRankPlayable(rank int) bool {
+	for i := range b.Stacks {
+		if b.isPlayable(i, rank) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *ReferenceBot) getCard(playerIndex int, order int) *ReferenceBotCard {
+	if playerIndex < 0 || playerIndex >= len(b.Hands) {
+		return nil
+	}
+	for _, c := range b.Hands[playerIndex] {
+		if c.Order == order {
+			return c
+		}
+	}
+	return nil
+}
+
+func (b *ReferenceBot) removeCard(playerIndex int, order int) {
+	if playerIndex < 0 || playerIndex >= len(b.Hands) {
+		return
+	}
+	hand := b.Hands[playerIndex]
+	for i, c := range hand {
+		if c.Order == order {
+			b.Hands[playerIndex] = append(hand[:i], hand[i+1:]...)
+			return
+		}
+	}
+}
@@ -27,11 +27,21 @@ func chatCommandInit() {
 	chatCommandMap["random"] = chatRandom
 	chatCommandMap["uptime"] = chatUptime
 	chatCommandMap["timeleft"] = chatTimeLeft
+	chatCommandMap["exportdata"] = chatExportData
+	chatCommandMap["deleteaccount"] = chatDeleteAccount
 
 	// Undocumented info commands (that work only in the lobby)
 	chatCommandMap["badhere"] = chatBadHere
 	chatCommandMap["wrongchannel"] = chatWrongChannel
 
+	// Matchmaking commands (that work only in the lobby)
+	chatCommandMap["queue"] = chatQueue
+	chatCommandMap["unqueue"] = chatUnqueue
+
+	// Table-only commands (pregame only)
+	chatCommandMap["ready"] = chatReady
+	chatCommandMap["unready"] = chatUnready
+
 	// Table-only commands (pregame only, table owner only)
 	chatCommandMap["s"] = chatS
 	chatCommandMap["s2"] = chatS2
@@ -39,8 +49,12 @@ func chatCommandInit() {
 	chatCommandMap["s4"] = chatS4
 	chatCommandMap["s5"] = chatS5
 	chatCommandMap["s6"] = chatS6
+	chatCommandMap["s7"] = chatS7
+	chatCommandMap["s8"] = chatS8
 	chatCommandMap["startin"] = chatStartIn
+	chatCommandMap["requireready"] = chatRequireReady
 	chatCommandMap["kick"] = chatKick
+	chatCommandMap["addbot"] = chatAddBot
 
 	// Table-only commands (pregame or game)
 	chatCommandMap["missing"] = chatMissingScores
@@ -56,11 +70,20 @@ func chatCommandInit() {
 	// Table-only commands (game only)
 	chatCommandMap["pause"] = chatPause
 	chatCommandMap["unpause"] = chatUnpause
+	chatCommandMap["takeback"] = chatTakeback
+	chatCommandMap["undo"] = chatTakeback
+	chatCommandMap["accept"] = chatAccept
+	chatCommandMap["decline"] = chatDecline
 
 	// Table-only commands (replay only)
 	chatCommandMap["suggest"] = chatSuggest
 	chatCommandMap["tags"] = chatTags
 	chatCommandMap["taglist"] = chatTags
+	chatCommandMap["hypos"] = chatHypos
+	chatCommandMap["hypotheticals"] = chatHypos
+	chatCommandMap["hyposave"] = chatHypoSave
+	chatCommandMap["hypoload"] = chatHypoLoad
+	chatCommandMap["hypodelete"] = chatHypoDelete
 
 	// Discord-only commands
 	chatCommandMap["here"] = chatHere
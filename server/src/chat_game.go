@@ -37,3 +37,60 @@ func chatUnpause(s *Session, d *CommandData, t *Table) {
 		NoLock:  true,
 	})
 }
+
+// /takeback
+func chatTakeback(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, "lobby")
+		return
+	}
+
+	if !t.Running {
+		chatServerSend(NotStartedFail, d.Room)
+		return
+	}
+
+	commandTakeback(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Setting: "request",
+		NoLock:  true,
+	})
+}
+
+// /accept
+func chatAccept(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, "lobby")
+		return
+	}
+
+	if !t.Running {
+		chatServerSend(NotStartedFail, d.Room)
+		return
+	}
+
+	commandTakeback(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Setting: "accept",
+		NoLock:  true,
+	})
+}
+
+// /decline
+func chatDecline(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, "lobby")
+		return
+	}
+
+	if !t.Running {
+		chatServerSend(NotStartedFail, d.Room)
+		return
+	}
+
+	commandTakeback(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Setting: "decline",
+		NoLock:  true,
+	})
+}
@@ -216,3 +216,47 @@ func getTimeLeft() (string, error) {
 
 	return "Time left until server shutdown: " + durationString, nil
 }
+
+// /exportdata
+func chatExportData(s *Session, d *CommandData, t *Table) {
+	if d.Discord {
+		chatCommandWebsiteOnly(s, d, t)
+		return
+	}
+
+	if !accountExportStart(s.UserID(), s.Username()) {
+		chatServerSendPM(s, "Your archive is already being generated.", d.Room)
+		return
+	}
+
+	msg := "Your archive is being generated. You will get a message when it is ready to download. " +
+		"(It will be available for " + strconv.Itoa(int(AccountExportExpiration.Hours()/24)) +
+		" days.)"
+	chatServerSendPM(s, msg, d.Room)
+}
+
+// /deleteaccount [username]
+// The username must be typed to confirm that they really want to delete their account
+func chatDeleteAccount(s *Session, d *CommandData, t *Table) {
+	if d.Discord {
+		chatCommandWebsiteOnly(s, d, t)
+		return
+	}
+
+	if len(d.Args) != 1 || normalizeString(d.Args[0]) != normalizeString(s.Username()) {
+		msg := "This will permanently delete your account, your settings, your friends, " +
+			"your chat messages, your tags, your annotations, and your notes. " +
+			"Your games will be kept, but your name will be replaced with \"" +
+			getDeletedUserName(s.UserID()) + "\". If you want your data, use the /exportdata " +
+			"command first. To confirm, type: /deleteaccount " + s.Username()
+		chatServerSendPM(s, msg, d.Room)
+		return
+	}
+
+	if msg, err := deleteAccount(s.UserID(), s.Username()); err != nil {
+		logger.Error("Failed to delete the account for user \""+s.Username()+"\":", err)
+		chatServerSendPM(s, DefaultErrorMsg, d.Room)
+	} else if msg != "" {
+		chatServerSendPM(s, msg, d.Room)
+	}
+}
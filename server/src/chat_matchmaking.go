@@ -0,0 +1,83 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// e.g. "3" or "2-4"
+	matchmakingPlayersRegExp = regexp.MustCompile(`^(\d+)(?:-(\d+))?$`)
+)
+
+/*
+	Matchmaking chat commands (that work only in the lobby)
+*/
+
+// /queue [variant class] [min players]-[max players] [timed|untimed]
+// All of the arguments are optional and can be given in any order
+func chatQueue(s *Session, d *CommandData, t *Table) {
+	if d.Discord {
+		chatCommandWebsiteOnly(s, d, t)
+		return
+	}
+
+	if t != nil || d.Room != "lobby" {
+		chatServerSend(NotInLobbyFail, d.Room)
+		return
+	}
+
+	// If there are no arguments and they are already in the queue, show them their status
+	if len(d.Args) == 0 {
+		if entry := matchmakingGetEntry(s.UserID()); entry != nil {
+			chatServerSendPM(s, "You are in the matchmaking queue for "+entry.String()+". "+
+				"(There are "+strconv.Itoa(matchmakingGetNumQueued())+" players in the queue.)",
+				d.Room)
+			return
+		}
+	}
+
+	// By default, find an untimed game with 2 to 5 players on any variant
+	matchmakingData := &CommandData{ // Manual invocation
+		VariantClass: MatchmakingAnyClass,
+		MinPlayers:   2,
+		MaxPlayers:   5,
+	}
+	for _, arg := range d.Args {
+		arg = strings.ToLower(arg)
+		if arg == "" {
+			continue
+		} else if arg == "timed" {
+			matchmakingData.Timed = true
+		} else if arg == "untimed" {
+			matchmakingData.Timed = false
+		} else if match := matchmakingPlayersRegExp.FindStringSubmatch(arg); match != nil {
+			// The regular expression guarantees that these are numbers
+			matchmakingData.MinPlayers, _ = strconv.Atoi(match[1])
+			matchmakingData.MaxPlayers = matchmakingData.MinPlayers
+			if match[2] != "" {
+				matchmakingData.MaxPlayers, _ = strconv.Atoi(match[2])
+			}
+		} else {
+			matchmakingData.VariantClass = arg
+		}
+	}
+
+	commandMatchmakingJoin(s, matchmakingData)
+}
+
+// /unqueue
+func chatUnqueue(s *Session, d *CommandData, t *Table) {
+	if d.Discord {
+		chatCommandWebsiteOnly(s, d, t)
+		return
+	}
+
+	if t != nil || d.Room != "lobby" {
+		chatServerSend(NotInLobbyFail, d.Room)
+		return
+	}
+
+	commandMatchmakingLeave(s, &CommandData{}) // Manual invocation
+}
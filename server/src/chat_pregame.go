@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 /*
@@ -41,6 +43,16 @@ func chatS6(s *Session, d *CommandData, t *Table) {
 	automaticStart(s, d, t, 6)
 }
 
+// /s7 - Automatically start the game as soon as there are 7 players
+func chatS7(s *Session, d *CommandData, t *Table) {
+	automaticStart(s, d, t, 7)
+}
+
+// /s8 - Automatically start the game as soon as there are 8 players
+func chatS8(s *Session, d *CommandData, t *Table) {
+	automaticStart(s, d, t, 8)
+}
+
 // /startin [minutes]
 func chatStartIn(s *Session, d *CommandData, t *Table) {
 	if t == nil || d.Room == "lobby" {
@@ -98,7 +110,71 @@ func chatStartIn(s *Session, d *CommandData, t *Table) {
 	}
 	announcement += "."
 	chatServerSend(announcement, d.Room)
-	go startIn(t, timeToWait, timeToStart)
+	scheduler.Schedule(t, TaskStartIn, timeToWait, startIn)
+}
+
+// /ready
+func chatReady(s *Session, d *CommandData, t *Table) {
+	setReady(s, d, t, true)
+}
+
+// /unready
+func chatUnready(s *Session, d *CommandData, t *Table) {
+	setReady(s, d, t, false)
+}
+
+func setReady(s *Session, d *CommandData, t *Table, ready bool) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, d.Room)
+		return
+	}
+
+	if t.Running {
+		chatServerSend(StartedFail, d.Room)
+		return
+	}
+
+	commandTableReady(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Ready:   ready,
+		NoLock:  true,
+	})
+}
+
+// /requireready - Toggle whether or not everyone must be ready before the game can start
+func chatRequireReady(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, d.Room)
+		return
+	}
+
+	if t.Running {
+		chatServerSend(StartedFail, d.Room)
+		return
+	}
+
+	if s.UserID() != t.Owner {
+		chatServerSend(NotOwnerFail, d.Room)
+		return
+	}
+
+	// Tables that were created by the matchmaking queue always require everyone to be ready
+	if t.ExtraOptions.Matchmaking {
+		chatServerSend("You cannot change this setting at a table that was created by the "+
+			"matchmaking queue.", d.Room)
+		return
+	}
+
+	t.RequireReady = !t.RequireReady
+	t.NotifyPlayerChange()
+
+	msg := "The game can now start "
+	if t.RequireReady {
+		msg += "only once everyone is ready. (Type \"/ready\" when you are ready to play.)"
+	} else {
+		msg += "even if not everyone is ready."
+	}
+	chatServerSend(msg, d.Room)
 }
 
 func chatKick(s *Session, d *CommandData, t *Table) {
@@ -157,6 +233,36 @@ func chatKick(s *Session, d *CommandData, t *Table) {
 	chatServerSend("\""+d.Args[0]+"\" is not joined to this game.", d.Room)
 }
 
+// /addbot [type]
+func chatAddBot(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, d.Room)
+		return
+	}
+
+	if t.Running {
+		chatServerSend(StartedFail, d.Room)
+		return
+	}
+
+	if s.UserID() != t.Owner {
+		chatServerSend(NotOwnerFail, d.Room)
+		return
+	}
+
+	if len(d.Args) != 1 {
+		chatServerSend("The format of the /addbot command is: /addbot [type] "+
+			"(the valid bot types are: "+strings.Join(getBotTypes(), ", ")+")", d.Room)
+		return
+	}
+
+	commandTableAddBot(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Bot:     d.Args[0],
+		NoLock:  true,
+	})
+}
+
 /*
 	Pregame or game chat commands
 */
@@ -181,8 +287,9 @@ func chatMissingScores(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
-	if len(usernames) < 2 || len(usernames) > 6 {
-		msg := "You can only perform this command if the game or shared replay has between 2 and 6 players."
+	if len(usernames) < engine.MinPlayers || len(usernames) > engine.MaxPlayers {
+		msg := "You can only perform this command if the game or shared replay has between " +
+			strconv.Itoa(engine.MinPlayers) + " and " + strconv.Itoa(engine.MaxPlayers) + " players."
 		chatServerSend(msg, d.Room)
 		return
 	}
@@ -216,8 +323,9 @@ func chatFindVariant(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
-	if len(userIDs) < 2 || len(userIDs) > 6 {
-		msg := "You can only perform this command if the game or shared replay has between 2 and 6 players."
+	if len(userIDs) < engine.MinPlayers || len(userIDs) > engine.MaxPlayers {
+		msg := "You can only perform this command if the game or shared replay has between " +
+			strconv.Itoa(engine.MinPlayers) + " and " + strconv.Itoa(engine.MaxPlayers) + " players."
 		chatServerSend(msg, d.Room)
 		return
 	}
@@ -238,7 +346,7 @@ func chatFindVariant(s *Session, d *CommandData, t *Table) {
 	// Make a list of variants that no-one has the max score in
 	variantsWithNoMaxScores := make([]string, 0)
 	for _, variant := range variants {
-		maxScore := len(variant.Suits) * PointsPerSuit
+		maxScore := len(variant.Suits) * engine.PointsPerSuit
 		someoneHasMaxScore := false
 		for _, statsMap := range statsMaps {
 			if stats, ok := statsMap[variant.ID]; ok {
@@ -294,8 +402,9 @@ func automaticStart(s *Session, d *CommandData, t *Table, numPlayers int) {
 			numPlayers = v
 		}
 
-		if numPlayers < 2 || numPlayers > 6 {
-			chatServerSend("You can only start a table with 2 to 6 players.", d.Room)
+		if numPlayers < engine.MinPlayers || numPlayers > engine.MaxPlayers {
+			chatServerSend("You can only start a table with "+strconv.Itoa(engine.MinPlayers)+
+				" to "+strconv.Itoa(engine.MaxPlayers)+" players.", d.Room)
 			return
 		}
 	}
@@ -312,29 +421,13 @@ func automaticStart(s *Session, d *CommandData, t *Table, numPlayers int) {
 	}
 }
 
-// startIn is meant to be run in a goroutine
-func startIn(t *Table, timeToWait time.Duration, datetimePlannedStart time.Time) {
-	// Sleep until it is time to automatically start
-	time.Sleep(timeToWait)
-
-	// Check to see if the table still exists
-	t2, exists := getTableAndLock(nil, t.ID, false)
-	if !exists || t != t2 {
-		return
-	}
-	t.Mutex.Lock()
-	defer t.Mutex.Unlock()
-
+// startIn is scheduled by the "/startin" command
+func startIn(t *Table) {
 	// Check to see if the game has already started
 	if t.Running {
 		return
 	}
 
-	// Check to see if the planned start time has changed
-	if datetimePlannedStart != t.DatetimePlannedStart {
-		return
-	}
-
 	// Check to see if the owner is present
 	for _, p := range t.Players {
 		if p.ID == t.Owner {
@@ -344,6 +437,15 @@ func startIn(t *Table, timeToWait time.Duration, datetimePlannedStart time.Time)
 				return
 			}
 
+			if t.RequireReady {
+				if notReadyNames := t.GetNotReadyNames(); len(notReadyNames) > 0 {
+					msg := "Aborting automatic game start since not everyone is ready. " +
+						"(Waiting for: " + strings.Join(notReadyNames, ", ") + ")"
+					chatServerSend(msg, t.GetRoomName())
+					return
+				}
+			}
+
 			logger.Info(t.GetName() + " Automatically starting (from the /startin command).")
 			commandTableStart(p.Session, &CommandData{ // Manual invocation
 				TableID: t.ID,
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"html"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 // /suggest
@@ -77,3 +79,109 @@ func chatTags(s *Session, d *CommandData, t *Table) {
 		chatServerSend(msg, d.Room)
 	}
 }
+
+// /hypos
+func chatHypos(s *Session, d *CommandData, t *Table) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, "lobby")
+		return
+	}
+
+	if !t.Replay {
+		chatServerSend(NotReplayFail, d.Room)
+		return
+	}
+
+	if t.ExtraOptions.DatabaseID <= 0 {
+		chatServerSend("This game is not stored in the database, so it has no saved hypotheticals.",
+			d.Room)
+		return
+	}
+
+	// Get the hypotheticals from the database
+	var hypotheticals []*GameHypothetical
+	if v, err := models.GameHypotheticals.GetAll(t.ExtraOptions.DatabaseID); err != nil {
+		logger.Error("Failed to get the hypotheticals for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	} else {
+		hypotheticals = v
+	}
+
+	if len(hypotheticals) == 0 {
+		chatServerSend("There are not yet any saved hypotheticals for this game.", d.Room)
+		return
+	}
+
+	// Display the lines as a tree, where each line is listed underneath the line that it branched
+	// off from (e.g. "1.2)" is the second line that branched off from "1)")
+	children := make(map[int][]*GameHypothetical)
+	for _, h := range hypotheticals {
+		children[h.ParentID] = append(children[h.ParentID], h)
+	}
+
+	chatServerSend("The saved hypotheticals for this game are as follows:", d.Room)
+	var listHypos func(parentID int, prefix string)
+	listHypos = func(parentID int, prefix string) {
+		for i, h := range children[parentID] {
+			number := prefix + strconv.Itoa(i+1)
+			msg := number + ") " + h.Name + " (from turn " + strconv.Itoa(h.Segment+1) + ") - " +
+				getHypotheticalURL(h)
+			chatServerSend(msg, d.Room)
+			listHypos(h.ID, number+".")
+		}
+	}
+	listHypos(0, "")
+}
+
+// /hyposave [name]
+func chatHypoSave(s *Session, d *CommandData, t *Table) {
+	hypoReplayAction(s, d, t, ReplayActionTypeHypoSave, "hyposave")
+}
+
+// /hypoload [name]
+func chatHypoLoad(s *Session, d *CommandData, t *Table) {
+	hypoReplayAction(s, d, t, ReplayActionTypeHypoLoad, "hypoload")
+}
+
+// /hypodelete [name]
+func chatHypoDelete(s *Session, d *CommandData, t *Table) {
+	hypoReplayAction(s, d, t, ReplayActionTypeHypoDelete, "hypodelete")
+}
+
+func hypoReplayAction(s *Session, d *CommandData, t *Table, actionType int, command string) {
+	if t == nil || d.Room == "lobby" {
+		chatServerSend(NotInGameFail, "lobby")
+		return
+	}
+
+	if !t.Replay {
+		chatServerSend(NotReplayFail, d.Room)
+		return
+	}
+
+	if len(d.Args) == 0 {
+		chatServerSend("The format of the /"+command+" command is: /"+command+" [name]", d.Room)
+		return
+	}
+
+	// Chat messages are HTML-escaped before they reach the chat command handlers
+	name := html.UnescapeString(strings.Join(d.Args, " "))
+
+	commandReplayAction(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Type:    actionType,
+		Name:    name,
+		NoLock:  true,
+	})
+}
+
+func getHypotheticalURL(h *GameHypothetical) string {
+	protocol := "http"
+	if useTLS {
+		protocol += "s"
+	}
+	return protocol + "://" + domain + "/shared-replay/" + strconv.Itoa(h.GameID) +
+		"?hypothetical=" + strconv.Itoa(h.ID)
+}
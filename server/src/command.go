@@ -1,5 +1,9 @@
 package main
 
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
 type CommandData struct {
 	// various
 	TableID uint64 `json:"tableID"`
@@ -14,9 +18,15 @@ type CommandData struct {
 	Recipient string `json:"recipient"`
 
 	// tableCreate
-	Name     string   `json:"name"`
-	Options  *Options `json:"options"`
-	Password string   `json:"password"`
+	Name     string          `json:"name"`
+	Options  *engine.Options `json:"options"`
+	Password string          `json:"password"`
+
+	// tableAddBot
+	Bot string `json:"bot"`
+
+	// tableReady
+	Ready bool `json:"ready"`
 
 	// action
 	Type   int `json:"type"`
@@ -34,6 +44,8 @@ type CommandData struct {
 	Source     string    `json:"source"`
 	GameJSON   *GameJSON `json:"gameJSON"`
 	Visibility string    `json:"visibility"`
+	// The saved hypothetical to open the shared replay with (optional)
+	HypotheticalID int `json:"hypotheticalID"`
 
 	// sharedReplay
 	Segment int    `json:"segment"`
@@ -52,9 +64,18 @@ type CommandData struct {
 	// hypoAction
 	ActionJSON string `json:"actionJSON"`
 
+	// annotation
+	Turn int `json:"turn"`
+
 	// inactive
 	Inactive bool `json:"inactive"`
 
+	// matchmakingJoin
+	VariantClass string `json:"variantClass"`
+	MinPlayers   int    `json:"minPlayers"`
+	MaxPlayers   int    `json:"maxPlayers"`
+	Timed        bool   `json:"timed"`
+
 	// Used internally
 	// (a tag of "-" means that the JSON encoder will ignore the field)
 	Username string `json:"-"` // Used to mark the username of a chat message
@@ -73,55 +94,146 @@ type CommandData struct {
 	NoLock bool `json:"-"`
 }
 
+type Command struct {
+	Handler func(*Session, *CommandData)
+	// An empty value of the typed request, which is used to validate the data of the command and to
+	// generate the schema (see "protocol.go")
+	Request interface{}
+	// The message that the server sends back to the user, if the command has a direct reply
+	Response *CommandResponse
+
+	// Filled in by "protocolInit()"
+	fields   map[string]struct{}
+	required []string
+	enums    map[string][]string // The valid values of the fields with an "enum" tag
+}
+
+type CommandResponse struct {
+	Command string
+	Data    interface{} // An empty value used to generate the schema
+}
+
 var (
 	// Used to store all of the functions that handle each command
-	commandMap = make(map[string]func(*Session, *CommandData))
+	commandMap = make(map[string]*Command)
 )
 
 // Define all of the WebSocket commands
 func commandInit() {
 	// Table commands
-	commandMap["tableCreate"] = commandTableCreate
-	commandMap["tableJoin"] = commandTableJoin
-	commandMap["tableLeave"] = commandTableLeave
-	commandMap["tableUnattend"] = commandTableUnattend
-	commandMap["tableReattend"] = commandTableReattend
-	commandMap["tableSetVariant"] = commandTableSetVariant
-	commandMap["tableSetLeader"] = commandTableSetLeader
-	commandMap["tableStart"] = commandTableStart
-	commandMap["tableTerminate"] = commandTableTerminate
-	commandMap["tableSpectate"] = commandTableSpectate
-	commandMap["tableRestart"] = commandTableRestart
+	commandMap["tableCreate"] = &Command{Handler: commandTableCreate, Request: &TableCreateRequest{}}
+	commandMap["tableJoin"] = &Command{Handler: commandTableJoin, Request: &TableJoinRequest{}}
+	commandMap["tableLeave"] = &Command{
+		Handler:  commandTableLeave,
+		Request:  &TableRequest{},
+		Response: &CommandResponse{"left", &TableLeftMessage{}},
+	}
+	commandMap["tableUnattend"] = &Command{Handler: commandTableUnattend, Request: &TableRequest{}}
+	commandMap["tableReattend"] = &Command{Handler: commandTableReattend, Request: &TableRequest{}}
+	commandMap["tableSetVariant"] = &Command{
+		Handler: commandTableSetVariant,
+		Request: &TableSetVariantRequest{},
+	}
+	commandMap["tableSetLeader"] = &Command{
+		Handler: commandTableSetLeader,
+		Request: &TableSetLeaderRequest{},
+	}
+	commandMap["tableStart"] = &Command{Handler: commandTableStart, Request: &TableRequest{}}
+	commandMap["tableTerminate"] = &Command{Handler: commandTableTerminate, Request: &TableRequest{}}
+	commandMap["tableSpectate"] = &Command{
+		Handler: commandTableSpectate,
+		Request: &TableSpectateRequest{},
+	}
+	commandMap["tableRestart"] = &Command{Handler: commandTableRestart, Request: &TableRequest{}}
+	commandMap["tableAddBot"] = &Command{Handler: commandTableAddBot, Request: &TableAddBotRequest{}}
+	commandMap["tableReady"] = &Command{Handler: commandTableReady, Request: &TableReadyRequest{}}
 
 	// Other lobby commands
-	commandMap["setting"] = commandSetting
-	commandMap["chat"] = commandChat
-	commandMap["chatPM"] = commandChatPM
-	commandMap["chatRead"] = commandChatRead
-	commandMap["chatTyping"] = commandChatTyping
-	commandMap["chatFriend"] = commandChatFriend
-	commandMap["chatUnfriend"] = commandChatUnfriend
-	commandMap["chatPlayerInfo"] = commandChatPlayerInfo
-	commandMap["getName"] = commandGetName
-	commandMap["inactive"] = commandInactive
-	commandMap["historyGet"] = commandHistoryGet
-	commandMap["historyGetSeed"] = commandHistoryGetSeed
-	commandMap["historyFriendsGet"] = commandHistoryFriendsGet
-	commandMap["replayCreate"] = commandReplayCreate
-	commandMap["tagSearch"] = commandTagSearch
+	commandMap["setting"] = &Command{Handler: commandSetting, Request: &SettingRequest{}}
+	commandMap["chat"] = &Command{Handler: commandChat, Request: &ChatRequest{}}
+	commandMap["chatPM"] = &Command{Handler: commandChatPM, Request: &ChatPMRequest{}}
+	commandMap["chatRead"] = &Command{Handler: commandChatRead, Request: &TableRequest{}}
+	commandMap["chatTyping"] = &Command{Handler: commandChatTyping, Request: &TableRequest{}}
+	commandMap["chatFriend"] = &Command{
+		Handler:  commandChatFriend,
+		Request:  &ChatFriendRequest{},
+		Response: &CommandResponse{"friends", &FriendsMessage{}},
+	}
+	commandMap["chatUnfriend"] = &Command{
+		Handler:  commandChatUnfriend,
+		Request:  &ChatFriendRequest{},
+		Response: &CommandResponse{"friends", &FriendsMessage{}},
+	}
+	commandMap["chatPlayerInfo"] = &Command{
+		Handler: commandChatPlayerInfo,
+		Request: &ChatFriendRequest{},
+	}
+	commandMap["getName"] = &Command{
+		Handler:  commandGetName,
+		Request:  &EmptyRequest{},
+		Response: &CommandResponse{"name", &NameMessage{}},
+	}
+	commandMap["inactive"] = &Command{Handler: commandInactive, Request: &InactiveRequest{}}
+	commandMap["historyGet"] = &Command{
+		Handler:  commandHistoryGet,
+		Request:  &HistoryGetRequest{},
+		Response: &CommandResponse{"gameHistory", []*GameHistory{}},
+	}
+	commandMap["historyGetSeed"] = &Command{
+		Handler:  commandHistoryGetSeed,
+		Request:  &HistoryGetSeedRequest{},
+		Response: &CommandResponse{"gameHistoryOtherScores", &GameHistoryOtherScoresMessage{}},
+	}
+	commandMap["historyFriendsGet"] = &Command{
+		Handler:  commandHistoryFriendsGet,
+		Request:  &HistoryGetRequest{},
+		Response: &CommandResponse{"gameHistoryFriends", []*GameHistory{}},
+	}
+	commandMap["replayCreate"] = &Command{
+		Handler: commandReplayCreate,
+		Request: &ReplayCreateRequest{},
+	}
+	commandMap["tagSearch"] = &Command{Handler: commandTagSearch, Request: &TagSearchRequest{}}
+	commandMap["matchmakingJoin"] = &Command{
+		Handler: commandMatchmakingJoin,
+		Request: &MatchmakingJoinRequest{},
+	}
+	commandMap["matchmakingLeave"] = &Command{
+		Handler: commandMatchmakingLeave,
+		Request: &EmptyRequest{},
+	}
 
 	// Game and replay commands
-	commandMap["getGameInfo1"] = commandGetGameInfo1
-	commandMap["getGameInfo2"] = commandGetGameInfo2
-	commandMap["loaded"] = commandLoaded
-	commandMap["tag"] = commandTag
-	commandMap["tagDelete"] = commandTagDelete
+	commandMap["getGameInfo1"] = &Command{
+		Handler:  commandGetGameInfo1,
+		Request:  &TableRequest{},
+		Response: &CommandResponse{"init", &InitMessage{}},
+	}
+	commandMap["getGameInfo2"] = &Command{Handler: commandGetGameInfo2, Request: &TableRequest{}}
+	commandMap["loaded"] = &Command{Handler: commandLoaded, Request: &TableRequest{}}
+	commandMap["tag"] = &Command{Handler: commandTag, Request: &TagRequest{}}
+	commandMap["tagDelete"] = &Command{Handler: commandTagDelete, Request: &TagRequest{}}
+	commandMap["annotation"] = &Command{
+		Handler:  commandAnnotation,
+		Request:  &AnnotationRequest{},
+		Response: &CommandResponse{"chatList", &ChatListMessage{}},
+	}
+	commandMap["annotationDelete"] = &Command{
+		Handler: commandAnnotationDelete,
+		Request: &AnnotationDeleteRequest{},
+	}
 
 	// Game commands
-	commandMap["action"] = commandAction
-	commandMap["note"] = commandNote
-	commandMap["pause"] = commandPause
+	commandMap["action"] = &Command{Handler: commandAction, Request: &ActionRequest{}}
+	commandMap["note"] = &Command{Handler: commandNote, Request: &NoteRequest{}}
+	commandMap["pause"] = &Command{Handler: commandPause, Request: &PauseRequest{}}
+	commandMap["takeback"] = &Command{Handler: commandTakeback, Request: &TakebackRequest{}}
 
 	// Replay commands
-	commandMap["replayAction"] = commandReplayAction
+	commandMap["replayAction"] = &Command{
+		Handler: commandReplayAction,
+		Request: &ReplayActionRequest{},
+	}
+
+	protocolInit()
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"html"
+	"strconv"
+	"time"
+)
+
+// commandAnnotation is sent when a user types the "/annotate [turn] [text]" command
+//
+// Example data:
+// {
+//   tableID: 123,
+//   turn: 14,
+//   msg: 'This was a finesse, not a play clue.',
+// }
+func commandAnnotation(s *Session, d *CommandData) {
+	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
+	if !exists {
+		return
+	}
+	if !d.NoLock {
+		defer t.Mutex.Unlock()
+	}
+
+	if !validateAnnotationTable(s, d, t) {
+		return
+	}
+
+	// Sanitize and validate the annotation
+	if v, valid := sanitizeChatInput(s, d.Msg, false); !valid {
+		return
+	} else {
+		d.Msg = v
+	}
+
+	annotation(s, d, t)
+}
+
+func annotation(s *Session, d *CommandData, t *Table) {
+	if err := models.GameAnnotations.Insert(
+		t.ExtraOptions.DatabaseID,
+		s.UserID(),
+		d.Turn,
+		d.Msg,
+	); err != nil {
+		logger.Error("Failed to insert an annotation for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	}
+
+	// Show the new annotation to everyone in the shared replay
+	// (it is not added to the chat history, since anyone who joins later will get it from the
+	// database)
+	annotations := []*GameAnnotation{{
+		Turn:            d.Turn,
+		Username:        s.Username(),
+		Annotation:      d.Msg,
+		DatetimeCreated: time.Now(),
+	}}
+	for _, sp := range t.Spectators {
+		notifyAnnotations(sp.Session, t, annotations)
+	}
+}
+
+// validateAnnotationTable checks that the command was sent from a shared replay of a game that is
+// stored in the database and that the turn is valid
+func validateAnnotationTable(s *Session, d *CommandData, t *Table) bool {
+	// Local variables
+	g := t.Game
+
+	if !t.Replay || !t.Visible {
+		s.Warning("You can only annotate turns from a shared replay.")
+		return false
+	}
+
+	if t.ExtraOptions.DatabaseID <= 0 {
+		s.Warning("This game is not stored in the database, so it cannot be annotated.")
+		return false
+	}
+
+	if t.GetSpectatorIndexFromID(s.UserID()) == -1 {
+		s.Warning("You are not in shared replay " + strconv.FormatUint(t.ID, 10) + ".")
+		return false
+	}
+
+	// Turns are represented to the user as starting from 1
+	// The final turn is the one where the game ended
+	if d.Turn < 1 || d.Turn > g.EndTurn+1 {
+		s.Warning("The turn must be between 1 and " + strconv.Itoa(g.EndTurn+1) + ".")
+		return false
+	}
+
+	return true
+}
+
+// sendAnnotations is called when someone loads the replay of a game from the database
+func sendAnnotations(s *Session, t *Table) {
+	var annotations []*GameAnnotation
+	if v, err := models.GameAnnotations.GetAll(t.ExtraOptions.DatabaseID); err != nil {
+		logger.Error("Failed to get the annotations for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		return
+	} else {
+		annotations = v
+	}
+
+	if len(annotations) == 0 {
+		return
+	}
+
+	notifyAnnotations(s, t, annotations)
+}
+
+// notifyAnnotations displays annotations in the in-game chat of a replay
+// (they are sent as unread chat messages so that the client does not need to handle them
+// separately)
+func notifyAnnotations(s *Session, t *Table, annotations []*GameAnnotation) {
+	chatList := make([]*ChatMessage, 0)
+	for _, annotation := range annotations {
+		// Annotations are stored without being HTML-escaped
+		msg := "[Turn " + strconv.Itoa(annotation.Turn) + "] " +
+			html.EscapeString(annotation.Annotation)
+		chatList = append(chatList, &ChatMessage{
+			Msg:      msg,
+			Who:      annotation.Username,
+			Discord:  false,
+			Server:   false,
+			Datetime: annotation.DatetimeCreated,
+			Room:     t.GetRoomName(),
+		})
+	}
+	s.Emit("chatList", &ChatListMessage{
+		List:   chatList,
+		Unread: len(chatList),
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strconv"
+)
+
+// commandAnnotationDelete is sent when a user types the "/unannotate [turn]" command
+// It deletes all of the annotations that the user left on that turn
+//
+// Example data:
+// {
+//   tableID: 123,
+//   turn: 14,
+// }
+func commandAnnotationDelete(s *Session, d *CommandData) {
+	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
+	if !exists {
+		return
+	}
+	if !d.NoLock {
+		defer t.Mutex.Unlock()
+	}
+
+	if !validateAnnotationTable(s, d, t) {
+		return
+	}
+
+	annotationDelete(s, d, t)
+}
+
+func annotationDelete(s *Session, d *CommandData, t *Table) {
+	var numDeleted int
+	if v, err := models.GameAnnotations.Delete(
+		t.ExtraOptions.DatabaseID,
+		s.UserID(),
+		d.Turn,
+	); err != nil {
+		logger.Error("Failed to delete the annotations for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	} else {
+		numDeleted = v
+	}
+
+	if numDeleted == 0 {
+		s.Warning("You have not annotated turn " + strconv.Itoa(d.Turn) + " of this game.")
+		return
+	}
+
+	msg := s.Username() + " has deleted their annotation"
+	if numDeleted > 1 {
+		msg += "s"
+	}
+	msg += " for turn " + strconv.Itoa(d.Turn) + "."
+	chatServerSend(msg, t.GetRoomName())
+}
@@ -20,6 +20,10 @@ func commandChatUnfriend(s *Session, d *CommandData) {
 	friend(s, d, false)
 }
 
+type FriendsMessage struct {
+	Friends []string `json:"friends"`
+}
+
 func friend(s *Session, d *CommandData, add bool) {
 	// Validate that they sent a username
 	if len(d.Name) == 0 {
@@ -142,9 +146,6 @@ func friend(s *Session, d *CommandData, add bool) {
 	}
 
 	// Send them their (new) friends
-	type FriendsMessage struct {
-		Friends []string `json:"friends"`
-	}
 	s.Emit("friends", &FriendsMessage{
 		Friends: friends,
 	})
@@ -2,6 +2,7 @@ package main
 
 import (
 	"strconv"
+	"strings"
 )
 
 // commandChatPlayerInfo is sent when a user types the "/playerinfo" command
@@ -39,8 +40,28 @@ func commandChatPlayerInfo(s *Session, d *CommandData) {
 		numGames = v
 	}
 
-	msg := "\"" + d.Name + "\" has played " + strconv.Itoa(numGames) + " non-speedrun games. " +
-		"More stats " +
+	var ratings []*UserRating
+	if v, err := models.UserRatings.GetAll(user.ID); err != nil {
+		logger.Error("Failed to get the ratings for player \""+d.Name+"\":", err)
+		s.Error("Something went wrong when getting stats. Please contact an administrator.")
+		return
+	} else {
+		ratings = v
+	}
+
+	msg := "\"" + d.Name + "\" has played " + strconv.Itoa(numGames) + " non-speedrun games. "
+	ratingStrings := make([]string, 0)
+	for _, rating := range ratings {
+		if rating.NumGames > 0 {
+			ratingStrings = append(ratingStrings, rating.String()+" in "+
+				strings.ToLower(ratingClassNames[rating.VariantClass])+" variants "+
+				"(after "+strconv.Itoa(rating.NumGames)+" rated games)")
+		}
+	}
+	if len(ratingStrings) > 0 {
+		msg += "Their rating is " + strings.Join(ratingStrings, ", ") + ". "
+	}
+	msg += "More stats " +
 		"<a href=\"/scores/" + d.Name + "\" target=\"_blank\" rel=\"noopener noreferrer\">" +
 		"here</a>."
 	chatServerSendPM(s, msg, d.Room)
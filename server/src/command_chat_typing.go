@@ -42,19 +42,17 @@ func commandChatTyping(s *Session, d *CommandData) {
 }
 
 func chatTyping(s *Session, t *Table, playerIndex int, spectatorIndex int) {
-	// Update the "LastTyped" and "Typing" fields
+	// Update the "Typing" field
 	// Check for spectators first in case this is a shared replay that the player happened to be in
 	name := ""
 	if spectatorIndex != -1 {
 		sp := t.Spectators[spectatorIndex]
-		sp.LastTyped = time.Now()
 		if !sp.Typing {
 			sp.Typing = true
 			name = sp.Name
 		}
 	} else if playerIndex != -1 {
 		p := t.Players[playerIndex]
-		p.LastTyped = time.Now()
 		if !p.Typing {
 			p.Typing = true
 			name = p.Name
@@ -66,21 +64,20 @@ func chatTyping(s *Session, t *Table, playerIndex int, spectatorIndex int) {
 		t.NotifyChatTyping(name, true)
 	}
 
-	// X seconds from now, check to see if they have stopped typing
-	go chatTypingCheckStopped(t, s.UserID())
+	// If they do not type anything else in the next X seconds, they have stopped typing
+	// (every new keystroke pushes this deadline back)
+	userID := s.UserID()
+	scheduler.Schedule(t, getTypingTaskName(userID), TypingDelay, func(t *Table) {
+		chatTypingCheckStopped(t, userID)
+	})
 }
 
-func chatTypingCheckStopped(t *Table, userID int) {
-	time.Sleep(TypingDelay)
-
-	// Check to see if the table still exists
-	t2, exists := getTableAndLock(nil, t.ID, false)
-	if !exists || t != t2 {
-		return
-	}
-	t.Mutex.Lock()
-	defer t.Mutex.Unlock()
+// Every user has their own typing deadline
+func getTypingTaskName(userID int) string {
+	return "typing " + strconv.Itoa(userID)
+}
 
+func chatTypingCheckStopped(t *Table, userID int) {
 	// Validate that they are in the game or are a spectator
 	playerIndex := t.GetPlayerIndexFromID(userID)
 	spectatorIndex := t.GetSpectatorIndexFromID(userID)
@@ -99,19 +96,13 @@ func chatTypingCheckStopped(t *Table, userID int) {
 	name := ""
 	if spectatorIndex != -1 {
 		sp := t.Spectators[spectatorIndex]
-		if !sp.Typing {
-			return
-		}
-		if time.Since(sp.LastTyped) >= TypingDelay {
+		if sp.Typing {
 			sp.Typing = false
 			name = sp.Name
 		}
 	} else if playerIndex != -1 {
 		p := t.Players[playerIndex]
-		if !p.Typing {
-			return
-		}
-		if time.Since(p.LastTyped) >= TypingDelay {
+		if p.Typing {
 			p.Typing = false
 			name = p.Name
 		}
@@ -3,6 +3,8 @@ package main
 import (
 	"strconv"
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 // commandGetGameInfo1 provides some high-level information about the game
@@ -47,6 +49,36 @@ func commandGetGameInfo1(s *Session, d *CommandData) {
 	getGameInfo1(s, t, playerIndex, spectatorIndex)
 }
 
+type InitMessage struct {
+	// Game settings
+	TableID          uint64          `json:"tableID"`
+	PlayerNames      []string        `json:"playerNames"`
+	Variant          string          `json:"variant"`
+	OurPlayerIndex   int             `json:"ourPlayerIndex"`
+	Spectating       bool            `json:"spectating"`
+	Replay           bool            `json:"replay"`
+	DatabaseID       int             `json:"databaseID"`
+	HasCustomSeed    bool            `json:"hasCustomSeed"`
+	Seed             string          `json:"seed"`
+	DatetimeStarted  time.Time       `json:"datetimeStarted"`
+	DatetimeFinished time.Time       `json:"datetimeFinished"`
+	Options          *engine.Options `json:"options"`
+
+	// Character settings
+	CharacterAssignments []int `json:"characterAssignments"`
+	CharacterMetadata    []int `json:"characterMetadata"`
+
+	// Shared replay settings
+	SharedReplay        bool   `json:"sharedReplay"`
+	SharedReplayLeader  string `json:"sharedReplayLeader"`
+	SharedReplaySegment int    `json:"sharedReplaySegment"`
+
+	// Other features
+	Paused           bool `json:"paused"`
+	PausePlayerIndex int  `json:"pausePlayerIndex"`
+	PauseQueued      bool `json:"pauseQueued"`
+}
+
 func getGameInfo1(s *Session, t *Table, playerIndex int, spectatorIndex int) {
 	// Local variables
 	g := t.Game
@@ -102,36 +134,6 @@ func getGameInfo1(s *Session, t *Table, playerIndex int, spectatorIndex int) {
 		pauseQueued = g.Players[playerIndex].RequestedPause
 	}
 
-	type InitMessage struct {
-		// Game settings
-		TableID          uint64    `json:"tableID"`
-		PlayerNames      []string  `json:"playerNames"`
-		Variant          string    `json:"variant"`
-		OurPlayerIndex   int       `json:"ourPlayerIndex"`
-		Spectating       bool      `json:"spectating"`
-		Replay           bool      `json:"replay"`
-		DatabaseID       int       `json:"databaseID"`
-		HasCustomSeed    bool      `json:"hasCustomSeed"`
-		Seed             string    `json:"seed"`
-		DatetimeStarted  time.Time `json:"datetimeStarted"`
-		DatetimeFinished time.Time `json:"datetimeFinished"`
-		Options          *Options  `json:"options"`
-
-		// Character settings
-		CharacterAssignments []int `json:"characterAssignments"`
-		CharacterMetadata    []int `json:"characterMetadata"`
-
-		// Shared replay settings
-		SharedReplay        bool   `json:"sharedReplay"`
-		SharedReplayLeader  string `json:"sharedReplayLeader"`
-		SharedReplaySegment int    `json:"sharedReplaySegment"`
-
-		// Other features
-		Paused           bool `json:"paused"`
-		PausePlayerIndex int  `json:"pausePlayerIndex"`
-		PauseQueued      bool `json:"pauseQueued"`
-	}
-
 	s.Emit("init", &InitMessage{
 		// Game settings
 		TableID:          t.ID, // The client needs to know the table ID for chat to work properly
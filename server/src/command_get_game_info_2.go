@@ -42,34 +42,19 @@ func getGameInfo2(s *Session, t *Table, playerIndex int, spectatorIndex int) {
 	// Local variables
 	g := t.Game
 
-	// Check to see if we need to remove some card information
-	scrubbedActions := make([]interface{}, 0)
-	if !t.Replay {
-		for _, action := range g.Actions {
-			scrubbedAction := CheckScrub(t, action, s.UserID())
-			scrubbedActions = append(scrubbedActions, scrubbedAction)
-		}
-	} else {
-		// The person requesting the game state is not an active player
-		// (and not a spectator shadowing a player), so we do not need to hide any information
-		scrubbedActions = g.Actions
-	}
-
 	// Send them all the actions in the game that have happened thus far
-	type GameActionListMessage struct {
-		TableID uint64        `json:"tableID"`
-		List    []interface{} `json:"list"`
-	}
-	s.Emit("gameActionList", &GameActionListMessage{
-		TableID: t.ID,
-		List:    scrubbedActions,
-	})
+	s.NotifyGameActionList(t)
 
 	// Send them the full list of all the cards in the deck if the game is already over
 	if t.Replay {
 		s.NotifyCardIdentities(t)
 	}
 
+	// Send them the annotations that reviewers have left on this game, if any
+	if t.Replay && t.ExtraOptions.DatabaseID > 0 {
+		sendAnnotations(s, t)
+	}
+
 	// Check if the game is still in progress
 	if t.Replay {
 		// Since the game is over, send them the notes from all the players & spectators
@@ -26,14 +26,15 @@ func wordListInit() {
 	}
 }
 
+type NameMessage struct {
+	Name string `json:"name"`
+}
+
 // commandGetName is sent when the user makes a new game
 // It generate a new random table name for them
 //
 // Has no data
 func commandGetName(s *Session, d *CommandData) {
-	type NameMessage struct {
-		Name string `json:"name"`
-	}
 	s.Emit("name", &NameMessage{
 		Name: getName(),
 	})
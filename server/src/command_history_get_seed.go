@@ -4,6 +4,11 @@ const (
 	SeedSort = "score DESC, id ASC"
 )
 
+type GameHistoryOtherScoresMessage struct {
+	Games   []*GameHistory `json:"games"`
+	Friends bool           `json:"friends"`
+}
+
 // commandHistoryGetSeed is sent when the user clicks on the "Compare Scores" button
 //
 // Example data:
@@ -28,6 +33,22 @@ func commandHistoryGetSeed(s *Session, d *CommandData) {
 		return
 	}
 
+	// Tournament participants cannot compare scores until the round is over
+	if hidden, err := models.TournamentRounds.IsSeedHidden(d.Seed, s.UserID()); err != nil {
+		logger.Error("Failed to check to see if seed \""+d.Seed+"\" is hidden:", err)
+		s.Error(DefaultErrorMsg)
+		return
+	} else if hidden {
+		s.Warning("That seed is being played in a tournament round that is still in progress, " +
+			"so you cannot see the other scores until the round is over.")
+		return
+	}
+
+	// The games on a daily challenge cannot be viewed until it has been played
+	if !dailyChallengeValidateView(s, d.Seed) {
+		return
+	}
+
 	// Get the list of game IDs played on this seed
 	var gameIDs []int
 	if v, err := models.Games.GetGameIDsSeed(d.Seed); err != nil {
@@ -49,10 +70,6 @@ func commandHistoryGetSeed(s *Session, d *CommandData) {
 		gameHistoryList = v
 	}
 
-	type GameHistoryOtherScoresMessage struct {
-		Games   []*GameHistory `json:"games"`
-		Friends bool           `json:"friends"`
-	}
 	s.Emit("gameHistoryOtherScores", &GameHistoryOtherScoresMessage{
 		Games:   gameHistoryList,
 		Friends: d.Friends,
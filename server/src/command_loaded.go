@@ -50,7 +50,7 @@ func commandLoaded(s *Session, d *CommandData) {
 
 		// Start the countdown for when the active player runs out of time
 		if t.Options.Timed && !t.ExtraOptions.NoWriteToDatabase {
-			go g.CheckTimer(g.Turn, g.PauseCount, g.Players[g.ActivePlayerIndex])
+			g.ScheduleTimer()
 		}
 	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// commandMatchmakingJoin is sent when the user wants the server to find a game for them
+// If they are already in the queue, their preferences are updated
+// (but they do not lose their place in line)
+//
+// Example data:
+// {
+//   variantClass: 'easy', // One of the keys of "matchmakingVariantClasses" or 'any'
+//   minPlayers: 2,
+//   maxPlayers: 4,
+//   timed: false,
+// }
+func commandMatchmakingJoin(s *Session, d *CommandData) {
+	// Validate that the server is not about to go offline
+	if checkImminentShutdown(s) {
+		return
+	}
+
+	// Validate that the server is not undergoing maintenance
+	if maintenanceMode.IsSet() {
+		s.Warning("The server is undergoing maintenance. " +
+			"You cannot start any new games for the time being.")
+		return
+	}
+
+	// Validate that the player is not joined to another table
+	if t := s.GetJoinedTable(); t != nil {
+		s.Warning("You cannot enter the matchmaking queue while you are at a table.")
+		return
+	}
+
+	// Validate the variant class
+	d.VariantClass = strings.ToLower(strings.TrimSpace(d.VariantClass))
+	if d.VariantClass == "" {
+		d.VariantClass = MatchmakingAnyClass
+	}
+	if !isValidMatchmakingVariantClass(d.VariantClass) {
+		s.Warning("\"" + d.VariantClass + "\" is not a valid variant class. The valid classes " +
+			"are: " + strings.Join(getMatchmakingVariantClasses(), ", ") + ", " +
+			MatchmakingAnyClass)
+		return
+	}
+
+	// Validate the range of players
+	if d.MinPlayers < engine.MinPlayers || d.MaxPlayers > engine.MaxPlayers {
+		s.Warning("The number of players must be between " + strconv.Itoa(engine.MinPlayers) +
+			" and " + strconv.Itoa(engine.MaxPlayers) + ".")
+		return
+	}
+	if d.MinPlayers > d.MaxPlayers {
+		s.Warning("The minimum number of players cannot be greater than the maximum number of " +
+			"players.")
+		return
+	}
+
+	entry := &MatchmakingEntry{
+		Session:        s,
+		VariantClass:   d.VariantClass,
+		MinPlayers:     d.MinPlayers,
+		MaxPlayers:     d.MaxPlayers,
+		Timed:          d.Timed,
+		DatetimeQueued: time.Now(),
+	}
+	numQueued := matchmakingGetNumQueued()
+	if oldEntry := matchmakingGetEntry(s.UserID()); oldEntry != nil {
+		entry.DatetimeQueued = oldEntry.DatetimeQueued
+	} else {
+		numQueued++
+	}
+
+	logger.Info("User \"" + s.Username() + "\" joined the matchmaking queue for " +
+		entry.String() + ".")
+	chatServerSendPM(s, "You are now in the matchmaking queue for "+entry.String()+". "+
+		"(There are "+strconv.Itoa(numQueued)+" players in the queue.)", "lobby")
+	matchmakingAdd(entry)
+}
@@ -0,0 +1,15 @@
+package main
+
+// commandMatchmakingLeave is sent when the user no longer wants the server to find a game for them
+//
+// Example data:
+// {}
+func commandMatchmakingLeave(s *Session, d *CommandData) {
+	if !matchmakingRemove(s.UserID()) {
+		s.Warning("You are not in the matchmaking queue.")
+		return
+	}
+
+	logger.Info("User \"" + s.Username() + "\" left the matchmaking queue.")
+	chatServerSendPM(s, "You have left the matchmaking queue.", "lobby")
+}
@@ -101,10 +101,14 @@ func pause(s *Session, d *CommandData, t *Table, playerIndex int) {
 	if d.Setting == "pause" {
 		g.Paused = true
 		g.PausePlayerIndex = playerIndex
-		g.PauseCount++
 
-		// Decrement the time that the player has taken so far prior to this pause
-		p.Time -= time.Since(g.DatetimeTurnBegin)
+		// The active player cannot run out of time (or take their turn, if they are a bot) while the
+		// game is paused
+		g.CancelTimer()
+		scheduler.Cancel(t, TaskBotTurn)
+
+		// Decrement the time that the active player has taken so far prior to this pause
+		g.TurnTimeBeforePause = g.ChargeTurnTime()
 	} else if d.Setting == "unpause" {
 		g.Paused = false
 		g.PausePlayerIndex = -1
@@ -113,10 +117,8 @@ func pause(s *Session, d *CommandData, t *Table, playerIndex int) {
 		// but this variable is only used for decrementing time taken at the end of a player's turn
 		g.DatetimeTurnBegin = time.Now()
 
-		// Restart the function that will check to see if the current player has run out of time
-		// (the old "CheckTimer()" invocation will return and do nothing because the pause count of
-		// the game will not match)
-		go g.CheckTimer(g.Turn, g.PauseCount, g.Players[g.ActivePlayerIndex])
+		// Schedule the current player to run out of time with the time that they have left
+		g.ScheduleTimer()
 	}
 
 	t.NotifyPause()
@@ -140,5 +142,8 @@ func pause(s *Session, d *CommandData, t *Table, playerIndex int) {
 	// Send everyone new clock values
 	if d.Setting == "unpause" {
 		t.NotifyTime()
+
+		// If it is a bot's turn, they were waiting for the game to be unpaused
+		t.CheckBotTurn()
 	}
 }
@@ -2,9 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxHypoNameLength = 100
 )
 
 var (
@@ -22,6 +30,9 @@ func replayActionsFunctionsInit() {
 		ReplayActionTypeHypoAction:     replayActionHypoAction,
 		ReplayActionTypeHypoBack:       replayActionHypoBack,
 		ReplayActionTypeToggleRevealed: replayActionToggleRevealed,
+		ReplayActionTypeHypoSave:       replayActionHypoSave,
+		ReplayActionTypeHypoLoad:       replayActionHypoLoad,
+		ReplayActionTypeHypoDelete:     replayActionHypoDelete,
 	}
 }
 
@@ -32,7 +43,7 @@ func replayActionsFunctionsInit() {
 //   tableID: 5,
 //   type: 0, // Types are listed in the "constants.go" file
 //   value: 10, // Optional
-//   name: 'Alice', // Optional
+//   name: 'Alice', // Optional (also used for the name of a saved hypothetical)
 // }
 func commandReplayAction(s *Session, d *CommandData) {
 	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
@@ -67,7 +78,7 @@ func commandReplayAction(s *Session, d *CommandData) {
 
 func replayAction(s *Session, d *CommandData, t *Table) {
 	// Start the idle timeout
-	go t.CheckIdle()
+	t.ScheduleIdle()
 
 	// Do different tasks depending on the action
 	if replayActionFunction, ok := replayActionFunctions[d.Type]; ok {
@@ -85,6 +96,7 @@ func replayActionSegment(s *Session, d *CommandData, t *Table) {
 	// Change the segment
 	// (we borrow the turn variable to use as a stand-in for the current shared replay segment)
 	g.Turn = d.Segment
+	t.UpdateReplayProgress()
 
 	// Notify everyone
 	type ReplaySegmentMessage struct {
@@ -98,8 +110,13 @@ func replayActionSegment(s *Session, d *CommandData, t *Table) {
 	for _, sp := range t.Spectators {
 		sp.Session.Emit("replaySegment", replaySegmentMessage)
 	}
+}
+
+// UpdateReplayProgress updates the progress of a shared replay based on the current segment
+func (t *Table) UpdateReplayProgress() {
+	// Local variables
+	g := t.Game
 
-	// Update the progress
 	progressFloat := float64(g.Turn) / float64(g.EndTurn) * 100 // In percent
 	progress := int(math.Round(progressFloat))
 	if progress > 100 {
@@ -162,6 +179,9 @@ func replayActionHypoStart(s *Session, d *CommandData, t *Table) {
 
 	// Start a hypothetical line
 	g.Hypothetical = true
+	g.HypoSegment = g.Turn
+	g.HypoBranchID = 0
+	g.HypoUnsaved = false
 
 	type HypoStartMessage struct {
 		TableID uint64
@@ -183,9 +203,16 @@ func replayActionHypoEnd(s *Session, d *CommandData, t *Table) {
 		return
 	}
 
+	// Moves that were not saved with "/hyposave" are lost
+	if g.HypoUnsaved && len(g.HypoActions) > 0 {
+		chatServerSend("The hypothetical ended without saving the latest moves.", t.GetRoomName())
+	}
+
 	// End a hypothetical line
 	g.Hypothetical = false
 	g.HypoActions = make([]string, 0)
+	g.HypoBranchID = 0
+	g.HypoUnsaved = false
 
 	type HypoEndMessage struct {
 		TableID uint64
@@ -217,6 +244,7 @@ func replayActionHypoAction(s *Session, d *CommandData, t *Table) {
 
 	// Perform a move in the hypothetical
 	g.HypoActions = append(g.HypoActions, d.ActionJSON)
+	g.HypoUnsaved = true
 
 	for _, sp := range t.Spectators {
 		sp.Session.Emit("hypoAction", d.ActionJSON)
@@ -247,6 +275,7 @@ func replayActionHypoBack(s *Session, d *CommandData, t *Table) {
 			break
 		}
 	}
+	g.HypoUnsaved = true
 
 	type HypoBackMessage struct {
 		TableID uint64
@@ -264,6 +293,9 @@ func replayActionToggleRevealed(s *Session, d *CommandData, t *Table) {
 	g := t.Game
 
 	g.HypoDrawnCardsShown = !g.HypoDrawnCardsShown
+	if g.Hypothetical {
+		g.HypoUnsaved = true
+	}
 
 	type HypoDrawnCardsShownMessage struct {
 		TableID         uint64 `json:"tableID"`
@@ -277,3 +309,232 @@ func replayActionToggleRevealed(s *Session, d *CommandData, t *Table) {
 		sp.Session.Emit("hypoDrawnCardsShown", hypoDrawnCardsShownMessage)
 	}
 }
+
+func replayActionHypoSave(s *Session, d *CommandData, t *Table) {
+	// Local variables
+	g := t.Game
+
+	if !g.Hypothetical {
+		s.Warning("You are not in a hypothetical, so you cannot save one.")
+		return
+	}
+
+	if t.ExtraOptions.DatabaseID <= 0 {
+		s.Warning("Hypotheticals can only be saved for games that are stored in the database.")
+		return
+	}
+
+	var name string
+	if v, err := sanitizeHypoName(d.Name); err != nil {
+		s.Warning(err.Error())
+		return
+	} else {
+		name = v
+	}
+
+	var h *GameHypothetical
+	exists := false
+	if v1, v2, err := models.GameHypotheticals.Get(t.ExtraOptions.DatabaseID, name); err != nil {
+		logger.Error("Failed to get the hypothetical \""+name+"\" for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	} else {
+		exists = v1
+		h = v2
+	}
+
+	// Saving with the name of the current line updates it;
+	// saving with a new name creates a new line that branches off from the current line
+	if exists && h.ID != g.HypoBranchID {
+		s.Warning("There is already a saved hypothetical named \"" + name + "\". " +
+			"(Load it first if you want to change it.)")
+		return
+	}
+
+	h.Segment = g.HypoSegment
+	h.DrawnCardsShown = g.HypoDrawnCardsShown
+	h.Actions = g.HypoActions
+
+	if exists {
+		if err := models.GameHypotheticals.Update(h); err != nil {
+			logger.Error("Failed to update hypothetical "+strconv.Itoa(h.ID)+":", err)
+			s.Error(DefaultErrorMsg)
+			return
+		}
+	} else {
+		h.GameID = t.ExtraOptions.DatabaseID
+		h.Name = name
+
+		// The line that we branched off from might have been deleted from another shared replay
+		if g.HypoBranchID != 0 {
+			if parentExists, _, err := models.GameHypotheticals.GetByID(g.HypoBranchID); err != nil {
+				logger.Error("Failed to get hypothetical "+strconv.Itoa(g.HypoBranchID)+":", err)
+				s.Error(DefaultErrorMsg)
+				return
+			} else if parentExists {
+				h.ParentID = g.HypoBranchID
+			}
+		}
+
+		if v, err := models.GameHypotheticals.Insert(h); err != nil {
+			logger.Error("Failed to insert a hypothetical for game ID "+
+				strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+			s.Error(DefaultErrorMsg)
+			return
+		} else {
+			h.ID = v
+		}
+	}
+
+	g.HypoBranchID = h.ID
+	g.HypoUnsaved = false
+
+	msg := s.Username() + " has saved the hypothetical as \"" + name + "\": " +
+		getHypotheticalURL(h)
+	chatServerSend(msg, t.GetRoomName())
+}
+
+func replayActionHypoLoad(s *Session, d *CommandData, t *Table) {
+	// Local variables
+	g := t.Game
+
+	var h *GameHypothetical
+	if v, success := getSavedHypothetical(s, d, t); !success {
+		return
+	} else {
+		h = v
+	}
+
+	if h.Segment > g.EndTurn {
+		s.Warning("The hypothetical \"" + h.Name + "\" starts after the end of the game.")
+		return
+	}
+
+	if g.HypoUnsaved && len(g.HypoActions) > 0 {
+		chatServerSend("The previous hypothetical was replaced without saving the latest moves.",
+			t.GetRoomName())
+	}
+
+	g.LoadHypothetical(h)
+
+	// Bring everyone to the start of the line and then replay all of its moves
+	type HypoLoadMessage struct {
+		TableID         uint64   `json:"tableID"`
+		Segment         int      `json:"segment"`
+		DrawnCardsShown bool     `json:"drawnCardsShown"`
+		Actions         []string `json:"actions"`
+	}
+	hypoLoadMessage := &HypoLoadMessage{
+		TableID:         t.ID,
+		Segment:         g.HypoSegment,
+		DrawnCardsShown: g.HypoDrawnCardsShown,
+		Actions:         g.HypoActions,
+	}
+	for _, sp := range t.Spectators {
+		sp.Session.Emit("hypoLoad", hypoLoadMessage)
+	}
+
+	msg := s.Username() + " has loaded the hypothetical \"" + h.Name + "\"."
+	chatServerSend(msg, t.GetRoomName())
+}
+
+// LoadHypothetical replaces the current hypothetical with a saved line
+// (the caller is responsible for notifying the spectators)
+func (g *Game) LoadHypothetical(h *GameHypothetical) {
+	g.Hypothetical = true
+	g.Turn = h.Segment
+	g.HypoSegment = h.Segment
+	g.HypoActions = h.Actions
+	g.HypoDrawnCardsShown = h.DrawnCardsShown
+	g.HypoBranchID = h.ID
+	g.HypoUnsaved = false
+	g.Table.UpdateReplayProgress()
+}
+
+func replayActionHypoDelete(s *Session, d *CommandData, t *Table) {
+	// Local variables
+	g := t.Game
+
+	var h *GameHypothetical
+	if v, success := getSavedHypothetical(s, d, t); !success {
+		return
+	} else {
+		h = v
+	}
+
+	// Any lines that branched off from this one will become top-level lines
+	if err := models.GameHypotheticals.Delete(h.ID); err != nil {
+		logger.Error("Failed to delete hypothetical "+strconv.Itoa(h.ID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	}
+
+	// The current line is kept, but it is no longer associated with a saved line
+	if g.HypoBranchID == h.ID {
+		g.HypoBranchID = 0
+		g.HypoUnsaved = true
+	}
+
+	msg := s.Username() + " has deleted the hypothetical \"" + h.Name + "\"."
+	chatServerSend(msg, t.GetRoomName())
+}
+
+// getSavedHypothetical looks up the saved hypothetical that matches the name in the command
+func getSavedHypothetical(s *Session, d *CommandData, t *Table) (*GameHypothetical, bool) {
+	if t.ExtraOptions.DatabaseID <= 0 {
+		s.Warning("This game is not stored in the database, so it has no saved hypotheticals.")
+		return nil, false
+	}
+
+	var name string
+	if v, err := sanitizeHypoName(d.Name); err != nil {
+		s.Warning(err.Error())
+		return nil, false
+	} else {
+		name = v
+	}
+
+	if exists, v, err := models.GameHypotheticals.Get(t.ExtraOptions.DatabaseID, name); err != nil {
+		logger.Error("Failed to get the hypothetical \""+name+"\" for game ID "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return nil, false
+	} else if !exists {
+		s.Warning("There is no saved hypothetical named \"" + name + "\".")
+		return nil, false
+	} else {
+		return v, true
+	}
+}
+
+func sanitizeHypoName(name string) (string, error) {
+	// Validate name length
+	if len(name) > MaxHypoNameLength {
+		return name, errors.New("Hypothetical names cannot be longer than " +
+			strconv.Itoa(MaxHypoNameLength) + " characters.")
+	}
+
+	// Check for valid UTF8
+	if !utf8.Valid([]byte(name)) {
+		return name, errors.New("Hypothetical names must contain valid UTF8 characters.") // nolint: golint, stylecheck
+	}
+
+	// Replace any whitespace that is not a space with a space
+	name2 := name
+	for _, letter := range name2 {
+		if unicode.IsSpace(letter) && letter != ' ' {
+			name = strings.ReplaceAll(name, string(letter), " ")
+		}
+	}
+
+	// Trim whitespace from both sides
+	name = strings.TrimSpace(name)
+
+	// Validate blank names
+	if name == "" {
+		return name, errors.New("Hypothetical names cannot be blank.") // nolint: golint, stylecheck
+	}
+
+	return normalizeString(name), nil
+}
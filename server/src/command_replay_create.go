@@ -4,14 +4,15 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	melody "gopkg.in/olahol/melody.v1"
 )
 
 type GameJSON struct {
-	ID      int             `json:"id,omitempty"` // Optional element only used for game exports
-	Players []string        `json:"players"`
-	Deck    []*CardIdentity `json:"deck"`
-	Actions []*GameAction   `json:"actions"`
+	ID      int                    `json:"id,omitempty"` // Optional element only used for game exports
+	Players []string               `json:"players"`
+	Deck    []*engine.CardIdentity `json:"deck"`
+	Actions []*engine.GameAction   `json:"actions"`
 	// Options is an optional element
 	// Thus, it must be a pointer so that we can tell if the value was specified or not
 	Options *OptionsJSON `json:"options,omitempty"`
@@ -19,15 +20,14 @@ type GameJSON struct {
 	Notes [][]string `json:"notes,omitempty"`
 	// Characters is an optional element that specifies the "Detrimental Character" assignment for
 	// each player, if any
-	Characters []*CharacterAssignment `json:"characters,omitempty"`
+	Characters []*engine.CharacterAssignment `json:"characters,omitempty"`
 	// Seed is an optional value that specifies the server-side seed for the game (e.g. "p2v0s1")
 	// This allows the server to reconstruct the game without the deck being present and to properly
 	// write the game back to the database
 	Seed string `json:"seed,omitempty"`
-}
-type CharacterAssignment struct {
-	Name     string `json:"name"`
-	Metadata int    `json:"metadata"`
+	// Annotations is an optional element that contains the commentary that reviewers left on
+	// specific turns (it is only used for game exports)
+	Annotations []*GameAnnotation `json:"annotations,omitempty"`
 }
 
 // commandReplayCreate is sent when the user clicks on the "Watch Replay", "Share Replay",
@@ -43,6 +43,7 @@ type CharacterAssignment struct {
 //   gameID: 15103, // Only if source is "id"
 //   json: '{"actions"=[],"deck"=[]}', // Only if source is "json"
 //   visibility: 'solo', // Can also be "shared"
+//   hypotheticalID: 5, // Optional (only if source is "id" and visibility is "shared")
 // }
 func commandReplayCreate(s *Session, d *CommandData) {
 	// Validate that there is not a password
@@ -168,6 +169,21 @@ func commandReplayCreate(s *Session, d *CommandData) {
 		}
 	}
 
+	// Open the shared replay directly to a saved hypothetical, if one was specified
+	// (e.g. from a "/shared-replay/123?hypothetical=5" URL)
+	if d.HypotheticalID != 0 && t.Visible && d.Source == "id" {
+		if exists, h, err := models.GameHypotheticals.GetByID(d.HypotheticalID); err != nil {
+			logger.Error("Failed to get hypothetical "+strconv.Itoa(d.HypotheticalID)+":", err)
+			s.Error(InitGameFail)
+			deleteTable(t)
+			return
+		} else if !exists || h.GameID != d.GameID || h.Segment > g.EndTurn {
+			s.Warning("That hypothetical does not exist for game #" + strconv.Itoa(d.GameID) + ".")
+		} else {
+			g.LoadHypothetical(h)
+		}
+	}
+
 	// Join the user to the new replay
 	commandTableSpectate(s, &CommandData{ // Manual invocation
 		TableID:              t.ID,
@@ -177,7 +193,7 @@ func commandReplayCreate(s *Session, d *CommandData) {
 	t.Owner = s.UserID()
 
 	// Start the idle timeout
-	go t.CheckIdle()
+	t.ScheduleIdle()
 
 	// The "commandTableSpectate()" function above sends the user the "tableStart" message
 	// After the client receives the "tableStart" message, they will send a "getGameInfo1" command
@@ -195,7 +211,13 @@ func validateDatabase(s *Session, d *CommandData) bool {
 		return false
 	}
 
-	return true
+	// Tournament participants cannot view the games of the other teams until the round is over
+	if !tournamentValidateReplay(s, d.GameID) {
+		return false
+	}
+
+	// Daily challenges cannot be viewed by people who have not played them until the day is over
+	return dailyChallengeValidateReplay(s, d.GameID)
 }
 
 func validateJSON(s *Session, d *CommandData) bool {
@@ -213,15 +235,65 @@ func validateJSON(s *Session, d *CommandData) bool {
 		d.GameJSON.Options.Variant = &variantText
 	}
 
+	// Custom variants are reconstructed from their definition, since the name of a custom variant
+	// is only meaningful on the server that created it
+	if d.GameJSON.Options.CustomVariant != nil {
+		if v := createCustomVariant(s, d.GameJSON.Options.CustomVariant); v == nil {
+			return false
+		} else {
+			d.GameJSON.Options.Variant = &v.Name
+		}
+	}
+
 	// Validate that the specified variant exists
-	var variant *Variant
-	if v, ok := variants[*d.GameJSON.Options.Variant]; !ok {
+	var variant *engine.Variant
+	if v := getVariant(*d.GameJSON.Options.Variant); v == nil {
 		s.Warning("\"" + *d.GameJSON.Options.Variant + "\" is not a valid variant.")
 		return false
 	} else {
 		variant = v
 	}
 
+	// Validate the clue and strike limits, if specified
+	maxClues := engine.MaxClueNum
+	if d.GameJSON.Options.MaxClues != nil {
+		maxClues = *d.GameJSON.Options.MaxClues
+		if maxClues < 1 || maxClues > engine.MaxCustomClueNum {
+			s.Warning("\"" + strconv.Itoa(maxClues) + "\" is not a valid value for \"maxClues\".")
+			return false
+		}
+	}
+	if d.GameJSON.Options.StartingClues != nil {
+		startingClues := *d.GameJSON.Options.StartingClues
+		if startingClues < 0 || startingClues > maxClues {
+			s.Warning("\"" + strconv.Itoa(startingClues) + "\" is not a valid value for \"startingClues\".")
+			return false
+		}
+	}
+	if d.GameJSON.Options.MaxStrikes != nil {
+		maxStrikes := *d.GameJSON.Options.MaxStrikes
+		if maxStrikes < 1 || maxStrikes > engine.MaxCustomStrikeNum {
+			s.Warning("\"" + strconv.Itoa(maxStrikes) + "\" is not a valid value for \"maxStrikes\".")
+			return false
+		}
+	}
+
+	// Validate the time controls, if specified
+	if d.GameJSON.Options.TimeControl != nil {
+		timeControl := *d.GameJSON.Options.TimeControl
+		if timeControl != engine.TimeControlIncrement &&
+			timeControl != engine.TimeControlDelay &&
+			timeControl != engine.TimeControlHourglass {
+
+			s.Warning("\"" + strconv.Itoa(timeControl) + "\" is not a valid value for \"timeControl\".")
+			return false
+		}
+	}
+	if d.GameJSON.Options.TurnTimeLimit != nil && *d.GameJSON.Options.TurnTimeLimit < 0 {
+		s.Warning("\"" + strconv.Itoa(*d.GameJSON.Options.TurnTimeLimit) + "\" is not a valid value for \"turnTimeLimit\".")
+		return false
+	}
+
 	// Validate that there is at least one action
 	if len(d.GameJSON.Actions) < 1 {
 		s.Warning("There must be at least one game action in the JSON array.")
@@ -230,7 +302,7 @@ func validateJSON(s *Session, d *CommandData) bool {
 
 	// Validate actions
 	for i, action := range d.GameJSON.Actions {
-		if action.Type == ActionTypePlay || action.Type == ActionTypeDiscard {
+		if action.Type == engine.ActionTypePlay || action.Type == engine.ActionTypeDiscard {
 			if action.Target < 0 || action.Target > len(d.GameJSON.Deck)-1 {
 				s.Warning("Action at index " + strconv.Itoa(i) +
 					" is a play or discard with an invalid target (card order) of " +
@@ -243,21 +315,21 @@ func validateJSON(s *Session, d *CommandData) bool {
 					", which is nonsensical.")
 				return false
 			}
-		} else if action.Type == ActionTypeColorClue || action.Type == ActionTypeRankClue {
+		} else if action.Type == engine.ActionTypeColorClue || action.Type == engine.ActionTypeRankClue {
 			if action.Target < 0 || action.Target > len(d.GameJSON.Players)-1 {
 				s.Warning("Action at index " + strconv.Itoa(i) +
 					" is a clue with an invalid target (player index) of " +
 					strconv.Itoa(action.Target) + ".")
 				return false
 			}
-			if action.Type == ActionTypeColorClue {
+			if action.Type == engine.ActionTypeColorClue {
 				if action.Value < 0 || action.Value > len(variant.ClueColors) {
 					s.Warning("Action at index " + strconv.Itoa(i) +
 						" is a color clue with an invalid value of " +
 						strconv.Itoa(action.Value) + ".")
 					return false
 				}
-			} else if action.Type == ActionTypeRankClue {
+			} else if action.Type == engine.ActionTypeRankClue {
 				if action.Value < 1 || action.Value > 5 {
 					s.Warning("Action at index " + strconv.Itoa(i) +
 						" is a rank clue with an invalid value of " +
@@ -265,7 +337,7 @@ func validateJSON(s *Session, d *CommandData) bool {
 					return false
 				}
 			}
-		} else if action.Type == ActionTypeEndGame {
+		} else if action.Type == engine.ActionTypeEndGame {
 			if action.Target < 0 || action.Target > len(d.GameJSON.Players)-1 {
 				s.Warning("Action at index " + strconv.Itoa(i) +
 					" is an end game with an invalid target (player index) of " +
@@ -291,7 +363,7 @@ func validateJSON(s *Session, d *CommandData) bool {
 				" has an invalid suit number of " + strconv.Itoa(card.SuitIndex) + ".")
 			return false
 		}
-		if (card.Rank < 1 || card.Rank > 5) && card.Rank != StartCardRank {
+		if (card.Rank < 1 || card.Rank > 5) && card.Rank != engine.StartCardRank {
 			s.Warning("The card at index " + strconv.Itoa(i) +
 				" has an invalid rank number of " + strconv.Itoa(card.Rank) + ".")
 			return false
@@ -299,8 +371,9 @@ func validateJSON(s *Session, d *CommandData) bool {
 	}
 
 	// Validate the amount of players
-	if len(d.GameJSON.Players) < 2 || len(d.GameJSON.Players) > 6 {
-		s.Warning("The number of players must be between 2 and 6.")
+	if len(d.GameJSON.Players) < engine.MinPlayers || len(d.GameJSON.Players) > engine.MaxPlayers {
+		s.Warning("The number of players must be between " + strconv.Itoa(engine.MinPlayers) +
+			" and " + strconv.Itoa(engine.MaxPlayers) + ".")
 		return false
 	}
 
@@ -373,7 +446,7 @@ func loadDatabaseOptionsToTable(s *Session, gameID int, t *Table) ([]*DBPlayer,
 		return nil, false
 	}
 
-	var characterAssignments []*CharacterAssignment
+	var characterAssignments []*engine.CharacterAssignment
 	if t.Options.DetrimentalCharacters {
 		characterAssignments = getCharacterAssignmentsFromDBPlayers(dbPlayers)
 	}
@@ -390,7 +463,7 @@ func loadDatabaseOptionsToTable(s *Session, gameID int, t *Table) ([]*DBPlayer,
 	}
 
 	// Get the actions from the database
-	var actions []*GameAction
+	var actions []*engine.GameAction
 	if v, err := models.GameActions.GetAll(gameID); err != nil {
 		logger.Error("Failed to get the actions from the database for game "+
 			strconv.Itoa(gameID)+":", err)
@@ -416,10 +489,10 @@ func loadDatabaseOptionsToTable(s *Session, gameID int, t *Table) ([]*DBPlayer,
 	return dbPlayers, true
 }
 
-func getCharacterAssignmentsFromDBPlayers(dbPlayers []*DBPlayer) []*CharacterAssignment {
-	characterAssignments := make([]*CharacterAssignment, 0)
+func getCharacterAssignmentsFromDBPlayers(dbPlayers []*DBPlayer) []*engine.CharacterAssignment {
+	characterAssignments := make([]*engine.CharacterAssignment, 0)
 	for _, dbPlayer := range dbPlayers {
-		characterAssignments = append(characterAssignments, &CharacterAssignment{
+		characterAssignments = append(characterAssignments, &engine.CharacterAssignment{
 			// Characters are stored in the database as integers,
 			// so we convert it to the character name by using the character ID map
 			Name: characterIDMap[dbPlayer.CharacterAssignment],
@@ -450,6 +523,14 @@ func loadJSONOptionsToTable(d *CommandData, t *Table) {
 	if d.GameJSON.Options.TimePerTurn != nil {
 		timePerTurn = *d.GameJSON.Options.TimePerTurn
 	}
+	timeControl := engine.TimeControlIncrement
+	if d.GameJSON.Options.TimeControl != nil {
+		timeControl = *d.GameJSON.Options.TimeControl
+	}
+	turnTimeLimit := 0
+	if d.GameJSON.Options.TurnTimeLimit != nil {
+		turnTimeLimit = *d.GameJSON.Options.TurnTimeLimit
+	}
 	speedrun := false
 	if d.GameJSON.Options.Speedrun != nil {
 		speedrun = *d.GameJSON.Options.Speedrun
@@ -482,15 +563,29 @@ func loadJSONOptionsToTable(d *CommandData, t *Table) {
 	if d.GameJSON.Options.DetrimentalCharacters != nil {
 		detrimentalCharacters = *d.GameJSON.Options.DetrimentalCharacters
 	}
+	startingClues := engine.MaxClueNum
+	if d.GameJSON.Options.StartingClues != nil {
+		startingClues = *d.GameJSON.Options.StartingClues
+	}
+	maxClues := engine.MaxClueNum
+	if d.GameJSON.Options.MaxClues != nil {
+		maxClues = *d.GameJSON.Options.MaxClues
+	}
+	maxStrikes := engine.MaxStrikeNum
+	if d.GameJSON.Options.MaxStrikes != nil {
+		maxStrikes = *d.GameJSON.Options.MaxStrikes
+	}
 
 	// Store the options on the table
 	// (the variant was already validated in the "validateJSON()" function)
-	t.Options = &Options{
+	t.Options = &engine.Options{
 		StartingPlayer:        startingPlayer,
 		VariantName:           *d.GameJSON.Options.Variant,
 		Timed:                 timed,
 		TimeBase:              timeBase,
 		TimePerTurn:           timePerTurn,
+		TimeControl:           timeControl,
+		TurnTimeLimit:         turnTimeLimit,
 		Speedrun:              speedrun,
 		CardCycle:             cardCycle,
 		DeckPlays:             deckPlays,
@@ -499,6 +594,10 @@ func loadJSONOptionsToTable(d *CommandData, t *Table) {
 		OneLessCard:           oneLessCard,
 		AllOrNothing:          allOrNothing,
 		DetrimentalCharacters: detrimentalCharacters,
+		StartingClues:         startingClues,
+		MaxClues:              maxClues,
+		MaxStrikes:            maxStrikes,
+		CustomVariant:         getCustomVariantJSON(*d.GameJSON.Options.Variant),
 	}
 	t.ExtraOptions = &ExtraOptions{
 		// Normally, "DatabaseID" is set to either -1 (in an ongoing game)
@@ -557,7 +656,7 @@ func applyNotesToPlayers(s *Session, d *CommandData, g *Game) bool {
 	var notes [][]string
 	if d.Source == "id" {
 		// Get the notes from the database
-		variant := variants[g.Options.VariantName]
+		variant := getVariant(g.Options.VariantName)
 		noteSize := variant.GetDeckSize() + len(variant.Suits)
 		if v, err := models.Games.GetNotes(d.GameID, len(g.Players), noteSize); err != nil {
 			logger.Error("Failed to get the notes from the database for game "+
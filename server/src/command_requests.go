@@ -0,0 +1,172 @@
+package main
+
+// The typed request of every WebSocket command
+// The JSON names of the fields must match the fields of "CommandData", since that is what is passed
+// to the command handlers (this is checked when the server starts in "protocolInit()")
+// Fields with a tag of `protocol:"required"` must be present in the data of the command
+// (as of protocol version 2; see "protocol.go")
+// String fields with an `enum` tag must be one of the comma-separated values
+
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// TableRequest is embedded in the requests of the commands that act on a specific table
+type TableRequest struct {
+	TableID uint64 `json:"tableID" protocol:"required"`
+}
+
+type EmptyRequest struct{}
+
+// Table commands
+
+type TableCreateRequest struct {
+	Name     string          `json:"name" protocol:"required"`
+	Options  *engine.Options `json:"options" protocol:"required"`
+	Password string          `json:"password"`
+	GameJSON *GameJSON       `json:"gameJSON"` // Optional; used to create a table with a custom deck
+}
+
+type TableJoinRequest struct {
+	TableRequest
+	Password string `json:"password"`
+}
+
+type TableSetVariantRequest struct {
+	TableRequest
+	Options *engine.Options `json:"options" protocol:"required"`
+}
+
+type TableSetLeaderRequest struct {
+	TableRequest
+	Name string `json:"name" protocol:"required"`
+}
+
+type TableSpectateRequest struct {
+	TableRequest
+	// A value of -1 means that they are not shadowing a player
+	ShadowingPlayerIndex int `json:"shadowingPlayerIndex" protocol:"required"`
+}
+
+type TableAddBotRequest struct {
+	TableRequest
+	Bot string `json:"bot" protocol:"required"`
+}
+
+type TableReadyRequest struct {
+	TableRequest
+	Ready bool `json:"ready" protocol:"required"`
+}
+
+// Other lobby commands
+
+type SettingRequest struct {
+	Name    string `json:"name" protocol:"required"`
+	Setting string `json:"setting" protocol:"required"` // All setting values must be strings
+}
+
+type ChatRequest struct {
+	Msg  string `json:"msg" protocol:"required"`
+	Room string `json:"room" protocol:"required"`
+}
+
+type ChatPMRequest struct {
+	Msg       string `json:"msg" protocol:"required"`
+	Recipient string `json:"recipient" protocol:"required"`
+	Room      string `json:"room"` // The room that the private message was typed in
+}
+
+type ChatFriendRequest struct {
+	Name string `json:"name" protocol:"required"`
+}
+
+type InactiveRequest struct {
+	Inactive bool `json:"inactive" protocol:"required"`
+}
+
+type HistoryGetRequest struct {
+	Offset int `json:"offset" protocol:"required"`
+	Amount int `json:"amount" protocol:"required"`
+}
+
+type HistoryGetSeedRequest struct {
+	Seed    string `json:"seed" protocol:"required"`
+	Friends bool   `json:"friends"`
+}
+
+type ReplayCreateRequest struct {
+	Source     string    `json:"source" protocol:"required"`     // Either "id" or "json"
+	GameID     int       `json:"gameID"`                         // Only if the source is "id"
+	GameJSON   *GameJSON `json:"gameJSON"`                       // Only if the source is "json"
+	Visibility string    `json:"visibility" protocol:"required"` // Either "solo" or "shared"
+	// Only if the source is "id" and the visibility is "shared"
+	HypotheticalID       int `json:"hypotheticalID"`
+	ShadowingPlayerIndex int `json:"shadowingPlayerIndex"`
+}
+
+type TagSearchRequest struct {
+	Msg  string `json:"msg" protocol:"required"`
+	Room string `json:"room"`
+}
+
+type MatchmakingJoinRequest struct {
+	VariantClass string `json:"variantClass"` // Defaults to "any"
+	MinPlayers   int    `json:"minPlayers" protocol:"required"`
+	MaxPlayers   int    `json:"maxPlayers" protocol:"required"`
+	Timed        bool   `json:"timed"`
+}
+
+// Game and replay commands
+
+type TagRequest struct {
+	TableRequest
+	Msg string `json:"msg" protocol:"required"`
+}
+
+type AnnotationRequest struct {
+	TableRequest
+	Turn int    `json:"turn" protocol:"required"`
+	Msg  string `json:"msg" protocol:"required"`
+}
+
+type AnnotationDeleteRequest struct {
+	TableRequest
+	Turn int `json:"turn" protocol:"required"`
+}
+
+// Game commands
+
+type ActionRequest struct {
+	TableRequest
+	Type   int `json:"type" protocol:"required"` // Corresponds to "actionType" in "constants.go"
+	Target int `json:"target" protocol:"required"`
+	Value  int `json:"value"` // Only if a clue or a game over
+}
+
+type NoteRequest struct {
+	TableRequest
+	Order int    `json:"order" protocol:"required"`
+	Note  string `json:"note" protocol:"required"`
+}
+
+type PauseRequest struct {
+	TableRequest
+	Setting string `json:"setting" protocol:"required" enum:"pause,unpause,pause-queue,pause-unqueue"`
+}
+
+type TakebackRequest struct {
+	TableRequest
+	Setting string `json:"setting" protocol:"required" enum:"request,accept,decline"`
+}
+
+// Replay commands
+
+type ReplayActionRequest struct {
+	TableRequest
+	Type       int    `json:"type" protocol:"required"` // Types are listed in the "constants.go" file
+	Segment    int    `json:"segment"`
+	Order      int    `json:"order"`
+	Sound      string `json:"sound"`
+	ActionJSON string `json:"actionJSON"`
+	Name       string `json:"name"` // The name of a saved hypothetical
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// commandTableAddBot is sent when the owner of a table adds a bot to a pre-game table
+// (bots can be removed in the same way as humans, with the "/kick" command)
+//
+// Example data:
+// {
+//   tableID: 5,
+//   // Corresponds to one of the keys of "botConstructors" in "bot.go"
+//   bot: 'Reference',
+// }
+func commandTableAddBot(s *Session, d *CommandData) {
+	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
+	if !exists {
+		return
+	}
+	if !d.NoLock {
+		defer t.Mutex.Unlock()
+	}
+
+	// Validate that this is the owner of the table
+	if s.UserID() != t.Owner {
+		s.Warning("Only the owner of a table can add a bot.")
+		return
+	}
+
+	// Validate that the game is not started yet
+	if t.Running {
+		s.Warning("The game has already started, so you cannot add a bot.")
+		return
+	}
+
+	// Validate that it is not a replay
+	if t.Replay {
+		s.Warning("You can not add a bot to a replay.")
+		return
+	}
+
+	// Validate that it is not a tournament game
+	// (only the members of the team can play)
+	if t.ExtraOptions.TournamentID != 0 {
+		s.Warning("You can not add a bot to a tournament game.")
+		return
+	}
+
+	// Validate that this table does not already have the maximum amount of players
+	if len(t.Players) >= engine.MaxPlayers {
+		s.Warning("That table is already full. (You can not play with more than " +
+			strconv.Itoa(engine.MaxPlayers) + " players.)")
+		return
+	}
+
+	// Validate that the bot type exists
+	var botType string
+	if v, ok := getBotType(d.Bot); !ok {
+		s.Warning("The bot type of \"" + d.Bot + "\" does not exist. " +
+			"The valid bot types are: " + strings.Join(getBotTypes(), ", "))
+		return
+	} else {
+		botType = v
+	}
+
+	p := t.NewBotPlayer(botType)
+	t.Players = append(t.Players, p)
+	logger.Info(t.GetName() + "User \"" + s.Username() + "\" added the bot \"" + p.Name + "\". " +
+		"(There are now " + strconv.Itoa(len(t.Players)) + " players.)")
+	notifyAllTable(t)
+	t.NotifyPlayerChange()
+	chatServerSend("\""+p.Name+"\" has joined the table.", t.GetRoomName())
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/alexedwards/argon2id"
 )
 
@@ -22,11 +23,17 @@ var (
 type SpecialGameData struct {
 	DatabaseID       int
 	CustomNumPlayers int
-	CustomActions    []*GameAction
+	CustomActions    []*engine.GameAction
 
 	SetSeedSuffix string
 	SetReplay     bool
 	SetReplayTurn int
+
+	DailyChallenge bool
+
+	TournamentID     int
+	TournamentRound  int
+	TournamentTeamID int
 }
 
 // commandTableCreate is sent when the user submits the "Create a New Game" form
@@ -139,6 +146,18 @@ func commandTableCreate(s *Session, d *CommandData) {
 			// However, the seed does not actually have to be a number,
 			// so allow the user to use any arbitrary string as a seed suffix
 			data.SetSeedSuffix = args[0]
+
+			// Tournament seeds must not be played before the round begins
+			if isTournamentSeedSuffix(data.SetSeedSuffix) {
+				s.Warning("That seed is reserved for tournaments.")
+				return
+			}
+
+			// Daily challenge seeds must only be played with "!daily" (or be played in advance)
+			if isDailyChallengeSeedSuffix(data.SetSeedSuffix) {
+				s.Warning("That seed is reserved for daily challenges.")
+				return
+			}
 		} else if command == "replay" {
 			// !replay - Replay a specific game up to a specific turn
 			if len(args) != 1 && len(args) != 2 {
@@ -165,6 +184,16 @@ func commandTableCreate(s *Session, d *CommandData) {
 				return
 			}
 
+			// Check to see if the game is on the seed of a tournament round that is in progress
+			if !tournamentValidateReplay(s, data.DatabaseID) {
+				return
+			}
+
+			// Check to see if the game is on the seed of a daily challenge that is in progress
+			if !dailyChallengeValidateReplay(s, data.DatabaseID) {
+				return
+			}
+
 			if len(args) == 1 {
 				data.SetReplayTurn = 1
 			} else {
@@ -203,6 +232,26 @@ func commandTableCreate(s *Session, d *CommandData) {
 			}
 
 			data.SetReplay = true
+		} else if command == "daily" {
+			// !daily - Play the daily challenge
+			if len(args) != 0 {
+				s.Warning("Daily challenge games must be created in the form: !daily")
+				return
+			}
+
+			// Everyone plays the challenge with the same options,
+			// so the options from the lobby are ignored
+			dc := getDailyChallenge()
+			d.Options = &engine.Options{
+				VariantName: dc.VariantName,
+			}
+			data.SetSeedSuffix = dc.GetSeedSuffix()
+			data.DailyChallenge = true
+		} else if command == "tournament" {
+			// !tournament - Play the current round of a tournament
+			if !tournamentInitTable(s, d, args, data) {
+				return
+			}
 		} else {
 			msg := "You cannot start a game with an exclamation mark unless you are trying to use a specific game creation command."
 			s.Warning(msg)
@@ -212,17 +261,29 @@ func commandTableCreate(s *Session, d *CommandData) {
 
 	// Validate that they sent the options object
 	if d.Options == nil {
-		d.Options = &Options{
+		d.Options = &engine.Options{
 			VariantName: "No Variant",
 		}
 	}
 
+	// Custom variants are built from a definition instead of being chosen by name
+	if d.Options.CustomVariant != nil {
+		if v := createCustomVariant(s, d.Options.CustomVariant); v == nil {
+			return
+		} else {
+			d.Options.VariantName = v.Name
+		}
+	}
+
 	// Validate that the variant name is valid
-	if _, ok := variants[d.Options.VariantName]; !ok {
+	if getVariant(d.Options.VariantName) == nil {
 		s.Warning("\"" + d.Options.VariantName + "\" is not a valid variant.")
 		return
 	}
 
+	// The client needs the full definition of a custom variant in order to draw it
+	d.Options.CustomVariant = getCustomVariantJSON(d.Options.VariantName)
+
 	// Validate that the time controls are sane
 	if d.Options.Timed {
 		if d.Options.TimeBase <= 0 {
@@ -233,12 +294,32 @@ func commandTableCreate(s *Session, d *CommandData) {
 			s.Warning("\"" + strconv.Itoa(d.Options.TimeBase) + "\" is too large of a value for \"Base Time\".")
 			return
 		}
-		if d.Options.TimePerTurn <= 0 {
-			s.Warning("\"" + strconv.Itoa(d.Options.TimePerTurn) + "\" is too small of a value for \"Time per Turn\".")
+		if d.Options.TimeControl != engine.TimeControlIncrement &&
+			d.Options.TimeControl != engine.TimeControlDelay &&
+			d.Options.TimeControl != engine.TimeControlHourglass {
+
+			s.Warning("\"" + strconv.Itoa(d.Options.TimeControl) + "\" is not a valid time control.")
+			return
+		}
+		if d.Options.TimeControl == engine.TimeControlHourglass {
+			// In an hourglass game, players only gain time when the other players spend it
+			d.Options.TimePerTurn = 0
+		} else {
+			if d.Options.TimePerTurn <= 0 {
+				s.Warning("\"" + strconv.Itoa(d.Options.TimePerTurn) + "\" is too small of a value for \"Time per Turn\".")
+				return
+			}
+			if d.Options.TimePerTurn > 86400 { // 1 day in seconds
+				s.Warning("\"" + strconv.Itoa(d.Options.TimePerTurn) + "\" is too large of a value for \"Time per Turn\".")
+				return
+			}
+		}
+		if d.Options.TurnTimeLimit < 0 {
+			s.Warning("\"" + strconv.Itoa(d.Options.TurnTimeLimit) + "\" is too small of a value for \"Turn Time Limit\".")
 			return
 		}
-		if d.Options.TimePerTurn > 86400 { // 1 day in seconds
-			s.Warning("\"" + strconv.Itoa(d.Options.TimePerTurn) + "\" is too large of a value for \"Time per Turn\".")
+		if d.Options.TurnTimeLimit > 86400 { // 1 day in seconds
+			s.Warning("\"" + strconv.Itoa(d.Options.TurnTimeLimit) + "\" is too large of a value for \"Turn Time Limit\".")
 			return
 		}
 	}
@@ -247,6 +328,8 @@ func commandTableCreate(s *Session, d *CommandData) {
 	if !d.Options.Timed {
 		d.Options.TimeBase = 0
 		d.Options.TimePerTurn = 0
+		d.Options.TimeControl = engine.TimeControlIncrement
+		d.Options.TurnTimeLimit = 0
 	}
 
 	// Validate that a speedrun cannot be timed
@@ -254,6 +337,39 @@ func commandTableCreate(s *Session, d *CommandData) {
 		d.Options.Timed = false
 		d.Options.TimeBase = 0
 		d.Options.TimePerTurn = 0
+		d.Options.TimeControl = engine.TimeControlIncrement
+		d.Options.TurnTimeLimit = 0
+	}
+
+	// Takebacks are recorded over the course of the game, so they cannot be specified in advance
+	// (this also resets the flag when a game that used a takeback is restarted)
+	d.Options.Takeback = false
+
+	// Validate that the clue and strike limits are sane
+	d.Options.SetDefaultLimits()
+	if d.Options.MaxClues < 1 {
+		s.Warning("\"" + strconv.Itoa(d.Options.MaxClues) + "\" is too small of a value for \"Max Clues\".")
+		return
+	}
+	if d.Options.MaxClues > engine.MaxCustomClueNum {
+		s.Warning("\"" + strconv.Itoa(d.Options.MaxClues) + "\" is too large of a value for \"Max Clues\".")
+		return
+	}
+	if d.Options.StartingClues < 0 {
+		s.Warning("\"" + strconv.Itoa(d.Options.StartingClues) + "\" is too small of a value for \"Starting Clues\".")
+		return
+	}
+	if d.Options.StartingClues > d.Options.MaxClues {
+		s.Warning("The team cannot start with more clues than the maximum amount of clues.")
+		return
+	}
+	if d.Options.MaxStrikes < 1 {
+		s.Warning("\"" + strconv.Itoa(d.Options.MaxStrikes) + "\" is too small of a value for \"Max Strikes\".")
+		return
+	}
+	if d.Options.MaxStrikes > engine.MaxCustomStrikeNum {
+		s.Warning("\"" + strconv.Itoa(d.Options.MaxStrikes) + "\" is too large of a value for \"Max Strikes\".")
+		return
 	}
 
 	// Validate that they did not send both the "One Extra Card" and the "One Less Card" option at
@@ -296,6 +412,10 @@ func tableCreate(s *Session, d *CommandData, data *SpecialGameData) {
 		DatabaseID:       data.DatabaseID,
 		CustomNumPlayers: data.CustomNumPlayers,
 		SetSeedSuffix:    data.SetSeedSuffix,
+		DailyChallenge:   data.DailyChallenge,
+		TournamentID:     data.TournamentID,
+		TournamentRound:  data.TournamentRound,
+		TournamentTeamID: data.TournamentTeamID,
 	}
 
 	// If this is a "!replay" game, override the options with the ones found in the database
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/alexedwards/argon2id"
 )
 
@@ -31,7 +32,8 @@ func commandTableJoin(s *Session, d *CommandData) {
 	}
 
 	// Validate that the player is not joined to another table
-	if !strings.HasPrefix(s.Username(), "Bot-") {
+	// (bots are allowed to play in more than one game at a time)
+	if !s.Bot() && !strings.HasPrefix(s.Username(), "Bot-") {
 		if t2 := s.GetJoinedTable(); t2 != nil {
 			s.Warning("You cannot join more than one table at a time. " +
 				"Terminate your other game before joining a new one.")
@@ -39,9 +41,10 @@ func commandTableJoin(s *Session, d *CommandData) {
 		}
 	}
 
-	// Validate that this table does not already have 6 players
-	if len(t.Players) >= 6 {
-		s.Warning("That table is already full. (You can not play with more than 6 players.)")
+	// Validate that this table does not already have the maximum amount of players
+	if len(t.Players) >= engine.MaxPlayers {
+		s.Warning("That table is already full. (You can not play with more than " +
+			strconv.Itoa(engine.MaxPlayers) + " players.)")
 		return
 	}
 
@@ -75,12 +78,35 @@ func commandTableJoin(s *Session, d *CommandData) {
 		return
 	}
 
+	// Validate that nobody else can join a table that was created by the matchmaking queue
+	// (the players are added directly by the "matchmakingCreateTable()" function)
+	if t.ExtraOptions.Matchmaking {
+		s.Warning("You cannot join a table that was created by the matchmaking queue.")
+		return
+	}
+
+	// Validate that only the members of the team can join a tournament game
+	if t.ExtraOptions.TournamentTeamID != 0 {
+		if isMember, err := models.TournamentTeamMembers.IsMember(
+			t.ExtraOptions.TournamentTeamID,
+			s.UserID(),
+		); err != nil {
+			logger.Error("Failed to check to see if \""+s.Username()+"\" is a member of team "+
+				strconv.Itoa(t.ExtraOptions.TournamentTeamID)+":", err)
+			s.Error(DefaultErrorMsg)
+			return
+		} else if !isMember {
+			s.Warning("Only the members of the team can join this tournament game.")
+			return
+		}
+	}
+
 	tableJoin(s, t)
 }
 
 func tableJoin(s *Session, t *Table) {
 	// Local variables
-	variant := variants[t.Options.VariantName]
+	variant := getVariant(t.Options.VariantName)
 
 	logger.Info(t.GetName() + "User \"" + s.Username() + "\" joined. " +
 		"(There are now " + strconv.Itoa(len(t.Players)+1) + " players.)")
@@ -107,6 +133,11 @@ func tableJoin(s *Session, t *Table) {
 		variantStats = v
 	}
 
+	// Once a player sits down at a table, they no longer need the matchmaking queue
+	if matchmakingRemove(s.UserID()) {
+		chatServerSendPM(s, "You have left the matchmaking queue since you joined a table.", "lobby")
+	}
+
 	p := &Player{
 		ID:      s.UserID(),
 		Name:    s.Username(),
@@ -116,6 +147,7 @@ func tableJoin(s *Session, t *Table) {
 			NumGames: numGames,
 			Variant:  variantStats,
 		},
+		BotAccount: s.Bot(),
 	}
 	t.Players = append(t.Players, p)
 	notifyAllTable(t)
@@ -145,6 +177,7 @@ func tableJoin(s *Session, t *Table) {
 	// If there is an automatic start countdown, cancel it
 	if !t.DatetimePlannedStart.IsZero() {
 		t.DatetimePlannedStart = time.Time{} // Assign a zero value
+		scheduler.Cancel(t, TaskStartIn)
 		chatServerSend("Automatic game start has been canceled.", t.GetRoomName())
 	}
 
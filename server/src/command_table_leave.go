@@ -43,7 +43,14 @@ func commandTableLeave(s *Session, d *CommandData) {
 	tableLeave(s, t, playerIndex)
 }
 
+type TableLeftMessage struct {
+	TableID uint64
+}
+
 func tableLeave(s *Session, t *Table, playerIndex int) {
+	// Local variables
+	p := t.Players[playerIndex]
+
 	logger.Info(t.GetName() + "User \"" + s.Username() + "\" left. " +
 		"(There are now " + strconv.Itoa(len(t.Players)-1) + " players.)")
 
@@ -53,16 +60,14 @@ func tableLeave(s *Session, t *Table, playerIndex int) {
 	t.NotifyPlayerChange()
 
 	// Set their status
-	if s != nil {
+	// (bots are not real users, so they do not have a status)
+	if s != nil && p.Bot == nil {
 		s.Set("status", StatusLobby)
 		s.Set("tableID", uint64(0))
 		notifyAllUser(s)
 	}
 
 	// Make the client switch screens to show the base lobby
-	type TableLeftMessage struct {
-		TableID uint64
-	}
 	s.Emit("left", &TableLeftMessage{
 		TableID: t.ID,
 	})
@@ -73,9 +78,17 @@ func tableLeave(s *Session, t *Table, playerIndex int) {
 	// If there is an automatic start countdown, cancel it
 	if !t.DatetimePlannedStart.IsZero() {
 		t.DatetimePlannedStart = time.Time{} // Assign a zero value
+		scheduler.Cancel(t, TaskStartIn)
 		chatServerSend("Automatic game start has been canceled.", t.GetRoomName())
 	}
 
+	// If this table was created by the matchmaking queue, the match falls through
+	// (this removes everyone else from the table)
+	if t.ReadyCheck != nil {
+		matchmakingCancel(t, s.Username()+" left the table.", []int{p.ID})
+		return
+	}
+
 	// Force everyone else to leave if it was the owner that left
 	if s.UserID() == t.Owner && len(t.Players) > 0 {
 		for len(t.Players) > 0 {
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+)
+
+// commandTableReady is sent when a player in a pre-game marks themselves as ready to play
+// (or as no longer ready)
+//
+// Example data:
+// {
+//   tableID: 5,
+//   ready: true,
+// }
+func commandTableReady(s *Session, d *CommandData) {
+	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
+	if !exists {
+		return
+	}
+	if !d.NoLock {
+		defer t.Mutex.Unlock()
+	}
+
+	// Validate that the game has not started
+	if t.Running {
+		s.Warning(StartedFail)
+		return
+	}
+
+	// Validate that it is not a replay
+	if t.Replay {
+		s.Warning("You can not be ready in a replay.")
+		return
+	}
+
+	// Validate that they are at the table
+	playerIndex := t.GetPlayerIndexFromID(s.UserID())
+	if playerIndex == -1 {
+		s.Warning("You are not at table " + strconv.FormatUint(t.ID, 10) + ", " +
+			"so you cannot be ready.")
+		return
+	}
+
+	// Validate that their ready state is changing
+	p := t.Players[playerIndex]
+	if p.Ready == d.Ready {
+		if d.Ready {
+			s.Warning("You are already ready.")
+		} else {
+			s.Warning("You are already not ready.")
+		}
+		return
+	}
+
+	tableReady(s, d, t, p)
+}
+
+func tableReady(s *Session, d *CommandData, t *Table, p *Player) {
+	p.Ready = d.Ready
+	t.NotifyPlayerChange()
+
+	msg := p.Name + " is "
+	if !p.Ready {
+		msg += "no longer "
+	}
+	msg += "ready."
+	chatServerSend(msg, t.GetRoomName())
+
+	// Tables that were created by the matchmaking queue start as soon as everyone is ready
+	if t.ReadyCheck != nil && p.Ready {
+		matchmakingCheckReady(t)
+	}
+}
@@ -106,6 +106,14 @@ func commandTableRestart(s *Session, d *CommandData) {
 		s.Warning("You are not allowed to restart \"!replay\" games.")
 		return
 	}
+	if t.ExtraOptions.DailyChallenge {
+		s.Warning("You are not allowed to restart daily challenge games.")
+		return
+	}
+	if t.ExtraOptions.TournamentID != 0 {
+		s.Warning("You are not allowed to restart tournament games.")
+		return
+	}
 
 	// Validate that the server is not about to go offline
 	if checkImminentShutdown(s) {
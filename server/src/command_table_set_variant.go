@@ -2,6 +2,8 @@ package main
 
 import (
 	"strconv"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 // commandTableSetVariant is sent when a user types the "/setvariant [variant]" command
@@ -11,6 +13,8 @@ import (
 //   tableID: 123,
 //   options: {
 //     variant: 'Black & Rainbow (6 Suit)',
+//     // Or, to build a custom variant:
+//     customVariant: { suits: ['Red', 'Blue', 'Rainbow'], [other fields omitted] },
 //   },
 // }
 func commandTableSetVariant(s *Session, d *CommandData) {
@@ -32,9 +36,30 @@ func commandTableSetVariant(s *Session, d *CommandData) {
 		return
 	}
 
+	// Every team in a tournament must play the seed of the round with the same variant
+	if t.ExtraOptions.TournamentID != 0 {
+		s.Warning("You are not allowed to change the variant of tournament games.")
+		return
+	}
+
+	// The leaderboard of the daily challenge compares games with the variant of the day
+	if t.ExtraOptions.DailyChallenge {
+		s.Warning("You are not allowed to change the variant of the daily challenge.")
+		return
+	}
+
 	// Validate that they sent the options object
 	if d.Options == nil {
-		d.Options = &Options{}
+		d.Options = &engine.Options{}
+	}
+
+	// Custom variants are built from a definition instead of being chosen by name
+	if d.Options.CustomVariant != nil {
+		if v := createCustomVariant(s, d.Options.CustomVariant); v == nil {
+			return
+		} else {
+			d.Options.VariantName = v.Name
+		}
 	}
 
 	if len(d.Options.VariantName) == 0 {
@@ -42,7 +67,7 @@ func commandTableSetVariant(s *Session, d *CommandData) {
 		return
 	}
 
-	if _, ok := variants[d.Options.VariantName]; !ok {
+	if getVariant(d.Options.VariantName) == nil {
 		s.Warning("The variant of \"" + d.Options.VariantName + "\" does not exist.")
 		return
 	}
@@ -52,10 +77,11 @@ func commandTableSetVariant(s *Session, d *CommandData) {
 
 func tableSetVariant(s *Session, d *CommandData, t *Table) {
 	// Local variables
-	variant := variants[d.Options.VariantName]
+	variant := getVariant(d.Options.VariantName)
 
 	// First, change the variant
 	t.Options.VariantName = d.Options.VariantName
+	t.Options.CustomVariant = getCustomVariantJSON(d.Options.VariantName)
 
 	// Update the variant-specific stats for each player at the table
 	for _, p := range t.Players {
@@ -75,6 +101,9 @@ func tableSetVariant(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
+	// Nobody has agreed to play the new variant yet
+	anyoneReady := t.ResetReady()
+
 	// Even though no-one has joined or left the game, this function will update the display of the
 	// variant on the client and refresh all of the variant-specific stats
 	t.NotifyPlayerChange()
@@ -83,5 +112,8 @@ func tableSetVariant(s *Session, d *CommandData, t *Table) {
 	notifyAllTable(t)
 
 	msg := s.Username() + " has changed the variant to: " + d.Options.VariantName
+	if anyoneReady {
+		msg += " (Everyone is no longer ready.)"
+	}
 	chatServerSend(msg, t.GetRoomName())
 }
@@ -64,6 +64,16 @@ func commandTableSpectate(s *Session, d *CommandData) {
 		}
 	}
 
+	// Validate that the other teams in a tournament cannot watch the game until the round is over
+	if t.ExtraOptions.TournamentID != 0 && !tournamentValidateSpectate(s, t) {
+		return
+	}
+
+	// Validate that the people who have not played the daily challenge cannot see the deal
+	if t.ExtraOptions.DailyChallenge && !dailyChallengeValidateView(s, t.Game.Seed) {
+		return
+	}
+
 	tableSpectate(s, d, t)
 }
 
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"math/rand"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 // commandTableStart is sent when the owner of a table clicks on the "Start Game" button
@@ -30,6 +32,15 @@ func commandTableStart(s *Session, d *CommandData) {
 		return
 	}
 
+	// Validate that everyone is ready, if the table requires it
+	if t.RequireReady {
+		if notReadyNames := t.GetNotReadyNames(); len(notReadyNames) > 0 {
+			s.Warning("Everyone must be ready before the game can start. " +
+				"(Waiting for: " + strings.Join(notReadyNames, ", ") + ")")
+			return
+		}
+	}
+
 	// Validate that the table has at least 2 players
 	if len(t.Players) < 2 {
 		s.Warning("You need at least 2 players before you can start a game.")
@@ -64,12 +75,81 @@ func commandTableStart(s *Session, d *CommandData) {
 		}
 	}
 
+	// Validate that the tournament round is still in progress
+	if t.ExtraOptions.TournamentID != 0 {
+		if isOpen, err := models.TournamentRounds.IsOpen(
+			t.ExtraOptions.TournamentID,
+			t.ExtraOptions.TournamentRound,
+		); err != nil {
+			logger.Error("Failed to check to see if round "+
+				strconv.Itoa(t.ExtraOptions.TournamentRound)+" of tournament "+
+				strconv.Itoa(t.ExtraOptions.TournamentID)+" is open:", err)
+			s.Error(StartGameFail)
+			return
+		} else if !isOpen {
+			s.Warning("Round " + strconv.Itoa(t.ExtraOptions.TournamentRound) +
+				" of the tournament is over, so this game can no longer be started.")
+			return
+		}
+
+		// Validate that the game will be played with the variant of the tournament
+		// (the standings compare the scores of every team on the same seed)
+		if exists, tournament, err := models.Tournaments.Get(
+			t.ExtraOptions.TournamentID,
+		); err != nil {
+			logger.Error("Failed to get tournament "+
+				strconv.Itoa(t.ExtraOptions.TournamentID)+":", err)
+			s.Error(StartGameFail)
+			return
+		} else if !exists || t.Options.VariantName != tournament.VariantName {
+			s.Warning("Tournament games must be played with the variant of the tournament.")
+			return
+		}
+	}
+
+	// Validate that it is still the same day and that everyone is playing the challenge for the
+	// first time
+	if t.ExtraOptions.DailyChallenge {
+		dc := getDailyChallenge()
+		if t.ExtraOptions.SetSeedSuffix != dc.GetSeedSuffix() {
+			s.Warning("The daily challenge for this table is over. " +
+				"Create a new table to play the challenge for today.")
+			return
+		}
+
+		// Validate that the game will be played with the variant of the day
+		variant := getVariant(t.Options.VariantName)
+		if exists, variantID, err := models.DailyChallenges.GetVariantID(dc.Date); err != nil {
+			logger.Error("Failed to get the daily challenge for "+
+				dc.Date.Format(DailyChallengeDateFormat)+":", err)
+			s.Error(StartGameFail)
+			return
+		} else if !exists || variant.ID != variantID {
+			s.Warning("The daily challenge must be played with the variant of the day.")
+			return
+		}
+
+		seed := dc.GetSeed(len(t.Players))
+		for _, p := range t.Players {
+			if hasPlayed, err := models.Games.HasPlayedSeed(p.ID, seed); err != nil {
+				logger.Error("Failed to check to see if \""+p.Name+"\" has played seed "+
+					"\""+seed+"\":", err)
+				s.Error(StartGameFail)
+				return
+			} else if hasPlayed {
+				s.Warning(p.Name + " has already played the daily challenge for " +
+					strconv.Itoa(len(t.Players)) + " players today.")
+				return
+			}
+		}
+	}
+
 	tableStart(s, d, t)
 }
 
 func tableStart(s *Session, d *CommandData, t *Table) {
 	// Local variables
-	variant := variants[t.Options.VariantName]
+	variant := getVariant(t.Options.VariantName)
 
 	logger.Info(t.GetName() + "Starting the game.")
 
@@ -84,15 +164,11 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
-	// Create the game object
-	g := NewGame(t)
-
 	// Start the idle timeout
-	go t.CheckIdle()
-
-	g.InitDeck()
+	t.ScheduleIdle()
 
 	// Handle setting the seed
+	var seed string
 	shuffleDeck := true
 	shufflePlayers := true
 	seedPrefix := "p" + strconv.Itoa(len(t.Players)) +
@@ -103,22 +179,22 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 		shufflePlayers = false
 		if t.ExtraOptions.CustomSeed == "" {
 			// No custom seed was specified along with the JSON, so use the specified deck
-			g.Seed = "JSON"
+			seed = "JSON"
 			shuffleDeck = false
 		} else {
 			// A custom seed was specified along with the JSON,
 			// so ignore the deck provided in the JSON, generate a deck based on the specified seed,
 			// and shuffle it as per normal
-			g.Seed = g.ExtraOptions.CustomSeed
+			seed = t.ExtraOptions.CustomSeed
 		}
 	} else if t.ExtraOptions.CustomSeed != "" {
 		// This is a replay from the database (or a custom "!replay" game)
-		g.Seed = t.ExtraOptions.CustomSeed
+		seed = t.ExtraOptions.CustomSeed
 		shufflePlayers = false
 	} else if t.ExtraOptions.SetSeedSuffix != "" {
 		// This is a custom table created with the "!seed" prefix
 		// (e.g. playing a deal with a specific seed)
-		g.Seed = seedPrefix + t.ExtraOptions.SetSeedSuffix
+		seed = seedPrefix + t.ExtraOptions.SetSeedSuffix
 	} else {
 		// This is a normal game with a random seed / a random deck
 		// Get a list of all the seeds that these players have played before
@@ -143,38 +219,38 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 		looking := true
 		for looking {
 			seedNum++
-			g.Seed = seedPrefix + strconv.Itoa(seedNum)
-			if _, ok := seedMap[g.Seed]; !ok {
+			seed = seedPrefix + strconv.Itoa(seedNum)
+			if _, ok := seedMap[seed]; !ok {
 				looking = false
 			}
 		}
 	}
-	logger.Info(t.GetName()+"Using seed:", g.Seed)
+	logger.Info(t.GetName()+"Using seed:", seed)
 	logger.Info("Shuffling deck:", shuffleDeck)
 	logger.Info("Shuffling players:", shufflePlayers)
 
-	setSeed(g.Seed) // Seed the random number generator
+	// Create the game object
+	g := NewGame(t, seed)
+
+	// Custom seeds override custom decks
+	var customDeck []*engine.CardIdentity
+	if t.ExtraOptions.CustomSeed == "" {
+		customDeck = t.ExtraOptions.CustomDeck
+	}
+	g.InitDeck(customDeck)
 	if shuffleDeck {
 		g.ShuffleDeck()
 	}
 
-	// Mark the order of all of the cards in the deck
-	for i, c := range g.Deck {
-		c.Order = i
-	}
-
 	// The 0th player will always go first
 	// Since we want a random player to start first, we need to shuffle the order of the players
 	// Additionally, we need to shuffle the order of the players so that the order that the players
 	// joined the game in does not correspond to the order of the players in the actual game
-	// https://stackoverflow.com/questions/12264789/shuffle-array-in-go
-	// For this step, we don't care about explicitly seeding the random number generator,
-	// since any random shuffling will do
+	// This is based on the seed so that the same seed will always result in the same order
 	if shufflePlayers {
-		for i := range t.Players {
-			j := rand.Intn(i + 1)
+		g.ShufflePlayers(len(t.Players), func(i, j int) {
 			t.Players[i], t.Players[j] = t.Players[j], t.Players[i]
-		}
+		})
 	}
 
 	// Games created prior to April 2020 do not always have the 0th player taking the first turn
@@ -183,13 +259,11 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 	}
 
 	// Initialize the GamePlayer objects
-	for i, p := range t.Players {
+	for _, p := range t.Players {
 		gp := &GamePlayer{
-			Name:  p.Name,
-			Index: i,
-			Game:  g,
+			GamePlayer: g.AddPlayer(p.Name),
+			Game:       g,
 
-			Hand:  make([]*Card, 0),
 			Notes: make([]string, g.GetNotesSize()),
 		}
 		gp.InitTime(t.Options)
@@ -199,6 +273,7 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 	// Decide the random character assignments
 	// (this has to be after seed generation and initialization)
 	charactersGenerate(g)
+	g.CharacterAssignments = g.GetCharacterAssignments()
 
 	// Initialize all of the players to not being present
 	// This is so that we don't send them unnecessary messages during the game initialization
@@ -213,12 +288,7 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 	}
 
 	// Deal the cards
-	handSize := g.GetHandSize()
-	for _, p := range g.Players {
-		for i := 0; i < handSize; i++ {
-			p.DrawCard()
-		}
-	}
+	g.Deal()
 
 	// Now that all of the initial game actions have been performed, mark that the game has started
 	t.Running = true
@@ -242,6 +312,9 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
+	// Bots do not need to load the UI, so they can start playing right away
+	t.StartBots()
+
 	// If we are emulating actions on a replay, we do not have to tell anyone about the table yet
 	if !t.ExtraOptions.NoWriteToDatabase {
 		if t.ExtraOptions.Restarted {
@@ -254,7 +327,7 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 
 		// Set the status for all of the users in the game
 		for _, p := range t.Players {
-			if p.Session != nil {
+			if p.Session != nil && p.Bot == nil {
 				p.Session.Set("status", StatusPlaying)
 				p.Session.Set("tableID", t.ID)
 				notifyAllUser(p.Session)
@@ -262,7 +335,8 @@ func tableStart(s *Session, d *CommandData, t *Table) {
 		}
 	}
 
-	// The "CheckTimer()" function will be called when the starting player has finished loading
+	// The turn timer will be scheduled when the starting player has finished loading
+	// (in the "commandLoaded()" function)
 }
 
 func emulateActions(s *Session, d *CommandData, t *Table) {
@@ -297,7 +371,6 @@ func emulateActions(s *Session, d *CommandData, t *Table) {
 					" was not valid. Skipping all subsequent actions. " +
 					"Please report this error to an administrator.")
 			}
-			badGameIDs = append(badGameIDs, d.GameID)
 			return
 		}
 	}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"strconv"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 // commandTableTerminate is sent when the user clicks the terminate button in the bottom-left-hand
@@ -47,9 +49,9 @@ func commandTableTerminate(s *Session, d *CommandData) {
 func terminate(s *Session, t *Table, playerIndex int) {
 	commandAction(s, &CommandData{ // Manual invocation
 		TableID: t.ID,
-		Type:    ActionTypeEndGame,
+		Type:    engine.ActionTypeEndGame,
 		Target:  playerIndex,
-		Value:   EndConditionTerminated,
+		Value:   engine.EndConditionTerminated,
 		NoLock:  true,
 	})
 }
@@ -30,12 +30,32 @@ func commandTagSearch(s *Session, d *CommandData) {
 		gameIDs = v
 	}
 
-	gameIDStrings := make([]string, 0)
-	for _, gameID := range gameIDs {
-		gameIDStrings = append(gameIDStrings, strconv.Itoa(gameID))
+	// Annotations are also searched, since they are free-form text
+	var annotationGameIDs []int
+	if v, err := models.GameAnnotations.Search(d.Msg); err != nil {
+		logger.Error("Failed to search for games with an annotation matching \""+d.Msg+"\":", err)
+		s.Error(DefaultErrorMsg)
+		return
+	} else {
+		annotationGameIDs = v
 	}
 
 	// Send the results via a private message as to not spam public channels
-	msg := "Games matching \"" + d.Msg + "\": " + strings.Join(gameIDStrings, ", ")
+	msg := "Games matching \"" + d.Msg + "\": " + getGameIDListString(gameIDs)
 	chatServerSendPM(s, msg, d.Room)
+	msg = "Games with annotations that mention \"" + d.Msg + "\": " +
+		getGameIDListString(annotationGameIDs)
+	chatServerSendPM(s, msg, d.Room)
+}
+
+func getGameIDListString(gameIDs []int) string {
+	if len(gameIDs) == 0 {
+		return "(none)"
+	}
+
+	gameIDStrings := make([]string, 0)
+	for _, gameID := range gameIDs {
+		gameIDStrings = append(gameIDStrings, strconv.Itoa(gameID))
+	}
+	return strings.Join(gameIDStrings, ", ")
 }
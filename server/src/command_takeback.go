@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// The amount of time that the other players have to agree to a takeback
+	TakebackTimeout = 30 * time.Second
+)
+
+// commandTakeback is sent when the user requests to undo the most recent action of the game
+// or when they vote on a takeback that another player has requested
+//
+// Example data:
+// {
+//   tableID: 5,
+//   setting: 'request', // Can also be 'accept' or 'decline'
+// }
+func commandTakeback(s *Session, d *CommandData) {
+	t, exists := getTableAndLock(s, d.TableID, !d.NoLock)
+	if !exists {
+		return
+	}
+	if !d.NoLock {
+		defer t.Mutex.Unlock()
+	}
+	g := t.Game
+
+	// Validate that the game has started
+	if !t.Running {
+		s.Warning(NotStartedFail)
+		return
+	}
+
+	// Validate that it is not a replay
+	if t.Replay {
+		s.Warning("You cannot take back a move in a replay.")
+		return
+	}
+
+	// Validate that they are in the game
+	playerIndex := t.GetPlayerIndexFromID(s.UserID())
+	if playerIndex == -1 {
+		s.Warning("You are not at table " + strconv.FormatUint(t.ID, 10) + ", " +
+			"so you cannot take back a move.")
+		return
+	}
+
+	// Validate the setting
+	if d.Setting == "request" {
+		if g.TakebackRequested {
+			s.Warning("A takeback has already been requested.")
+			return
+		}
+
+		if len(g.Actions2) == 0 {
+			s.Warning("No moves have been made yet, so there is nothing to take back.")
+			return
+		}
+	} else if d.Setting == "accept" || d.Setting == "decline" {
+		if !g.TakebackRequested {
+			s.Warning("Nobody has requested a takeback, so you cannot vote on one.")
+			return
+		}
+
+		if g.TakebackVotes[playerIndex] {
+			s.Warning("You have already agreed to the takeback.")
+			return
+		}
+	} else {
+		s.Warning("That is not a valid setting.")
+		return
+	}
+
+	takeback(s, d, t, playerIndex)
+}
+
+func takeback(s *Session, d *CommandData, t *Table, playerIndex int) {
+	// Local variables
+	g := t.Game
+
+	if d.Setting == "decline" {
+		takebackCancel(t, s.Username()+" declined the takeback.")
+		return
+	}
+
+	if d.Setting == "request" {
+		g.TakebackRequested = true
+		g.TakebackVotes = make([]bool, len(g.Players))
+
+		// Bots do not have an opinion on misclicks, so they always agree
+		for i, p := range t.Players {
+			if p.Bot != nil {
+				g.TakebackVotes[i] = true
+			}
+		}
+
+		scheduler.Schedule(t, TaskTakeback, TakebackTimeout, func(t *Table) {
+			takebackCancel(t, "Not everyone agreed to the takeback in time.")
+		})
+
+		msg := s.Username() + " requested to take back the last move. " +
+			"Type \"/accept\" or \"/decline\" within " +
+			strconv.Itoa(int(TakebackTimeout/time.Second)) + " seconds."
+		chatServerSend(msg, t.GetRoomName())
+	} else if d.Setting == "accept" {
+		chatServerSend(s.Username()+" agreed to the takeback.", t.GetRoomName())
+	}
+	g.TakebackVotes[playerIndex] = true
+
+	for _, vote := range g.TakebackVotes {
+		if !vote {
+			// We are still waiting for someone to vote
+			return
+		}
+	}
+
+	g.TakebackRequested = false
+	scheduler.Cancel(t, TaskTakeback)
+	if err := g.Takeback(); err != nil {
+		logger.Error(t.GetName()+"Failed to take back the last move:", err)
+		chatServerSend("Failed to take back the last move.", t.GetRoomName())
+		return
+	}
+	chatServerSend("The last move was taken back.", t.GetRoomName())
+}
+
+// takebackCancel is called when a takeback request should no longer go through
+func takebackCancel(t *Table, msg string) {
+	g := t.Game
+
+	if !g.TakebackRequested {
+		return
+	}
+
+	g.TakebackRequested = false
+	scheduler.Cancel(t, TaskTakeback)
+	chatServerSend(msg, t.GetRoomName())
+}
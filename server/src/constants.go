@@ -18,34 +18,6 @@ const (
 	StatusSharedReplay
 )
 
-// When in a game, players can send certain types of "actions" to the server to communicate what
-// kind of move they want to perform
-const (
-	ActionTypePlay = iota
-	ActionTypeDiscard
-	ActionTypeColorClue
-	ActionTypeRankClue
-	ActionTypeEndGame // Players cannot send this (internal only)
-)
-
-const (
-	ClueTypeColor = iota
-	ClueTypeRank
-)
-
-const (
-	EndConditionInProgress = iota
-	EndConditionNormal
-	EndConditionStrikeout
-	EndConditionTimeout
-	EndConditionTerminated
-	EndConditionSpeedrunFail
-	EndConditionIdleTimeout
-	EndConditionCharacterSoftlock
-	EndConditionAllOrNothingFail
-	EndConditionAllOrNothingSoftlock
-)
-
 // When in a shared replay, spectators can send certain types of "actions" to the server to
 // communicate what kind of function they want to perform
 const (
@@ -65,35 +37,17 @@ const (
 	ReplayActionTypeHypoBack
 	// Toggle whether or not drawn cards should be hidden (true by default)
 	ReplayActionTypeToggleRevealed
-)
-
-// Certain types of optional game settings can make the game easier
-// We need to keep track of these options when determining the maximum score for a particular
-// variant
-const (
-	ScoreModifierDeckPlays Bitmask = 1 << iota // e.g. 1, 2, 4, and so forth
-	ScoreModifierEmptyClues
-	ScoreModifierOneExtraCard
-	ScoreModifierOneLessCard
-	ScoreModifierAllOrNothing
+	// Save the current hypothetical line with a name
+	ReplayActionTypeHypoSave
+	// Switch to a saved hypothetical line
+	ReplayActionTypeHypoLoad
+	// Delete a saved hypothetical line
+	ReplayActionTypeHypoDelete
 )
 
 const (
 	WebsiteName = "Hanab Live"
 
-	// The maximum amount of clues (and the amount of clues that players start the game with)
-	MaxClueNum = 8
-
-	// The maximum amount of strikes/misplays allowed before the game ends
-	MaxStrikeNum = 3
-
-	// Currently, in all variants, you get 5 points per suit/stack,
-	// but this may not always be the case
-	PointsPerSuit = 5
-
-	// A "reversed" version of every suit exists
-	SuitReversedSuffix = " Reversed"
-
 	// The amount of time that players have to finish their game once
 	// a server shutdown or restart is initiated
 	ShutdownTimeout = time.Minute * 30
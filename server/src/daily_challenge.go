@@ -0,0 +1,178 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// The leaderboard is sorted by score, with ties broken by the fewest turns
+	// and then by who finished first
+	DailyChallengeSort = "score DESC, num_turns ASC, id ASC"
+
+	DailyChallengeDateFormat     = "2006-01-02"
+	DailyChallengeSeedDateFormat = "20060102"
+)
+
+var (
+	// The variant of the daily challenge cycles through this list
+	dailyChallengeVariants = []string{
+		"No Variant",
+		"Rainbow (5 Suits)",
+		"6 Suits",
+		"Black (6 Suits)",
+		"Pink (5 Suits)",
+		"White (5 Suits)",
+		"Brown (5 Suits)",
+		"Rainbow (6 Suits)",
+		"Omni (5 Suits)",
+		"Null (5 Suits)",
+		"Light Pink (5 Suits)",
+		"Muddy Rainbow (5 Suits)",
+		"Prism (5 Suits)",
+		"Gray (6 Suits)",
+	}
+
+	dailyChallenge      *DailyChallenge
+	dailyChallengeMutex = sync.RWMutex{}
+
+	// e.g. "p2v0sd20501231"
+	dailyChallengeSeedRegExp   = regexp.MustCompile(`^p\d+v\d+sd(\d{8})$`)
+	isDailyChallengeSeedSuffix = regexp.MustCompile(`^d\d{8}$`).MatchString
+)
+
+type DailyChallenge struct {
+	Date        time.Time // Midnight UTC of the day that the challenge is for
+	VariantName string
+}
+
+// dailyChallengeInit publishes the challenge for today and schedules the challenge for tomorrow
+func dailyChallengeInit() {
+	for _, variantName := range dailyChallengeVariants {
+		if getVariant(variantName) == nil {
+			logger.Fatal("The daily challenge variant of \"" + variantName + "\" does not exist.")
+			return
+		}
+	}
+
+	dailyChallengePublish(false)
+}
+
+func dailyChallengePublish(announce bool) {
+	date := getDailyChallengeDate(time.Now())
+
+	// The variant is stored in the database so that it does not change if the list of variants is
+	// modified in the middle of the day
+	dayNumber := int(date.Unix() / int64(24*time.Hour/time.Second))
+	variant := getVariant(dailyChallengeVariants[dayNumber%len(dailyChallengeVariants)])
+	if err := models.DailyChallenges.Insert(date, variant.ID); err != nil {
+		logger.Error("Failed to insert the daily challenge for "+
+			date.Format(DailyChallengeDateFormat)+":", err)
+	} else if exists, v, err := models.DailyChallenges.GetVariantID(date); err != nil {
+		logger.Error("Failed to get the daily challenge for "+
+			date.Format(DailyChallengeDateFormat)+":", err)
+	} else if exists {
+		if variantName, ok := getVariantNameFromID(v); ok {
+			variant = getVariant(variantName)
+		}
+	}
+
+	newDailyChallenge := &DailyChallenge{
+		Date:        date,
+		VariantName: variant.Name,
+	}
+	dailyChallengeMutex.Lock()
+	dailyChallenge = newDailyChallenge
+	dailyChallengeMutex.Unlock()
+
+	logger.Info("Published the daily challenge for " + date.Format(DailyChallengeDateFormat) +
+		" with a variant of: " + variant.Name)
+	if announce {
+		msg := "The daily challenge for " + date.Format(DailyChallengeDateFormat) + " is " +
+			"\"" + variant.Name + "\". Create a table named \"!daily\" to play it."
+		chatServerSendAll(msg)
+	}
+
+	// Publish the next challenge at midnight UTC
+	time.AfterFunc(time.Until(date.Add(24*time.Hour)), func() {
+		dailyChallengePublish(true)
+	})
+}
+
+func getDailyChallenge() *DailyChallenge {
+	dailyChallengeMutex.RLock()
+	defer dailyChallengeMutex.RUnlock()
+	return dailyChallenge
+}
+
+// getDailyChallengeDate returns midnight UTC of the day that contains the given time
+func getDailyChallengeDate(datetime time.Time) time.Time {
+	return datetime.UTC().Truncate(24 * time.Hour)
+}
+
+// GetSeedSuffix returns the part of the seed that comes after the player count and variant
+// (e.g. "d20501231" for "p2v0sd20501231")
+func (dc *DailyChallenge) GetSeedSuffix() string {
+	return "d" + dc.Date.Format(DailyChallengeSeedDateFormat)
+}
+
+func (dc *DailyChallenge) GetSeed(numPlayers int) string {
+	variant := getVariant(dc.VariantName)
+	return "p" + strconv.Itoa(numPlayers) + "v" + strconv.Itoa(variant.ID) + "s" +
+		dc.GetSeedSuffix()
+}
+
+// isDailyChallengeSeedHidden returns true if the seed belongs to a daily challenge whose day has
+// not ended yet
+func isDailyChallengeSeedHidden(seed string) bool {
+	match := dailyChallengeSeedRegExp.FindStringSubmatch(seed)
+	if match == nil {
+		return false
+	}
+
+	date, err := time.Parse(DailyChallengeSeedDateFormat, match[1])
+	if err != nil {
+		return false
+	}
+
+	return time.Now().Before(date.Add(24 * time.Hour))
+}
+
+// dailyChallengeValidateReplay is the same as "dailyChallengeValidateView()" but for a game in the
+// database
+func dailyChallengeValidateReplay(s *Session, databaseID int) bool {
+	var seed string
+	if v, err := models.Games.GetSeed(databaseID); err != nil {
+		logger.Error("Failed to get the seed for game "+strconv.Itoa(databaseID)+":", err)
+		s.Error(InitGameFail)
+		return false
+	} else {
+		seed = v
+	}
+
+	return dailyChallengeValidateView(s, seed)
+}
+
+// dailyChallengeValidateView returns false if the user is not allowed to see the deck of a game
+// on the seed
+// Until the day is over, only the players who have already played the challenge can see it
+func dailyChallengeValidateView(s *Session, seed string) bool {
+	if !isDailyChallengeSeedHidden(seed) {
+		return true
+	}
+
+	if hasPlayed, err := models.Games.HasPlayedSeed(s.UserID(), seed); err != nil {
+		logger.Error("Failed to check to see if \""+s.Username()+"\" has played seed "+
+			"\""+seed+"\":", err)
+		s.Error(DefaultErrorMsg)
+		return false
+	} else if !hasPlayed {
+		s.Warning("That deal is a daily challenge. You can only view games on it after you " +
+			"have played it or after the day is over.")
+		return false
+	}
+
+	return true
+}
@@ -7,10 +7,6 @@ import (
 	"strings"
 )
 
-var (
-	badGameIDs = make([]int, 0)
-)
-
 func debugPrint() {
 	tablesMutex.RLock()
 	defer tablesMutex.RUnlock()
@@ -208,7 +204,6 @@ func debugFunction() {
 	// updateAllUserStats()
 	// updateAllVariantStats()
 	// updateUserStatsFromPast24Hours()
-	// getBadGameIDs()
 
 	updateUserStatsFromInterval("2 hours")
 
@@ -284,7 +279,7 @@ func updateStatsFromGameHistory(gameHistory *GameHistory) {
 	logger.Debug("Updating stats for game: " + strconv.Itoa(gameHistory.ID))
 
 	// Local variables
-	variant := variants[gameHistory.Options.VariantName]
+	variant := getVariant(gameHistory.Options.VariantName)
 	// 2-player is at index 0, 3-player is at index 1, etc.
 	bestScoreIndex := gameHistory.Options.NumPlayers - 2
 
@@ -367,34 +362,4 @@ func variantGetHighestID() int {
 	}
 	return highestID
 }
-
-func getBadGameIDs() {
-	// Get all game IDs
-	var ids []int
-	if v, err := models.Games.GetAllIDs(); err != nil {
-		logger.Fatal("Failed to get all of the game IDs:", err)
-		return
-	} else {
-		ids = v
-	}
-
-	for i, id := range ids {
-		if i > 1000 {
-			break
-		}
-		logger.Debug("ON GAME:", id)
-		s := newFakeSession(1, "Server")
-		commandReplayCreate(s, &CommandData{ // Manual invocation
-			Source:     "id",
-			GameID:     id,
-			Visibility: "solo",
-		})
-		commandTableUnattend(s, &CommandData{ // Manual invocation
-			TableID: tableIDCounter,
-		})
-	}
-
-	logger.Debug("BAD GAME IDS:")
-	logger.Debug(badGameIDs)
-}
 */
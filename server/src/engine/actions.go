@@ -1,8 +1,9 @@
 // Actions represent a change in the game state
 // Different actions will have different fields
 // Any actions implemented here must also be accounted for in the "restoreTables()" function
+// (in the server)
 
-package main
+package engine
 
 // Used to implement the "Slow-Witted" detrimental character
 type ActionCardIdentity struct {
@@ -85,12 +86,20 @@ type Clue struct {
 	Value int `json:"value"`
 }
 
-func NewClue(d *CommandData) Clue {
+func NewClue(a *GameAction) Clue {
 	return Clue{
 		// A color clue is action type 2
 		// A rank clue is action type 3
 		// Remap these to 0 and 1, respectively
-		Type:  d.Type - 2,
-		Value: d.Value,
+		Type:  a.Type - 2,
+		Value: a.Value,
 	}
 }
+
+// GameAction is a database-compatible representation of an in-game move
+// These fields are described in "database_schema.sql"
+type GameAction struct {
+	Type   int `json:"type"`
+	Target int `json:"target"`
+	Value  int `json:"value"`
+}
@@ -0,0 +1,109 @@
+package engine
+
+// Scrub removes some information from the action to prevent players having more knowledge
+// than they should have, if necessary (e.g. when a card is drawn to a player's hand)
+// "playerIndex" is the index of the player that will receive the action
+// (or -1 for a spectator that can see every hand)
+func (g *Game) Scrub(action interface{}, playerIndex int) interface{} {
+	var p *GamePlayer
+	if playerIndex >= 0 && playerIndex < len(g.Players) {
+		p = g.Players[playerIndex]
+	}
+
+	cardIdentityAction, ok := action.(ActionCardIdentity)
+	if ok && cardIdentityAction.Type == "cardIdentity" {
+		cardIdentityAction.Scrub(g, p)
+		return cardIdentityAction
+	}
+
+	discardAction, ok := action.(ActionDiscard)
+	if ok && discardAction.Type == "discard" {
+		discardAction.Scrub(g, p)
+		return discardAction
+	}
+
+	drawAction, ok := action.(ActionDraw)
+	if ok && drawAction.Type == "draw" {
+		drawAction.Scrub(g, p)
+		return drawAction
+	}
+
+	playAction, ok := action.(ActionPlay)
+	if ok && playAction.Type == "play" {
+		playAction.Scrub(g, p)
+		return playAction
+	}
+
+	return action
+}
+
+// GetScrubbedActions returns every action that has happened thus far in the game,
+// as seen from the perspective of a particular player
+// (or -1 for a spectator that can see every hand)
+func (g *Game) GetScrubbedActions(playerIndex int) []interface{} {
+	scrubbedActions := make([]interface{}, 0, len(g.Actions))
+	for _, action := range g.Actions {
+		scrubbedActions = append(scrubbedActions, g.Scrub(action, playerIndex))
+	}
+	return scrubbedActions
+}
+
+// Scrub removes some information from a draw so that we do not reveal the identity of drawn
+// cards to the players drawing those cards
+func (a *ActionDraw) Scrub(g *Game, p *GamePlayer) {
+	if p == nil {
+		// Spectators get to see the identities of all drawn cards
+		return
+	}
+
+	if a.PlayerIndex == p.Index || // They are drawing the card
+		// They are playing a special character that should not be able to see the card
+		characterHideCard(a, g, p) {
+
+		a.Rank = -1
+		a.SuitIndex = -1
+	}
+}
+
+// Scrub removes some information from played cards so that we do not reveal the identity of played
+// cards to anybody (in some specific variants)
+func (a *ActionPlay) Scrub(g *Game, p *GamePlayer) {
+	if p == nil {
+		// Spectators get to see the identities of played cards
+		return
+	}
+
+	if g.Variant.IsThrowItInAHole() {
+		a.Rank = -1
+		a.SuitIndex = -1
+	}
+}
+
+// Scrub removes some information from discarded cards so that we do not reveal the identity of
+// discarded cards to anybody (in some specific variants)
+func (a *ActionDiscard) Scrub(g *Game, p *GamePlayer) {
+	if p == nil {
+		// Spectators get to see the identities of discarded cards
+		return
+	}
+
+	if g.Variant.IsThrowItInAHole() && a.Failed {
+		// For the purposes of hiding information, failed discards are equivalent to plays
+		a.Rank = -1
+		a.SuitIndex = -1
+	}
+}
+
+// Scrub removes some information from a card identity action so that we do not reveal the identity
+// of sliding cards to the players who are holding those cards
+func (a *ActionCardIdentity) Scrub(g *Game, p *GamePlayer) {
+	if p == nil {
+		// Spectators get to see the identities of all cards
+		return
+	}
+
+	if a.PlayerIndex == p.Index { // They are holding the card
+		a.Rank = -1
+		a.SuitIndex = -1
+	}
+}
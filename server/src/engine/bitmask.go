@@ -1,4 +1,4 @@
-package main
+package engine
 
 // From: https://stackoverflow.com/questions/48050522/using-bitsets-in-golang-to-represent-capabilities
 type Bitmask uint32
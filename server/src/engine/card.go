@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"strconv"
@@ -29,8 +29,7 @@ func NewCard(suit int, rank int) *Card {
 	return c
 }
 
-func (c *Card) Name(g *Game) string {
-	variant := variants[g.Options.VariantName]
+func (c *Card) Name(variant *Variant) string {
 	suit := variant.Suits[c.SuitIndex]
 	name := suit.Name
 	name += " "
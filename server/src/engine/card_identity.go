@@ -1,4 +1,4 @@
-package main
+package engine
 
 type CardIdentity struct {
 	SuitIndex int `json:"suitIndex"`
@@ -1,4 +1,4 @@
-package main
+package engine
 
 type Character struct {
 	// Similar to variants, each character must have a unique numerical ID (for the database)
@@ -9,3 +9,10 @@ type Character struct {
 	WriteMetadataToDatabase bool   `json:"writeMetadataToDatabase"`
 	Not2P                   bool   `json:"not2P"`
 }
+
+// CharacterAssignment is the character (and the associated metadata) that a particular player
+// was assigned in a game with detrimental characters
+type CharacterAssignment struct {
+	Name     string `json:"name"`
+	Metadata int    `json:"metadata"`
+}
@@ -0,0 +1,646 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+)
+
+// LoadCharacters reads the "characters.json" file and returns every character
+// (in the same order as they are listed in the file)
+func LoadCharacters(filePath string) ([]*Character, error) {
+	// Import the JSON file
+	var fileContents []byte
+	if v, err := ioutil.ReadFile(filePath); err != nil {
+		return nil, err
+	} else {
+		fileContents = v
+	}
+	var charactersArray []*Character
+	if err := json.Unmarshal(fileContents, &charactersArray); err != nil {
+		return nil, err
+	}
+
+	characterNameMap := make(map[string]struct{})
+	characterIDMap := make(map[int]struct{})
+	for _, character := range charactersArray {
+		// Validate the name
+		if character.Name == "" {
+			return nil, errors.New("there is a character with an empty name")
+		}
+
+		// Validate the ID
+		if character.ID < 0 { // The first character has an ID of 0
+			return nil, errors.New("the \"" + character.Name + "\" character has an invalid ID")
+		}
+
+		// Validate the description
+		if character.Description == "" {
+			return nil, errors.New("the \"" + character.Name + "\" character " +
+				"does not have a description")
+		}
+
+		// Validate the emoji
+		if character.Emoji == "" {
+			return nil, errors.New("the \"" + character.Name + "\" character " +
+				"does not have an emoji")
+		}
+
+		// Validate that all of the names are unique
+		if _, ok := characterNameMap[character.Name]; ok {
+			return nil, errors.New("there are two characters with the name of " +
+				"\"" + character.Name + "\"")
+		}
+		characterNameMap[character.Name] = struct{}{}
+
+		// Validate that all of the ID's are unique
+		if _, ok := characterIDMap[character.ID]; ok {
+			return nil, errors.New("there are two characters with the ID of " +
+				"\"" + strconv.Itoa(character.ID) + "\"")
+		}
+		characterIDMap[character.ID] = struct{}{}
+	}
+
+	return charactersArray, nil
+}
+
+// SetCharacters assigns predefined characters to every player (e.g. when watching a replay)
+func (g *Game) SetCharacters(characterAssignments []*CharacterAssignment) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	if len(characterAssignments) != len(g.Players) {
+		return errors.New("there are " + strconv.Itoa(len(characterAssignments)) +
+			" predefined characters, but there are " + strconv.Itoa(len(g.Players)) +
+			" players in the game")
+	}
+
+	for i, p := range g.Players {
+		p.Character = characterAssignments[i].Name
+		p.CharacterMetadata = characterAssignments[i].Metadata
+	}
+
+	return nil
+}
+
+// GetCharacterAssignments returns the characters that the players currently have
+// (this is the inverse of "SetCharacters()")
+func (g *Game) GetCharacterAssignments() []*CharacterAssignment {
+	characterAssignments := make([]*CharacterAssignment, 0, len(g.Players))
+	for _, p := range g.Players {
+		characterAssignments = append(characterAssignments, &CharacterAssignment{
+			Name:     p.Character,
+			Metadata: p.CharacterMetadata,
+		})
+	}
+
+	return characterAssignments
+}
+
+// GenerateCharacters randomly assigns a character to every player based on the game's seed
+// "characters" must be every possible character, in the same order as the "characters.json" file
+func (g *Game) GenerateCharacters(characters []*Character) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	rng := g.seedRNG()
+
+	for i, p := range g.Players {
+		// Set the character
+		for {
+			// Get a random character assignment
+			randomIndex := rng.Intn(len(characters))
+			character := characters[randomIndex]
+			p.Character = character.Name
+
+			// Check to see if any other players have this assignment already
+			alreadyAssigned := false
+			for j, p2 := range g.Players {
+				if i == j {
+					break
+				}
+
+				if p2.Character == p.Character {
+					alreadyAssigned = true
+					break
+				}
+			}
+			if alreadyAssigned {
+				continue
+			}
+
+			// Check to see if this character is restricted from 2-player games
+			if character.Not2P && len(g.Players) == 2 {
+				continue
+			}
+
+			break
+		}
+
+		// Initialize the metadata to -1
+		p.CharacterMetadata = -1
+
+		// Specific characters also have secondary attributes that are stored in the character
+		// metadata field
+		if p.Character == "Fuming" { // 0
+			// A random number from 0 to the number of colors in this variant
+			p.CharacterMetadata = rng.Intn(len(g.Variant.ClueColors))
+		} else if p.Character == "Dumbfounded" { // 1
+			// A random number from 1 to 5
+			p.CharacterMetadata = rng.Intn(4) + 1
+		} else if p.Character == "Inept" { // 2
+			// A random number from 0 to the number of suits in this variant
+			p.CharacterMetadata = rng.Intn(len(g.Stacks))
+		} else if p.Character == "Awkward" { // 3
+			// A random number from 1 to 5
+			p.CharacterMetadata = rng.Intn(4) + 1
+		}
+	}
+}
+
+// characterValidateAction returns an error if validation fails
+func characterValidateAction(a *GameAction, g *Game, p *GamePlayer) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	if p.Character == "Vindictive" && // 9
+		p.CharacterMetadata == 0 &&
+		(a.Type != ActionTypeColorClue && a.Type != ActionTypeRankClue) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you must give a clue if you have been given a clue on this go-around.")
+	} else if p.Character == "Insistent" && // 13
+		p.CharacterMetadata != -1 &&
+		(a.Type != ActionTypeColorClue && a.Type != ActionTypeRankClue) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you must continue to clue the same card until it is played or discarded.")
+	} else if p.Character == "Impulsive" && // 17
+		p.CharacterMetadata == 0 &&
+		(a.Type != ActionTypePlay ||
+			a.Target != p.Hand[len(p.Hand)-1].Order) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you must play your slot 1 card after it has been clued.")
+	} else if p.Character == "Indolent" && // 18
+		a.Type == ActionTypePlay &&
+		p.CharacterMetadata == 0 {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot play a card if you played one in the last round.")
+	} else if p.Character == "Stubborn" && // 28
+		(a.Type == p.CharacterMetadata ||
+			(a.Type == ActionTypeColorClue && p.CharacterMetadata == ActionTypeRankClue) ||
+			(a.Type == ActionTypeRankClue && p.CharacterMetadata == ActionTypeColorClue)) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot perform the same kind of action that the previous player did.")
+	}
+
+	return nil
+}
+
+// characterValidateSecondAction returns an error if validation fails
+func characterValidateSecondAction(a *GameAction, g *Game, p *GamePlayer) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	if p.CharacterMetadata == -1 {
+		return nil
+	}
+
+	if p.Character == "Genius" { // 24
+		if a.Type != ActionTypeRankClue {
+			return errors.New("You are " + p.Character + ", so you must now give a rank clue.")
+		}
+
+		if a.Target != p.CharacterMetadata {
+			return errors.New("You are " + p.Character + ", " +
+				"so you must give the second clue to the same player.")
+		}
+	} else if p.Character == "Panicky" && // 26
+		a.Type != ActionTypeDiscard {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you must discard again since there are 4 or less clues available.")
+	}
+
+	return nil
+}
+
+// characterValidateClue returns an error if validation fails
+func characterValidateClue(a *GameAction, g *Game, p *GamePlayer) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	clue := NewClue(a)        // Convert the incoming data to a clue object
+	p2 := g.Players[a.Target] // Get the target of the clue
+
+	if p.Character == "Fuming" && // 0
+		clue.Type == ClueTypeColor &&
+		clue.Value != p.CharacterMetadata {
+
+		return errors.New("You are " + p.Character + ", so you can not give that type of clue.")
+	} else if p.Character == "Dumbfounded" && // 1
+		clue.Type == ClueTypeRank &&
+		clue.Value != p.CharacterMetadata {
+
+		return errors.New("You are " + p.Character + ", so you can not give that type of clue.")
+	} else if p.Character == "Inept" { // 2
+		cardsTouched := p2.FindCardsTouchedByClue(clue)
+		for _, order := range cardsTouched {
+			c := g.Deck[order]
+			if c.SuitIndex == p.CharacterMetadata {
+				return errors.New("You are " + p.Character + ", " +
+					"so you cannot give clues that touch a specific suit.")
+			}
+		}
+	} else if p.Character == "Awkward" { // 3
+		cardsTouched := p2.FindCardsTouchedByClue(clue)
+		for _, order := range cardsTouched {
+			c := g.Deck[order]
+			if c.Rank == p.CharacterMetadata {
+				return errors.New("You are " + p.Character + ", " +
+					"so you cannot give clues that touch cards with a rank of " +
+					strconv.Itoa(p.CharacterMetadata) + ".")
+			}
+		}
+	} else if p.Character == "Conservative" && // 4
+		len(p2.FindCardsTouchedByClue(clue)) != 1 {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you can only give clues that touch a single card.")
+	} else if p.Character == "Greedy" && // 5
+		len(p2.FindCardsTouchedByClue(clue)) < 2 {
+
+		return errors.New("You are " + p.Character + ", so you can only give clues that touch 2+ cards.")
+	} else if p.Character == "Picky" && // 6
+		((clue.Type == ClueTypeRank &&
+			clue.Value%2 == 0) ||
+			(clue.Type == ClueTypeColor &&
+				(clue.Value+1)%2 == 0)) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you can only clue odd numbers or odd colors.")
+	} else if p.Character == "Spiteful" { // 7
+		leftIndex := p.Index + 1
+		if leftIndex == len(g.Players) {
+			leftIndex = 0
+		}
+		if a.Target == leftIndex {
+			return errors.New("You are " + p.Character + ", so you cannot clue the player to your left.")
+		}
+	} else if p.Character == "Insolent" { // 8
+		rightIndex := p.Index - 1
+		if rightIndex == -1 {
+			rightIndex = len(g.Players) - 1
+		}
+		if a.Target == rightIndex {
+			return errors.New("You are " + p.Character + ", so you cannot clue the player to your right.")
+		}
+	} else if p.Character == "Miser" && // 10
+		g.ClueTokens < g.Variant.GetAdjustedClueTokens(4) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot give a clue unless there are 4 or more clues available.")
+	} else if p.Character == "Compulsive" && // 11
+		!p2.IsFirstCardTouchedByClue(clue) &&
+		!p2.IsLastCardTouchedByClue(clue) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you can only give a clue if it touches either the newest or oldest card in a hand.")
+	} else if p.Character == "Mood Swings" && // 12
+		p.CharacterMetadata == clue.Type {
+
+		return errors.New("You are " + p.Character + ", so cannot give the same clue type twice in a row.")
+	} else if p.Character == "Insistent" && // 13
+		p.CharacterMetadata != -1 {
+
+		cardsTouched := p2.FindCardsTouchedByClue(clue)
+		touchedInsistentCard := false
+		for _, order := range cardsTouched {
+			c := g.Deck[order]
+			if c.InsistentTouched {
+				touchedInsistentCard = true
+				break
+			}
+		}
+		if !touchedInsistentCard {
+			return errors.New("You are " + p.Character + ", " +
+				"so you must continue to clue a card until it is played or discarded.")
+		}
+	} else if p.Character == "Genius" && // 24
+		p.CharacterMetadata == -1 {
+
+		if g.ClueTokens < g.Variant.GetAdjustedClueTokens(2) {
+			return errors.New("You are " + p.Character + ", " +
+				"so there needs to be at least two clues available for you to give a clue.")
+		}
+
+		if clue.Type != ClueTypeColor {
+			return errors.New("You are " + p.Character + ", so you must give a color clue first.")
+		}
+	}
+
+	if p2.Character == "Vulnerable" && // 14
+		clue.Type == ClueTypeRank &&
+		(clue.Value == 2 || clue.Value == 5) {
+
+		return errors.New("You cannot give a number 2 or number 5 clue to a " + p2.Character + " character.")
+	} else if p2.Character == "Color-Blind" && // 15
+		clue.Type == ClueTypeColor {
+
+		return errors.New("You cannot give that color clue to a " + p2.Character + " character.")
+	}
+
+	return nil
+}
+
+// characterCheckPlay returns an error if the card cannot be played
+func characterCheckPlay(a *GameAction, g *Game, p *GamePlayer) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	if p.Character == "Hesitant" && // 19
+		p.GetCardSlot(a.Target) == 1 {
+
+		return errors.New("You cannot play that card since you are a " + p.Character + " character.")
+	}
+
+	return nil
+}
+
+// characterCheckMisplay returns true if the card should misplay
+func characterCheckMisplay(g *Game, p *GamePlayer, c *Card) bool {
+	if !g.Options.DetrimentalCharacters {
+		return false
+	}
+
+	if p.Character == "Follower" { // 31
+		// Look through the stacks to see if two cards of this rank have already been played
+		numPlayedOfThisRank := 0
+		for _, s := range g.Stacks {
+			if s >= c.Rank {
+				numPlayedOfThisRank++
+			}
+		}
+		if numPlayedOfThisRank < 2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// characterCheckDiscard returns an error if the player cannot currently discard
+func characterCheckDiscard(g *Game, p *GamePlayer) error {
+	if !g.Options.DetrimentalCharacters {
+		return nil
+	}
+
+	if p.Character == "Anxious" && // 21
+		g.ClueTokens%2 == 0 { // Even amount of clues
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot discard when there is an even number of clues available.")
+	} else if p.Character == "Traumatized" && // 22
+		g.ClueTokens%2 == 1 { // Odd amount of clues
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot discard when there is an odd number of clues available.")
+	} else if p.Character == "Wasteful" && // 23
+		g.ClueTokens >= g.Variant.GetAdjustedClueTokens(2) {
+
+		return errors.New("You are " + p.Character + ", " +
+			"so you cannot discard if there are 2 or more clues available.")
+	}
+
+	return nil
+}
+
+func characterPostClue(a *GameAction, g *Game, p *GamePlayer) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	clue := NewClue(a)        // Convert the incoming data to a clue object
+	p2 := g.Players[a.Target] // Get the target of the clue
+
+	if p.Character == "Mood Swings" { // 12
+		p.CharacterMetadata = clue.Type
+	} else if p.Character == "Insistent" { // 13
+		// Don't do anything if they are already in their "Insistent" state
+		if p.CharacterMetadata == -1 {
+			// Mark that the cards that they clued must be continue to be clued
+			cardsTouched := p2.FindCardsTouchedByClue(clue)
+			for _, order := range cardsTouched {
+				c := g.Deck[order]
+				c.InsistentTouched = true
+			}
+			p.CharacterMetadata = 0 // 0 means that the "Insistent" state is activated
+		}
+	}
+
+	if p2.Character == "Vindictive" { // 9
+		// Store that they have had at least one clue given to them on this go-around of the table
+		p2.CharacterMetadata = 0
+	} else if p2.Character == "Impulsive" && // 17
+		p2.IsFirstCardTouchedByClue(clue) {
+
+		// Store that they had their slot 1 card clued
+		p2.CharacterMetadata = 0
+	}
+}
+
+func characterPostRemoveCard(g *Game, p *GamePlayer, c *Card) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	if !c.InsistentTouched {
+		return
+	}
+
+	for _, c2 := range p.Hand {
+		c2.InsistentTouched = false
+	}
+
+	// Find the "Insistent" player and reset their state so that
+	// they are not forced to give a clue on their subsequent turn
+	for _, p2 := range g.Players {
+		if p2.Character == "Insistent" { // 13
+			p2.CharacterMetadata = -1
+			break // Only one player should be Insistent
+		}
+	}
+}
+
+func characterPostAction(a *GameAction, g *Game, p *GamePlayer) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	// Clear the counter for characters that have abilities relating to
+	// a single go-around of the table
+	if p.Character == "Vindictive" { // 9
+		p.CharacterMetadata = -1
+	} else if p.Character == "Impulsive" { // 17
+		p.CharacterMetadata = -1
+	} else if p.Character == "Indolent" { // 18
+		if a.Type == ActionTypePlay {
+			p.CharacterMetadata = 0
+		} else {
+			p.CharacterMetadata = -1
+		}
+	} else if p.Character == "Contrarian" { // 27
+		g.TurnsInverted = !g.TurnsInverted
+	}
+
+	// Store the last action that was performed
+	for _, p2 := range g.Players {
+		if p2.Character == "Stubborn" { // 28
+			p2.CharacterMetadata = a.Type
+		}
+	}
+}
+
+func characterNeedsToTakeSecondTurn(a *GameAction, g *Game, p *GamePlayer) bool {
+	if !g.Options.DetrimentalCharacters {
+		return false
+	}
+
+	if p.Character == "Genius" { // 24
+		// Must clue both a color and a number (uses 2 clues)
+		// The clue target is stored in "p.CharacterMetadata"
+		if a.Type == ActionTypeColorClue {
+			p.CharacterMetadata = a.Target
+			return true
+		} else if a.Type == ActionTypeRankClue {
+			p.CharacterMetadata = -1
+			return false
+		}
+	} else if p.Character == "Panicky" && // 26
+		a.Type == ActionTypeDiscard {
+
+		// After discarding, discards again if there are 4 clues or less
+		// "p.CharacterMetadata" represents the state, which alternates between -1 and 0
+		if p.CharacterMetadata == -1 && g.ClueTokens <= g.Variant.GetAdjustedClueTokens(4) {
+			p.CharacterMetadata = 0
+			return true
+		} else if p.CharacterMetadata == 0 {
+			p.CharacterMetadata = -1
+			return false
+		}
+	}
+
+	return false
+}
+
+func characterHideCard(a *ActionDraw, g *Game, p *GamePlayer) bool {
+	if !g.Options.DetrimentalCharacters {
+		return false
+	}
+
+	if p.Character == "Blind Spot" && a.PlayerIndex == p.GetNextPlayer() { // 29
+		return true
+	} else if p.Character == "Oblivious" && a.PlayerIndex == p.GetPreviousPlayer() { // 30
+		return true
+	} else if p.Character == "Slow-Witted" { // 33
+		return true
+	}
+
+	return false
+}
+
+func characterSendCardIdentityOfSlot2(g *Game, playerIndexDrawingCard int) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	// Local variables
+	p := g.Players[playerIndexDrawingCard]
+
+	if len(p.Hand) <= 1 {
+		return
+	}
+
+	hasSlowWitted := false
+	for _, p2 := range g.Players {
+		if p2.Character == "Slow-Witted" { // 33
+			hasSlowWitted = true
+			break
+		}
+	}
+
+	if hasSlowWitted {
+		// Card information will be scrubbed from the action in the "Scrub()" function
+		c := p.Hand[len(p.Hand)-2] // Slot 2
+		g.AddAction(ActionCardIdentity{
+			Type:        "cardIdentity",
+			PlayerIndex: p.Index,
+			Order:       c.Order,
+			SuitIndex:   c.SuitIndex,
+			Rank:        c.Rank,
+		})
+	}
+}
+
+func characterAdjustEndTurn(g *Game) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	// Check to see if anyone is playing as a character that will adjust
+	// the final go-around of the table
+	for _, p := range g.Players {
+		if p.Character == "Contrarian" { // 27
+			// 3 instead of 2 because it should be 2 turns after the final card is drawn
+			g.EndTurn = g.Turn + 3
+		}
+	}
+}
+
+func characterCheckSoftlock(g *Game, p *GamePlayer) {
+	if !g.Options.DetrimentalCharacters {
+		return
+	}
+
+	if g.ClueTokens < g.Variant.GetAdjustedClueTokens(1) &&
+		p.CharacterMetadata == 0 && // The character's "special ability" is currently enabled
+		(p.Character == "Vindictive" || // 9
+			p.Character == "Insistent") { // 13
+
+		g.EndCondition = EndConditionCharacterSoftlock
+		g.EndPlayer = p.Index
+	}
+}
+
+func characterSeesCard(g *Game, p *GamePlayer, p2 *GamePlayer, cardOrder int) bool {
+	if !g.Options.DetrimentalCharacters {
+		return true
+	}
+
+	if p.Character == "Blind Spot" && p2.Index == p.GetNextPlayer() { // 29
+		// Cannot see the cards of the next player
+		return false
+	}
+
+	if p.Character == "Oblivious" && p2.Index == p.GetPreviousPlayer() { // 30
+		// Cannot see the cards of the previous player
+		return false
+	}
+
+	if p.Character == "Slow-Witted" && p2.GetCardSlot(cardOrder) == 1 { // 33
+		// Cannot see cards in slot 1
+		return false
+	}
+
+	return true
+}
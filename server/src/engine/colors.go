@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+)
+
+// LoadColors reads the "colors.json" file and returns a map of every color, keyed by name
+func LoadColors(filePath string) (map[string]*Color, error) {
+	// Import the JSON file
+	var fileContents []byte
+	if v, err := ioutil.ReadFile(filePath); err != nil {
+		return nil, err
+	} else {
+		fileContents = v
+	}
+	var colorsArray []*Color
+	if err := json.Unmarshal(fileContents, &colorsArray); err != nil {
+		return nil, err
+	}
+
+	// Convert the array to a map
+	colors := make(map[string]*Color)
+	for _, color := range colorsArray {
+		// Validate the name
+		if color.Name == "" {
+			return nil, errors.New("there is a color with an empty name")
+		}
+
+		// Validate that there is an abbreviation
+		if color.Abbreviation == "" {
+			// Assume that it is the first letter of the color
+			color.Abbreviation = string([]rune(color.Name)[0])
+		} else if len(color.Abbreviation) != 1 {
+			return nil, errors.New("the \"" + color.Name + "\" color has an abbreviation " +
+				"that is not one letter long")
+		}
+
+		// Validate the fill
+		if color.Fill == "" {
+			return nil, errors.New("the \"" + color.Name + "\" color has an empty fill")
+		}
+
+		// Validate that all of the names are unique
+		if _, ok := colors[color.Name]; ok {
+			return nil, errors.New("there are two colors with the name of \"" + color.Name + "\"")
+		}
+
+		// Add it to the map
+		colors[color.Name] = color
+	}
+
+	return colors, nil
+}
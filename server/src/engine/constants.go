@@ -0,0 +1,86 @@
+// Package engine contains the rules of Hanabi, independent of any networking or database code
+// A game is created from a variant, a set of options, and a seed; afterwards, game actions
+// can be applied one by one and the resulting state (and the per-player action streams) can be
+// inspected
+// This allows the rules to be used by the server, by bots, and by offline analysis tools alike
+package engine
+
+// iota starts at 0 and counts upwards
+// i.e. ActionTypePlay = 0, ActionTypeDiscard = 1, etc.
+
+// When in a game, players can send certain types of "actions" to the server to communicate what
+// kind of move they want to perform
+const (
+	ActionTypePlay = iota
+	ActionTypeDiscard
+	ActionTypeColorClue
+	ActionTypeRankClue
+	ActionTypeEndGame // Players cannot send this (internal only)
+)
+
+const (
+	ClueTypeColor = iota
+	ClueTypeRank
+)
+
+const (
+	EndConditionInProgress = iota
+	EndConditionNormal
+	EndConditionStrikeout
+	EndConditionTimeout
+	EndConditionTerminated
+	EndConditionSpeedrunFail
+	EndConditionIdleTimeout
+	EndConditionCharacterSoftlock
+	EndConditionAllOrNothingFail
+	EndConditionAllOrNothingSoftlock
+)
+
+// In a timed game, the time control specifies how players gain time over the course of the game
+const (
+	// Players gain "TimePerTurn" seconds after every move (this was the only time control before
+	// October 2020)
+	TimeControlIncrement = iota
+	// The first "TimePerTurn" seconds of every move are not deducted from the clock
+	// (i.e. a Bronstein delay; the unused part of the delay is not kept)
+	TimeControlDelay
+	// The time that a player spends on their turn is split evenly between the other players
+	TimeControlHourglass
+)
+
+// Certain types of optional game settings can make the game easier
+// We need to keep track of these options when determining the maximum score for a particular
+// variant
+const (
+	ScoreModifierDeckPlays Bitmask = 1 << iota // e.g. 1, 2, 4, and so forth
+	ScoreModifierEmptyClues
+	ScoreModifierOneExtraCard
+	ScoreModifierOneLessCard
+	ScoreModifierAllOrNothing
+	ScoreModifierClueLimits
+	ScoreModifierStrikeLimit
+	ScoreModifierTakeback
+)
+
+const (
+	// The amount of players that can be in a game
+	MinPlayers = 2
+	MaxPlayers = 8
+
+	// The default maximum amount of clues (and the amount of clues that players start the game with)
+	MaxClueNum = 8
+
+	// The default maximum amount of strikes/misplays allowed before the game ends
+	MaxStrikeNum = 3
+
+	// The table creator can change the clue and strike limits up to these values
+	MaxCustomClueNum   = 16
+	MaxCustomStrikeNum = 5
+
+	// Currently, in all variants, you get 5 points per suit/stack,
+	// but this may not always be the case
+	PointsPerSuit = 5
+
+	// A "reversed" version of every suit exists
+	SuitReversedSuffix = " Reversed"
+)
@@ -0,0 +1,367 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+)
+
+// Game represents all of the state associated with a game of Hanabi
+// It contains no information about the server, the database, or who is watching
+// A tag of `json:"-"` denotes that the JSON serializer should skip the field when serializing
+// (which is used in this case to prevent circular references)
+type Game struct {
+	// The variant and the options are specified when the game is created and do not change
+	Variant *Variant `json:"-"`
+	Options *Options `json:"-"`
+	// (circular references must also be restored after deserializing)
+
+	Players []*GamePlayer
+	// The seed specifies how the deck is dealt
+	Seed                string
+	Deck                []*Card
+	CardIdentities      []*CardIdentity // A bare-bones version of the deck
+	DeckIndex           int
+	Stacks              []int
+	PlayStackDirections []int // The values for this are listed in "variants_reversible.go"
+	Turn                int   // Starts at 0; the client will represent turn 0 as turn 1 to the user
+	TurnsInverted       bool
+	ActivePlayerIndex   int // Every game always starts with the 0th player going first
+	ClueTokens          int
+	Score               int
+	MaxScore            int
+	Strikes             int
+	LastClueTypeGiven   int // Used in "Alternating Clues" variants
+	// Actions is a list of all of the in-game moves that players have taken thus far
+	// Different actions will have different fields, so we need this to be an generic interface
+	// Furthermore, we do not want this to be a pointer of interfaces because
+	// this simplifies action scrubbing
+	// In the future, we will just send Actions2 to the client and delete Actions
+	Actions []interface{}
+	// Actions2 is a database-compatible representation of in-game moves
+	// (it is much less verbose when compared with Actions)
+	Actions2     []*GameAction
+	EndCondition int // The values for this are listed in "constants.go"
+	// The index of the player who ended the game, if any
+	// (needed for writing a "game over" terminate action to the database)
+	EndPlayer int
+	// Initialized to -1 and set when the final card is drawn
+	// (to determine when the game should end)
+	EndTurn int
+
+	// ActionCallback is invoked every time that a new action is appended to "Actions"
+	// (e.g. so that the server can send the new action to the players)
+	ActionCallback func(action interface{}) `json:"-"`
+
+	// rng is the random number generator for this game, which is derived from the seed
+	// (every game has its own generator so that games that start at the same time cannot
+	// interfere with each other)
+	rng *rand.Rand
+}
+
+// NewGame creates a game with no players and no cards
+// Afterwards, the caller is expected to use "InitDeck()", "ShuffleDeck()", "AddPlayer()",
+// and "Deal()" to get the game ready for the first action
+// (or use "NewSeededGame()" to do all of these steps at once)
+func NewGame(variant *Variant, options *Options, seed string) *Game {
+	g := &Game{
+		Variant: variant,
+		Options: options,
+
+		Players:             make([]*GamePlayer, 0),
+		Seed:                seed,
+		Deck:                make([]*Card, 0),
+		CardIdentities:      make([]*CardIdentity, 0),
+		Stacks:              make([]int, len(variant.Suits)),
+		PlayStackDirections: make([]int, len(variant.Suits)),
+		ClueTokens:          variant.GetAdjustedClueTokens(options.StartingClues),
+		MaxScore:            len(variant.Suits) * PointsPerSuit,
+		LastClueTypeGiven:   -1,
+		Actions:             make([]interface{}, 0),
+		Actions2:            make([]*GameAction, 0),
+		EndTurn:             -1,
+	}
+
+	// Reverse the stack direction of reversed suits, except on the "Up or Down" variant
+	// that uses the "Undecided" direction.
+	if variant.HasReversedSuits() && !variant.IsUpOrDown() {
+		for i, s := range variant.Suits {
+			if s.Reversed {
+				g.PlayStackDirections[i] = StackDirectionDown
+			} else {
+				g.PlayStackDirections[i] = StackDirectionUp
+			}
+		}
+	}
+
+	return g
+}
+
+// NewSeededGame creates a game, shuffles the deck based on the seed,
+// seats the players in the order given, and deals the starting hands
+func NewSeededGame(variant *Variant, options *Options, seed string, playerNames []string) *Game {
+	g := NewGame(variant, options, seed)
+	g.InitDeck(nil)
+	g.ShuffleDeck()
+	for _, name := range playerNames {
+		g.AddPlayer(name)
+	}
+	g.Deal()
+
+	return g
+}
+
+// AddPlayer seats a new player at the next available index
+func (g *Game) AddPlayer(name string) *GamePlayer {
+	p := &GamePlayer{
+		Name:  name,
+		Index: len(g.Players),
+		Game:  g,
+
+		Hand: make([]*Card, 0),
+	}
+	g.Players = append(g.Players, p)
+
+	return p
+}
+
+// Deal gives every player their starting hand
+func (g *Game) Deal() {
+	handSize := g.GetHandSize()
+	for _, p := range g.Players {
+		for i := 0; i < handSize; i++ {
+			p.DrawCard()
+		}
+	}
+}
+
+// AddAction appends a new action to the action list and invokes the action callback, if any
+func (g *Game) AddAction(action interface{}) {
+	g.Actions = append(g.Actions, action)
+	if g.ActionCallback != nil {
+		g.ActionCallback(action)
+	}
+}
+
+// RestoreReferences recreates the circular references that are not represented in JSON
+func (g *Game) RestoreReferences(variant *Variant, options *Options) {
+	g.Variant = variant
+	g.Options = options
+	for _, p := range g.Players {
+		p.Game = g
+	}
+}
+
+// Rewind creates a new game with the same deck and players and then re-applies the first
+// "numActions" actions of "Actions2" to it (e.g. to undo a move)
+// "characterAssignments" must be the characters that the players had when the game started,
+// since character metadata can change over the course of a game
+// The action callback is not copied, so no messages are sent while the actions are re-applied
+func (g *Game) Rewind(characterAssignments []*CharacterAssignment, numActions int) (*Game, error) {
+	if numActions < 0 || numActions > len(g.Actions2) {
+		return nil, errors.New("cannot rewind to action " + strconv.Itoa(numActions) +
+			" when there are " + strconv.Itoa(len(g.Actions2)) + " actions")
+	}
+
+	g2 := NewGame(g.Variant, g.Options, g.Seed)
+
+	// The deck is already in the right order, so we do not need to shuffle it again
+	g2.InitDeck(g.CardIdentities)
+	for _, p := range g.Players {
+		g2.AddPlayer(p.Name)
+	}
+	if err := g2.SetCharacters(characterAssignments); err != nil {
+		return nil, err
+	}
+	g2.ActivePlayerIndex = g.Options.StartingPlayer
+	g2.Deal()
+
+	for i, a := range g.Actions2[:numActions] {
+		playerIndex := g2.ActivePlayerIndex
+		if a.Type == ActionTypeEndGame {
+			playerIndex = a.Target
+		}
+
+		if err := g2.Apply(playerIndex, &GameAction{
+			Type:   a.Type,
+			Target: a.Target,
+			Value:  a.Value,
+		}); err != nil {
+			return nil, errors.New("failed to re-apply the action at index " + strconv.Itoa(i) +
+				": " + err.Error())
+		}
+	}
+
+	return g2, nil
+}
+
+// seedRNG resets the random number generator of the game to the start of the sequence for the seed
+// It must be called before drawing any random numbers so that the results depend only on the seed
+// (and not on whatever was drawn beforehand); this is what allows historical seeds to keep
+// producing the same decks and characters
+func (g *Game) seedRNG() *rand.Rand {
+	intSeed := seedToInt64(g.Seed)
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(intSeed))
+	} else {
+		g.rng.Seed(intSeed)
+	}
+
+	return g.rng
+}
+
+// CheckEnd examines the game state and sets "EndCondition" to the appropriate value, if any
+func (g *Game) CheckEnd() bool {
+	// Some ending conditions will already be set by the time we get here
+	if g.EndCondition == EndConditionTimeout ||
+		g.EndCondition == EndConditionTerminated ||
+		g.EndCondition == EndConditionIdleTimeout ||
+		g.EndCondition == EndConditionCharacterSoftlock {
+
+		return true
+	}
+
+	// Check for 3 strikes (or the custom strike limit)
+	if g.Strikes >= g.Options.MaxStrikes {
+		g.EndCondition = EndConditionStrikeout
+		return true
+	}
+
+	// In a speedrun, check to see if a perfect score can still be achieved
+	if g.Options.Speedrun && g.MaxScore < g.Variant.MaxScore {
+		g.EndCondition = EndConditionSpeedrunFail
+		return true
+	}
+
+	// In an "All or Nothing" game, check to see if a maximum score can still be reached
+	if g.Options.AllOrNothing && g.MaxScore < g.Variant.MaxScore {
+		g.EndCondition = EndConditionAllOrNothingFail
+		return true
+	}
+
+	// In an "All or Nothing game",
+	// handle the case where a player would have to discard without any cards in their hand
+	if g.Options.AllOrNothing &&
+		len(g.Players[g.ActivePlayerIndex].Hand) == 0 &&
+		g.ClueTokens < g.Variant.GetAdjustedClueTokens(1) {
+
+		g.EndCondition = EndConditionAllOrNothingSoftlock
+		g.EndPlayer = g.Players[g.ActivePlayerIndex].Index
+		return true
+	}
+
+	// Check to see if the final go-around has completed
+	// (which is initiated after the last card is played from the deck)
+	if g.Turn == g.EndTurn {
+		g.EndCondition = EndConditionNormal
+		return true
+	}
+
+	// Check to see if the maximum score has been reached
+	if g.Score == g.MaxScore {
+		g.EndCondition = EndConditionNormal
+		return true
+	}
+
+	// Check to see if there are any cards remaining that can be played on the stacks
+	if g.Variant.HasReversedSuits() {
+		// Searching for the next card is much more complicated if we are playing an "Up or Down"
+		// or "Reversed" variant, so the logic for this is stored in a separate file
+		if !variantReversibleCheckAllDead(g) {
+			return false
+		}
+	} else {
+		for i, stackLen := range g.Stacks {
+			// Search through the deck
+			if stackLen == 5 {
+				continue
+			}
+			neededSuit := i
+			neededRank := stackLen + 1
+			for _, c := range g.Deck {
+				if c.SuitIndex == neededSuit &&
+					c.Rank == neededRank &&
+					!c.Discarded &&
+					!c.CannotBePlayed {
+
+					return false
+				}
+			}
+		}
+	}
+
+	// If we got this far, nothing can be played
+	g.EndCondition = EndConditionNormal
+	return true
+}
+
+/*
+	Miscellaneous functions
+*/
+
+func (g *Game) GetHandSize() int {
+	handSize := g.GetHandSizeForNormalGame()
+	if g.Options.OneExtraCard {
+		handSize++
+	}
+	if g.Options.OneLessCard {
+		handSize--
+	}
+	return handSize
+}
+
+func (g *Game) GetHandSizeForNormalGame() int {
+	numPlayers := len(g.Players)
+	if numPlayers == 2 || numPlayers == 3 {
+		return 5
+	} else if numPlayers == 4 || numPlayers == 5 {
+		return 4
+	} else if numPlayers == 6 || numPlayers == 7 || numPlayers == 8 {
+		return 3
+	}
+
+	// Default to the hand size of a 4-player game
+	return 4
+}
+
+// GetMaxScore calculates what the maximum score is,
+// accounting for stacks that cannot be completed due to discarded cards
+func (g *Game) GetMaxScore() int {
+	// Getting the maximum score is much more complicated if we are playing a
+	// "Reversed" or "Up or Down" variant
+	if g.Variant.HasReversedSuits() {
+		return variantReversibleGetMaxScore(g)
+	}
+
+	maxScore := 0
+	for suit := range g.Stacks {
+		for rank := 1; rank <= 5; rank++ {
+			// Search through the deck to see if all the copies of this card are discarded already
+			total, discarded := g.GetSpecificCardNum(suit, rank)
+			if total > discarded {
+				maxScore++
+			} else {
+				break
+			}
+		}
+	}
+
+	return maxScore
+}
+
+// GetSpecificCardNum returns the total cards in the deck of the specified suit and rank
+// as well as how many of those that have been already discarded
+func (g *Game) GetSpecificCardNum(suitIndex int, rank int) (int, int) {
+	total := 0
+	discarded := 0
+	for _, c := range g.Deck {
+		if c.SuitIndex == suitIndex && c.Rank == rank {
+			total++
+			if c.Discarded {
+				discarded++
+			}
+		}
+	}
+
+	return total, discarded
+}
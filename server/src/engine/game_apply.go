@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	actionFunctions = map[int]func(*GameAction, *Game, *GamePlayer) error{
+		ActionTypePlay:      applyPlay,
+		ActionTypeDiscard:   applyDiscard,
+		ActionTypeColorClue: applyClue,
+		ActionTypeRankClue:  applyClue,
+		ActionTypeEndGame:   applyEndGame,
+	}
+)
+
+// Apply performs an action on behalf of the player at the specified index
+// If the action is not legal, the game state is left unchanged and an error is returned
+// (the text of the error is suitable to be shown directly to the player)
+//
+// For actions of type "ActionTypeEndGame", the target is the index of the player who ended the
+// game and the value is the end condition (see "EndCondition" in "constants.go")
+func (g *Game) Apply(playerIndex int, a *GameAction) error {
+	// Validate that the game is not already over
+	if g.EndCondition > EndConditionInProgress {
+		return errors.New("The game is already over, so you cannot perform an action.")
+	}
+
+	// Validate that the player exists
+	if playerIndex < 0 || playerIndex > len(g.Players)-1 {
+		return errors.New("That is an invalid player index.")
+	}
+	p := g.Players[playerIndex]
+
+	if a.Type != ActionTypeEndGame {
+		// Validate that it is this player's turn
+		if g.ActivePlayerIndex != playerIndex {
+			return errors.New("It is not your turn, so you cannot perform an action.")
+		}
+
+		// Validate that a player is not doing an illegal action for their character
+		if err := characterValidateAction(a, g, p); err != nil {
+			return err
+		}
+		if err := characterValidateSecondAction(a, g, p); err != nil {
+			return err
+		}
+	}
+
+	// Do different tasks depending on the action
+	if actionFunction, ok := actionFunctions[a.Type]; ok {
+		if err := actionFunction(a, g, p); err != nil {
+			return err
+		}
+	} else {
+		return errors.New("That is not a valid action type.")
+	}
+
+	// Do post-action tasks
+	characterPostAction(a, g, p)
+
+	// Record the current status
+	g.AddAction(ActionStatus{
+		Type:     "status",
+		Clues:    g.ClueTokens,
+		Score:    g.Score,
+		MaxScore: g.MaxScore,
+	})
+
+	// If a player has just taken their final turn,
+	// mark all of the cards in their hand as not able to be played
+	// (but don't do this if we are in an end game that has a custom amount of turns)
+	if g.EndTurn != -1 &&
+		g.EndTurn != g.Turn+len(g.Players)+1 {
+
+		for _, c := range p.Hand {
+			c.CannotBePlayed = true
+		}
+	}
+
+	// Increment the turn
+	// (but don't increment it if we are on a characters that take two turns in a row)
+	if !characterNeedsToTakeSecondTurn(a, g, p) {
+		g.Turn++
+		if g.TurnsInverted {
+			// In Golang, "%" will give the remainder and not the modulus,
+			// so we need to ensure that the result is not negative or we will get a
+			// "index out of range" error
+			g.ActivePlayerIndex += len(g.Players)
+			g.ActivePlayerIndex = (g.ActivePlayerIndex - 1) % len(g.Players)
+		} else {
+			g.ActivePlayerIndex = (g.ActivePlayerIndex + 1) % len(g.Players)
+		}
+	}
+	np := g.Players[g.ActivePlayerIndex] // The next player
+
+	// Check for character-related softlocks
+	// (we will set the strikes to 3 if there is a softlock)
+	characterCheckSoftlock(g, np)
+
+	// Check for end game states
+	if g.CheckEnd() {
+		// Append a game over action
+		g.AddAction(ActionGameOver{
+			Type:         "gameOver",
+			EndCondition: g.EndCondition,
+			PlayerIndex:  g.EndPlayer,
+		})
+	}
+
+	// Record the new turn
+	currentPlayerIndex := g.ActivePlayerIndex
+	if g.EndCondition > EndConditionInProgress {
+		currentPlayerIndex = -1
+	}
+	g.AddAction(ActionTurn{
+		Type:               "turn",
+		Num:                g.Turn,
+		CurrentPlayerIndex: currentPlayerIndex,
+	})
+
+	return nil
+}
+
+func applyPlay(a *GameAction, g *Game, p *GamePlayer) error {
+	// Validate "Detrimental Character Assignment" restrictions
+	if err := characterCheckPlay(a, g, p); err != nil {
+		return err
+	}
+
+	// Validate deck plays
+	if g.Options.DeckPlays &&
+		g.DeckIndex == len(g.Deck)-1 && // There is 1 card left in the deck
+		a.Target == g.DeckIndex { // The target is the last card left in the deck
+
+		p.PlayDeck()
+		return nil
+	}
+
+	// Validate that the card is in their hand
+	if !p.InHand(a.Target) {
+		return errors.New("You cannot play a card that is not in your hand.")
+	}
+
+	c := p.RemoveCard(a.Target)
+	p.PlayCard(c)
+	p.DrawCard()
+
+	return nil
+}
+
+func applyDiscard(a *GameAction, g *Game, p *GamePlayer) error {
+	// Validate that the card is in their hand
+	if !p.InHand(a.Target) {
+		return errors.New("You cannot play a card that is not in your hand.")
+	}
+
+	// Validate that the team is not at the maximum amount of clues
+	if g.Variant.AtMaxClueTokens(g.ClueTokens, g.Options.MaxClues) {
+		return errors.New("You cannot discard while the team has " + strconv.Itoa(g.Options.MaxClues) +
+			" clues.")
+	}
+
+	// Validate "Detrimental Character Assignment" restrictions
+	if err := characterCheckDiscard(g, p); err != nil {
+		return err
+	}
+
+	g.ClueTokens++
+	c := p.RemoveCard(a.Target)
+	p.DiscardCard(c)
+	p.DrawCard()
+
+	return nil
+}
+
+func applyClue(a *GameAction, g *Game, p *GamePlayer) error {
+	// Validate that the target of the clue is sane
+	if a.Target < 0 || a.Target > len(g.Players)-1 {
+		return errors.New("That is an invalid clue target.")
+	}
+
+	// Validate that the player is not giving a clue to themselves
+	if g.ActivePlayerIndex == a.Target {
+		return errors.New("You cannot give a clue to yourself.")
+	}
+
+	// Validate that there are clues available to use
+	if g.ClueTokens < g.Variant.GetAdjustedClueTokens(1) {
+		return errors.New("You need at least 1 clue token available in order to give a clue.")
+	}
+
+	// Convert the incoming data to a clue object
+	clue := NewClue(a)
+
+	// Validate the clue value
+	if clue.Type == ClueTypeColor {
+		if clue.Value < 0 || clue.Value > len(g.Variant.ClueColors)-1 {
+			return errors.New("You cannot give a color clue with a value of " +
+				"\"" + strconv.Itoa(clue.Value) + "\".")
+		}
+	} else if clue.Type == ClueTypeRank {
+		if !intInSlice(clue.Value, g.Variant.ClueRanks) {
+			return errors.New("You cannot give a rank clue with a value of " +
+				"\"" + strconv.Itoa(clue.Value) + "\".")
+		}
+	} else {
+		return errors.New("The clue type of " + strconv.Itoa(clue.Type) + " is invalid..")
+	}
+
+	// Validate special variant restrictions
+	if g.Variant.IsAlternatingClues() && clue.Type == g.LastClueTypeGiven {
+		return errors.New("You cannot give two clues of the same time in a row in this variant.")
+	}
+
+	// Validate "Detrimental Character Assignment" restrictions
+	if err := characterValidateClue(a, g, p); err != nil {
+		return err
+	}
+
+	// Validate that the clue touches at least one card
+	p2 := g.Players[a.Target] // The target of the clue
+	touchedAtLeastOneCard := false
+	for _, c := range p2.Hand {
+		// Prevent characters from cluing cards that they are not supposed to see
+		if !characterSeesCard(g, p, p2, c.Order) {
+			continue
+		}
+
+		if g.Variant.IsCardTouched(clue, c) {
+			touchedAtLeastOneCard = true
+			break
+		}
+	}
+	if !touchedAtLeastOneCard &&
+		// Make an exception if they have the optional setting for "Empty Clues" turned on
+		!g.Options.EmptyClues &&
+		// Make an exception for variants where color clues are always allowed
+		(!g.Variant.ColorCluesTouchNothing || clue.Type != ClueTypeColor) &&
+		// Make an exception for variants where rank clues are always allowed
+		(!g.Variant.RankCluesTouchNothing || clue.Type != ClueTypeRank) {
+
+		return errors.New("You cannot give a clue that touches 0 cards in the hand.")
+	}
+
+	p.GiveClue(a)
+
+	return nil
+}
+
+func applyEndGame(a *GameAction, g *Game, p *GamePlayer) error {
+	// An "endGame" action is a special action type sent by the server to itself
+	// The value will correspond to the end condition (see "endCondition" in "constants.go")
+	// The target will correspond to the index of the player who ended the game
+
+	// Validate the value
+	if a.Value != EndConditionTimeout &&
+		a.Value != EndConditionTerminated &&
+		a.Value != EndConditionIdleTimeout {
+
+		return errors.New("That is not a valid value for the end game action.")
+	}
+
+	// Mark that the game should be ended
+	g.EndCondition = a.Value
+	g.EndPlayer = a.Target
+
+	// Insert an "end game" action
+	endGameAction := &GameAction{
+		Type:   ActionTypeEndGame,
+		Target: g.EndPlayer,
+		Value:  g.EndCondition,
+	}
+	if g.EndCondition == EndConditionIdleTimeout {
+		endGameAction.Target = -1
+	}
+	g.Actions2 = append(g.Actions2, endGameAction)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"testing"
+)
+
+// testNewCustomGame creates a 2-player "No Variant" game where the deck is in the given order
+// (the first 5 cards go to the first player and the next 5 cards go to the second player)
+func testNewCustomGame(t *testing.T, options *Options, deck []*CardIdentity) *Game {
+	t.Helper()
+
+	g := NewGame(testGetVariant(t, "No Variant"), options, "")
+	g.InitDeck(deck)
+	g.AddPlayer("Alice")
+	g.AddPlayer("Bob")
+	g.Deal()
+
+	return g
+}
+
+// testRepeatCard makes a deck that consists of copies of a single card
+func testRepeatCard(suitIndex int, rank int, amount int) []*CardIdentity {
+	deck := make([]*CardIdentity, 0)
+	for i := 0; i < amount; i++ {
+		deck = append(deck, &CardIdentity{
+			SuitIndex: suitIndex,
+			Rank:      rank,
+		})
+	}
+	return deck
+}
+
+func testApplyToFirstCard(t *testing.T, g *Game, actionType int) error {
+	t.Helper()
+
+	p := g.Players[g.ActivePlayerIndex]
+	return g.Apply(p.Index, &GameAction{
+		Type:   actionType,
+		Target: p.Hand[0].Order,
+	})
+}
+
+func TestStartingClues(t *testing.T) {
+	options := testNewOptions(2, "No Variant")
+	options.StartingClues = 2
+	options.MaxClues = 3
+	g := testNewCustomGame(t, options, testRepeatCard(0, 1, 20))
+
+	if g.ClueTokens != 2 {
+		t.Fatalf("There are %d clue tokens, expected 2.", g.ClueTokens)
+	}
+
+	// The first discard brings the team to the maximum
+	if err := testApplyToFirstCard(t, g, ActionTypeDiscard); err != nil {
+		t.Fatal("Failed to discard:", err)
+	}
+	if g.ClueTokens != 3 {
+		t.Fatalf("There are %d clue tokens, expected 3.", g.ClueTokens)
+	}
+
+	// Discarding is not allowed at the maximum of the table
+	if err := testApplyToFirstCard(t, g, ActionTypeDiscard); err == nil {
+		t.Fatal("It was possible to discard at the maximum amount of clues.")
+	}
+}
+
+func TestMaxCluesAboveDefault(t *testing.T) {
+	options := testNewOptions(2, "No Variant")
+	options.MaxClues = 12
+	g := testNewCustomGame(t, options, testRepeatCard(0, 1, 20))
+
+	// The team starts with the default amount of clues but can discard past it
+	for i := 0; i < 4; i++ {
+		if err := testApplyToFirstCard(t, g, ActionTypeDiscard); err != nil {
+			t.Fatalf("Failed to discard at %d clues: %v", g.ClueTokens, err)
+		}
+	}
+	if g.ClueTokens != 12 {
+		t.Fatalf("There are %d clue tokens, expected 12.", g.ClueTokens)
+	}
+	if err := testApplyToFirstCard(t, g, ActionTypeDiscard); err == nil {
+		t.Fatal("It was possible to discard at the maximum amount of clues.")
+	}
+}
+
+func TestCompletingAStackGainsAClueUpToTheMaximum(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		maxClues int
+		expected int
+	}{
+		{"default", MaxClueNum, MaxClueNum},
+		{"higher maximum", 12, MaxClueNum + 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			options := testNewOptions(2, "No Variant")
+			options.MaxClues = test.maxClues
+
+			// Alice has the red 1, 3, and 5 and Bob has the red 2 and 4
+			deck := []*CardIdentity{
+				{SuitIndex: 0, Rank: 1},
+				{SuitIndex: 0, Rank: 3},
+				{SuitIndex: 0, Rank: 5},
+				{SuitIndex: 1, Rank: 1},
+				{SuitIndex: 1, Rank: 1},
+				{SuitIndex: 0, Rank: 2},
+				{SuitIndex: 0, Rank: 4},
+				{SuitIndex: 1, Rank: 1},
+				{SuitIndex: 1, Rank: 1},
+				{SuitIndex: 1, Rank: 1},
+			}
+			deck = append(deck, testRepeatCard(2, 1, 10)...)
+			g := testNewCustomGame(t, options, deck)
+
+			for i := 0; i < 5; i++ {
+				if err := testApplyToFirstCard(t, g, ActionTypePlay); err != nil {
+					t.Fatalf("Failed to play on turn %d: %v", g.Turn, err)
+				}
+			}
+			if g.Stacks[0] != 5 {
+				t.Fatalf("The red stack is at %d, expected 5.", g.Stacks[0])
+			}
+			if g.ClueTokens != test.expected {
+				t.Errorf("There are %d clue tokens, expected %d.", g.ClueTokens, test.expected)
+			}
+		})
+	}
+}
+
+func TestMaxStrikes(t *testing.T) {
+	for _, maxStrikes := range []int{1, MaxStrikeNum, MaxCustomStrikeNum} {
+		options := testNewOptions(2, "No Variant")
+		options.MaxStrikes = maxStrikes
+
+		// Every card that is drawn is a red 5, so every play is a misplay
+		// (the red 1 at the bottom of the deck keeps the game from ending for other reasons)
+		deck := testRepeatCard(0, 5, 20)
+		deck = append(deck, testRepeatCard(0, 1, 1)...)
+		g := testNewCustomGame(t, options, deck)
+
+		for i := 1; i <= maxStrikes; i++ {
+			if err := testApplyToFirstCard(t, g, ActionTypePlay); err != nil {
+				t.Fatalf("Failed to play with %d strikes: %v", g.Strikes, err)
+			}
+			if i < maxStrikes && g.EndCondition != EndConditionInProgress {
+				t.Fatalf("The game ended after %d strikes with a limit of %d.", i, maxStrikes)
+			}
+		}
+
+		if g.EndCondition != EndConditionStrikeout {
+			t.Errorf("The end condition is %d after %d strikes, expected %d.",
+				g.EndCondition, g.Strikes, EndConditionStrikeout)
+		}
+	}
+}
@@ -1,20 +1,14 @@
-package main
+package engine
 
-import (
-	"math/rand"
-)
-
-func (g *Game) InitDeck() {
-	// Local variables
-	variant := variants[g.Options.VariantName]
+// InitDeck adds every card to the deck (in an unshuffled order)
+// If a custom deck is provided, then the cards will be added exactly as specified
+func (g *Game) InitDeck(customDeck []*CardIdentity) {
+	defer g.markCardOrders()
 
 	// If a custom deck was provided along with the game options,
 	// then we can simply add every card to the deck as specified
-	if g.ExtraOptions.CustomDeck != nil &&
-		len(g.ExtraOptions.CustomDeck) != 0 &&
-		g.ExtraOptions.CustomSeed == "" { // Custom seeds override custom decks
-
-		for _, card := range g.ExtraOptions.CustomDeck {
+	if len(customDeck) != 0 {
+		for _, card := range customDeck {
 			g.Deck = append(g.Deck, NewCard(card.SuitIndex, card.Rank))
 			g.CardIdentities = append(g.CardIdentities, &CardIdentity{
 				SuitIndex: card.SuitIndex,
@@ -26,10 +20,10 @@ func (g *Game) InitDeck() {
 
 	// Suits are represented as a slice of integers from 0 to the number of suits - 1
 	// (e.g. [0, 1, 2, 3, 4] for a "No Variant" game)
-	for suitIndex, suit := range variant.Suits {
+	for suitIndex, suit := range g.Variant.Suits {
 		// Ranks are represented as a slice of integers
 		// (e.g. [1, 2, 3, 4, 5] for a "No Variant" game)
-		for _, rank := range variant.Ranks {
+		for _, rank := range g.Variant.Ranks {
 			// In a normal suit, there are:
 			// - three 1's
 			// - two 2's
@@ -39,7 +33,7 @@ func (g *Game) InitDeck() {
 			var amountToAdd int
 			if rank == 1 {
 				amountToAdd = 3
-				if variant.IsUpOrDown() || suit.Reversed {
+				if g.Variant.IsUpOrDown() || suit.Reversed {
 					amountToAdd = 1
 				}
 			} else if rank == 5 {
@@ -68,11 +62,41 @@ func (g *Game) InitDeck() {
 	}
 }
 
+// ShuffleDeck shuffles the deck based on the game's seed
+// (the same seed will always result in the same deck)
 func (g *Game) ShuffleDeck() {
+	rng := g.seedRNG()
+
 	// From: https://stackoverflow.com/questions/12264789/shuffle-array-in-go
 	for i := range g.Deck {
-		j := rand.Intn(i + 1)
+		j := rng.Intn(i + 1)
 		g.Deck[i], g.Deck[j] = g.Deck[j], g.Deck[i]
 		g.CardIdentities[i], g.CardIdentities[j] = g.CardIdentities[j], g.CardIdentities[i]
 	}
+
+	g.markCardOrders()
+}
+
+// ShufflePlayers shuffles the order of the players based on the game's seed
+// (the same seed will always result in the same order)
+// "swap" swaps the players at the two indexes, in the same way as the "rand.Shuffle()" function
+// This must be called after "ShuffleDeck()", since it continues from where the deck shuffle left
+// off in the random number sequence
+func (g *Game) ShufflePlayers(numPlayers int, swap func(i, j int)) {
+	// Custom decks are not shuffled
+	if g.rng == nil {
+		g.seedRNG()
+	}
+
+	for i := 0; i < numPlayers; i++ {
+		j := g.rng.Intn(i + 1)
+		swap(i, j)
+	}
+}
+
+// markCardOrders marks the order of all of the cards in the deck
+func (g *Game) markCardOrders() {
+	for i, c := range g.Deck {
+		c.Order = i
+	}
 }
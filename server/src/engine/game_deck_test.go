@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The decks that the server dealt for these seeds before every game had its own random number
+// generator (when the global generator was seeded with "rand.Seed()")
+// Each card is written as the suit index followed by the rank
+// If these ever change, then all of the existing seeds will produce different decks
+var testSeedDecks = map[string]string{
+	"p2v0s1": "35 44 33 04 21 33 31 01 22 24 02 32 22 41 02 01 31 13 14 01 14 24 42 43 41 " +
+		"04 25 12 41 11 15 11 23 05 43 12 31 23 13 34 11 03 34 03 21 32 21 44 45 42",
+	"p4v0s42": "42 34 21 03 24 14 33 13 02 45 23 22 32 25 14 24 13 35 12 33 11 01 23 05 04 " +
+		"01 42 41 41 34 31 44 11 43 03 02 21 43 11 31 12 31 44 22 32 01 21 41 15 04",
+	"p5v0sd20201018": "03 22 21 44 41 11 04 41 13 33 02 31 32 31 42 21 22 24 43 13 01 14 11 23 " +
+		"34 25 34 12 04 24 14 05 02 31 01 11 42 03 01 44 21 41 45 43 15 32 23 35 12 33",
+	"JSON": "22 31 12 41 21 35 05 22 43 24 03 44 42 32 13 44 25 04 31 33 21 11 01 34 33 23 " +
+		"11 41 31 01 34 04 24 12 23 15 11 02 01 43 42 32 41 14 45 13 03 14 21 02",
+}
+
+func testGetDeckString(g *Game) string {
+	cards := make([]string, 0)
+	for _, c := range g.Deck {
+		cards = append(cards, strconv.Itoa(c.SuitIndex)+strconv.Itoa(c.Rank))
+	}
+	return strings.Join(cards, " ")
+}
+
+func TestShuffleDeckMatchesHistoricalSeeds(t *testing.T) {
+	variant := testGetVariant(t, "No Variant")
+	for seed, expected := range testSeedDecks {
+		g := NewGame(variant, testNewOptions(2, variant.Name), seed)
+		g.InitDeck(nil)
+		g.ShuffleDeck()
+
+		if deck := testGetDeckString(g); deck != expected {
+			t.Errorf("Seed \"%s\" was shuffled into:\n%s\nexpected:\n%s", seed, deck, expected)
+		}
+	}
+}
+
+func TestShuffleDeckDoesNotDependOnOtherGames(t *testing.T) {
+	variant := testGetVariant(t, "No Variant")
+	seed := "p2v0s1"
+
+	// Shuffling other decks in between must not change the result
+	// (this was not the case when every game shared the global random number generator)
+	g1 := NewGame(variant, testNewOptions(2, variant.Name), seed)
+	g1.InitDeck(nil)
+	g2 := NewGame(variant, testNewOptions(2, variant.Name), "p2v0s2")
+	g2.InitDeck(nil)
+	g2.ShuffleDeck()
+	g1.ShuffleDeck()
+	g2.ShuffleDeck()
+
+	if deck := testGetDeckString(g1); deck != testSeedDecks[seed] {
+		t.Errorf("Seed \"%s\" was shuffled into:\n%s\nexpected:\n%s", seed, deck, testSeedDecks[seed])
+	}
+}
+
+func TestSeedToInt64(t *testing.T) {
+	// This is the value that the old "setSeed()" function passed to "rand.Seed()"
+	if v := seedToInt64("p2v0s1"); v != 6498259397102791896 {
+		t.Errorf("Seed \"p2v0s1\" was converted to %d, expected 6498259397102791896.", v)
+	}
+}
+
+// testStartGame shuffles the deck and the players and then assigns the characters in the same way
+// that the server does when a game starts
+func testStartGame(t *testing.T, seed string, playerNames []string) *Game {
+	t.Helper()
+
+	var characters []*Character
+	if v, err := LoadCharacters(path.Join(testDataPath, "characters.json")); err != nil {
+		t.Fatal("Failed to load the characters:", err)
+	} else {
+		characters = v
+	}
+
+	variant := testGetVariant(t, "No Variant")
+	options := testNewOptions(len(playerNames), variant.Name)
+	options.DetrimentalCharacters = true
+	g := NewGame(variant, options, seed)
+	g.InitDeck(nil)
+	g.ShuffleDeck()
+
+	names := make([]string, len(playerNames))
+	copy(names, playerNames)
+	g.ShufflePlayers(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+	for _, name := range names {
+		g.AddPlayer(name)
+	}
+	g.GenerateCharacters(characters)
+
+	return g
+}
+
+func testGetPlayersString(g *Game) string {
+	players := make([]string, 0)
+	for _, p := range g.Players {
+		players = append(players, p.Name+" ("+p.Character+" "+
+			strconv.Itoa(p.CharacterMetadata)+")")
+	}
+	return strings.Join(players, ", ")
+}
+
+func TestShufflePlayersMatchesHistoricalSeeds(t *testing.T) {
+	// This is the order that the server used when the players were shuffled with the global random
+	// number generator right after the deck
+	g := testStartGame(t, "p5v0sd20201018", []string{"Alice", "Bob", "Cathy", "Donald", "Emily"})
+	expected := []string{"Donald", "Cathy", "Bob", "Alice", "Emily"}
+	for i, p := range g.Players {
+		if p.Name != expected[i] {
+			t.Fatalf("The players were shuffled into %s, expected %v.", testGetPlayersString(g),
+				expected)
+		}
+	}
+}
+
+func TestShufflePlayersIsBasedOnTheSeed(t *testing.T) {
+	seed := "p5v0sd20201018"
+	playerNames := []string{"Alice", "Bob", "Cathy", "Donald", "Emily"}
+	g1 := testStartGame(t, seed, playerNames)
+
+	// Starting other games in between must not change the result
+	testStartGame(t, "p5v0s1", playerNames)
+	g2 := testStartGame(t, seed, playerNames)
+
+	players1 := testGetPlayersString(g1)
+	players2 := testGetPlayersString(g2)
+	if players1 != players2 {
+		t.Errorf("Seed \"%s\" resulted in:\n%s\nand then:\n%s", seed, players1, players2)
+	}
+}
@@ -1,10 +1,6 @@
 // GamePlayer subroutines
 
-package main
-
-import (
-	"time"
-)
+package engine
 
 // GetChopIndex gets the index of the oldest (right-most) unclued card
 // (used for the "Card Cycling" feature)
@@ -20,17 +16,6 @@ func (p *GamePlayer) GetChopIndex() int {
 	return len(p.Hand) - 1
 }
 
-func (p *GamePlayer) InitTime(options *Options) {
-	if options.Timed {
-		// In timed games, each player starts with the base time specified in the options
-		p.Time = time.Duration(options.TimeBase) * time.Second
-	} else {
-		// In non-timed games, each player starts with 0 "time left"
-		// It will decrement into negative numbers to show how much time they are taking
-		p.Time = time.Duration(0)
-	}
-}
-
 // FindCardsTouchedByClue returns a slice of card orders
 // (in this context, "orders" are the card positions in the deck, not in the hand)
 func (p *GamePlayer) FindCardsTouchedByClue(clue Clue) []int {
@@ -39,7 +24,7 @@ func (p *GamePlayer) FindCardsTouchedByClue(clue Clue) []int {
 
 	list := make([]int, 0)
 	for _, c := range p.Hand {
-		if variantIsCardTouched(g.Options.VariantName, clue, c) {
+		if g.Variant.IsCardTouched(clue, c) {
 			list = append(list, c.Order)
 		}
 	}
@@ -50,13 +35,13 @@ func (p *GamePlayer) FindCardsTouchedByClue(clue Clue) []int {
 func (p *GamePlayer) IsFirstCardTouchedByClue(clue Clue) bool {
 	g := p.Game
 	card := p.Hand[len(p.Hand)-1]
-	return variantIsCardTouched(g.Options.VariantName, clue, card)
+	return g.Variant.IsCardTouched(clue, card)
 }
 
 func (p *GamePlayer) IsLastCardTouchedByClue(clue Clue) bool {
 	g := p.Game
 	card := p.Hand[0]
-	return variantIsCardTouched(g.Options.VariantName, clue, card)
+	return g.Variant.IsCardTouched(clue, card)
 }
 
 func (p *GamePlayer) InHand(order int) bool {
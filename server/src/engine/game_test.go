@@ -0,0 +1,308 @@
+package engine
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+var (
+	// The data files are shared with the server and the client
+	testDataPath = path.Join("..", "..", "..", "data")
+	// Games that were exported from the website (which are also used by the client tests)
+	testGamesPath = path.Join("..", "..", "..", "client", "test_data")
+
+	testVariants map[string]*Variant
+)
+
+// testGameJSON is the subset of the "/export" format that is needed to replay a game
+type testGameJSON struct {
+	Players []string        `json:"players"`
+	Deck    []*CardIdentity `json:"deck"`
+	Actions []*GameAction   `json:"actions"`
+	Options *struct {
+		StartingPlayer int    `json:"startingPlayer"`
+		Variant        string `json:"variant"`
+		DeckPlays      bool   `json:"deckPlays"`
+		EmptyClues     bool   `json:"emptyClues"`
+	} `json:"options"`
+}
+
+func testGetVariant(t *testing.T, name string) *Variant {
+	t.Helper()
+
+	if testVariants == nil {
+		var colors map[string]*Color
+		if v, err := LoadColors(path.Join(testDataPath, "colors.json")); err != nil {
+			t.Fatal("Failed to load the colors:", err)
+		} else {
+			colors = v
+		}
+
+		var suits map[string]*Suit
+		if v, err := LoadSuits(path.Join(testDataPath, "suits.json"), colors); err != nil {
+			t.Fatal("Failed to load the suits:", err)
+		} else {
+			suits = v
+		}
+
+		var variantList []*Variant
+		variantsPath := path.Join(testDataPath, "variants.json")
+		if v, err := LoadVariants(variantsPath, suits, colors); err != nil {
+			t.Fatal("Failed to load the variants:", err)
+		} else {
+			variantList = v
+		}
+
+		testVariants = make(map[string]*Variant)
+		for _, variant := range variantList {
+			testVariants[variant.Name] = variant
+		}
+	}
+
+	variant, ok := testVariants[name]
+	if !ok {
+		t.Fatal("The \"" + name + "\" variant does not exist.")
+	}
+	return variant
+}
+
+func testNewOptions(numPlayers int, variantName string) *Options {
+	options := &Options{
+		NumPlayers:  numPlayers,
+		VariantName: variantName,
+	}
+	options.SetDefaultLimits()
+	return options
+}
+
+// testLoadGame creates a game from an exported game in the same way that "verifyReplay()" does,
+// applying the first "numActions" actions (or all of them if "numActions" is -1)
+func testLoadGame(t *testing.T, fileName string, numActions int) *Game {
+	t.Helper()
+
+	var gameJSON testGameJSON
+	if fileContents, err := ioutil.ReadFile(path.Join(testGamesPath, fileName)); err != nil {
+		t.Fatal("Failed to read the game:", err)
+	} else if err := json.Unmarshal(fileContents, &gameJSON); err != nil {
+		t.Fatal("Failed to unmarshal the game:", err)
+	}
+
+	options := testNewOptions(len(gameJSON.Players), "No Variant")
+	if gameJSON.Options != nil {
+		if gameJSON.Options.Variant != "" {
+			options.VariantName = gameJSON.Options.Variant
+		}
+		options.StartingPlayer = gameJSON.Options.StartingPlayer
+		options.DeckPlays = gameJSON.Options.DeckPlays
+		options.EmptyClues = gameJSON.Options.EmptyClues
+	}
+
+	g := NewGame(testGetVariant(t, options.VariantName), options, "")
+	g.InitDeck(gameJSON.Deck)
+	for _, name := range gameJSON.Players {
+		g.AddPlayer(name)
+	}
+	g.ActivePlayerIndex = options.StartingPlayer
+	g.Deal()
+
+	if numActions == -1 {
+		numActions = len(gameJSON.Actions)
+	}
+	for i, a := range gameJSON.Actions[:numActions] {
+		playerIndex := g.ActivePlayerIndex
+		if a.Type == ActionTypeEndGame {
+			playerIndex = a.Target
+		}
+		if err := g.Apply(playerIndex, a); err != nil {
+			t.Fatalf("Failed to apply the action at index %d: %v", i, err)
+		}
+	}
+
+	return g
+}
+
+func testGetHandOrders(g *Game) [][]int {
+	hands := make([][]int, 0)
+	for _, p := range g.Players {
+		hand := make([]int, 0)
+		for _, c := range p.Hand {
+			hand = append(hand, c.Order)
+		}
+		hands = append(hands, hand)
+	}
+	return hands
+}
+
+func testCheckHands(t *testing.T, g *Game, expected [][]int) {
+	t.Helper()
+
+	hands := testGetHandOrders(g)
+	if len(hands) != len(expected) {
+		t.Fatalf("There are %d hands, expected %d.", len(hands), len(expected))
+	}
+	for i := range expected {
+		if len(hands[i]) != len(expected[i]) {
+			t.Errorf("Hand %d is %v, expected %v.", i, hands[i], expected[i])
+			continue
+		}
+		for j := range expected[i] {
+			if hands[i][j] != expected[i][j] {
+				t.Errorf("Hand %d is %v, expected %v.", i, hands[i], expected[i])
+				break
+			}
+		}
+	}
+}
+
+// The expected values below are the same ones that the client checks in "integration.test.ts"
+
+func TestReplayUpOrDownTurn5(t *testing.T) {
+	g := testLoadGame(t, "up_or_down.json", 4)
+
+	testCheckHands(t, g, [][]int{
+		{0, 1, 2, 3},
+		{4, 5, 6, 7},
+		{8, 9, 11, 16},
+		{12, 13, 15, 17},
+	})
+	if g.Turn != 4 {
+		t.Errorf("The turn is %d, expected 4.", g.Turn)
+	}
+	if g.ActivePlayerIndex != 0 {
+		t.Errorf("The active player is %d, expected 0.", g.ActivePlayerIndex)
+	}
+	if g.Score != 2 {
+		t.Errorf("The score is %d, expected 2.", g.Score)
+	}
+	if g.ClueTokens != 6 {
+		t.Errorf("There are %d clue tokens, expected 6.", g.ClueTokens)
+	}
+
+	expectedDirections := []int{
+		StackDirectionUndecided,
+		StackDirectionDown,
+		StackDirectionUndecided,
+		StackDirectionDown,
+		StackDirectionUndecided,
+	}
+	for i, direction := range expectedDirections {
+		if g.PlayStackDirections[i] != direction {
+			t.Errorf("The direction of stack %d is %d, expected %d.",
+				i, g.PlayStackDirections[i], direction)
+		}
+	}
+}
+
+func TestReplayUpOrDownFinal(t *testing.T) {
+	g := testLoadGame(t, "up_or_down.json", -1)
+
+	testCheckHands(t, g, [][]int{
+		{27, 32, 35},
+		{22, 30, 43},
+		{31, 36, 41, 44},
+		{13, 23, 39},
+	})
+	if g.EndCondition != EndConditionNormal {
+		t.Errorf("The end condition is %d, expected %d.", g.EndCondition, EndConditionNormal)
+	}
+	if g.Turn != 50 {
+		t.Errorf("The turn is %d, expected 50.", g.Turn)
+	}
+	if g.Score != 24 {
+		t.Errorf("The score is %d, expected 24.", g.Score)
+	}
+	if g.ClueTokens != 2 {
+		t.Errorf("There are %d clue tokens, expected 2.", g.ClueTokens)
+	}
+}
+
+func TestReplayRainbowOnesAndPinkFinal(t *testing.T) {
+	g := testLoadGame(t, "rainbow-ones_and_pink.json", -1)
+
+	if g.EndCondition != EndConditionNormal {
+		t.Errorf("The end condition is %d, expected %d.", g.EndCondition, EndConditionNormal)
+	}
+	if g.Turn != 53 {
+		t.Errorf("The turn is %d, expected 53.", g.Turn)
+	}
+	if g.Score != 25 {
+		t.Errorf("The score is %d, expected 25.", g.Score)
+	}
+	if g.ClueTokens != 8 {
+		t.Errorf("There are %d clue tokens, expected 8.", g.ClueTokens)
+	}
+}
+
+func TestReplayAllGames(t *testing.T) {
+	// Every exported game must replay without any illegal actions
+	for _, fileName := range []string{
+		"no_variant.json",
+		"omni-ones_and_white.json",
+		"rainbow-ones_and_pink.json",
+		"up_or_down.json",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			g := testLoadGame(t, fileName, -1)
+			if g.EndCondition == EndConditionInProgress {
+				t.Error("The game did not end.")
+			}
+			if g.Score > g.MaxScore {
+				t.Errorf("The score of %d is higher than the max score of %d.", g.Score, g.MaxScore)
+			}
+		})
+	}
+}
+
+func TestApplyRejectsIllegalActions(t *testing.T) {
+	options := testNewOptions(3, "No Variant")
+	g := NewSeededGame(testGetVariant(t, "No Variant"), options, "p3v0s1", []string{
+		"Alice",
+		"Bob",
+		"Cathy",
+	})
+
+	// It is not Bob's turn
+	bobCard := g.Players[1].Hand[0].Order
+	if err := g.Apply(1, &GameAction{Type: ActionTypeDiscard, Target: bobCard}); err == nil {
+		t.Error("A player was able to act out of turn.")
+	}
+
+	// Players cannot discard at the maximum amount of clues
+	aliceCard := g.Players[0].Hand[0].Order
+	if err := g.Apply(0, &GameAction{Type: ActionTypeDiscard, Target: aliceCard}); err == nil {
+		t.Error("A player was able to discard at the maximum amount of clues.")
+	}
+
+	// Players cannot clue themselves
+	if err := g.Apply(0, &GameAction{Type: ActionTypeRankClue, Target: 0, Value: 1}); err == nil {
+		t.Error("A player was able to clue themselves.")
+	}
+
+	// Failed actions must not change the game state
+	if g.Turn != 0 || g.ClueTokens != MaxClueNum || len(g.Actions2) != 0 {
+		t.Error("A failed action changed the game state.")
+	}
+}
+
+func TestRewind(t *testing.T) {
+	g := testLoadGame(t, "up_or_down.json", 10)
+	full := testLoadGame(t, "up_or_down.json", -1)
+
+	var rewound *Game
+	if v, err := full.Rewind(nil, 10); err != nil {
+		t.Fatal("Failed to rewind:", err)
+	} else {
+		rewound = v
+	}
+
+	testCheckHands(t, rewound, testGetHandOrders(g))
+	if rewound.Turn != g.Turn || rewound.Score != g.Score || rewound.ClueTokens != g.ClueTokens {
+		t.Error("The rewound game does not match the game after the same amount of actions.")
+	}
+
+	if _, err := full.Rewind(nil, len(full.Actions2)+1); err == nil {
+		t.Error("It was possible to rewind past the end of the game.")
+	}
+}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"hash/crc64"
+)
+
+// seedToInt64 converts a seed string to a number that can seed a random number generator
+// Golang's "rand.NewSource()" function takes an int64, so we need to convert a string to an int64
+// We use the CRC64 hash function to do this
+// Also note that seeding with negative numbers will not work
+// This must never change, or else existing seeds will no longer produce the same decks
+func seedToInt64(seed string) int64 {
+	crc64Table := crc64.MakeTable(crc64.ECMA)
+	intSeed := crc64.Checksum([]byte(seed), crc64Table)
+	return int64(intSeed)
+}
+
+func intInSlice(a int, slice []int) bool {
+	for _, b := range slice {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+func stringInSlice(a string, slice []string) bool {
+	for _, b := range slice {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+package engine
+
+// Options are things that are specified about the game upon table creation (before the game starts)
+// All of these are stored in the database as columns of the "games" table
+// A pointer to these options is copied into the Game struct when the game starts for convenience
+type Options struct {
+	NumPlayers int `json:"numPlayers"`
+	// StartingPlayer is a legacy field for games prior to April 2020
+	StartingPlayer        int    `json:"startingPlayer"`
+	VariantName           string `json:"variantName"`
+	Timed                 bool   `json:"timed"`
+	TimeBase              int    `json:"timeBase"`
+	TimePerTurn           int    `json:"timePerTurn"`
+	TimeControl           int    `json:"timeControl"`   // See the "TimeControl" constants
+	TurnTimeLimit         int    `json:"turnTimeLimit"` // In seconds (0 means that there is no limit)
+	Speedrun              bool   `json:"speedrun"`
+	CardCycle             bool   `json:"cardCycle"`
+	DeckPlays             bool   `json:"deckPlays"`
+	EmptyClues            bool   `json:"emptyClues"`
+	OneExtraCard          bool   `json:"oneExtraCard"`
+	OneLessCard           bool   `json:"oneLessCard"`
+	AllOrNothing          bool   `json:"allOrNothing"`
+	DetrimentalCharacters bool   `json:"detrimentalCharacters"`
+	// The clue and strike limits are normally equal to "MaxClueNum" and "MaxStrikeNum"
+	// (see "SetDefaultLimits()")
+	StartingClues int `json:"startingClues"`
+	MaxClues      int `json:"maxClues"`
+	MaxStrikes    int `json:"maxStrikes"`
+	// Takeback is not chosen by the table creator;
+	// it is set during the game if the players vote to take back a move
+	Takeback bool `json:"takeback"`
+	// CustomVariant is only specified for variants that are built by the creator of the table
+	// (it is stored in the "custom_variants" table instead of in the "games" table)
+	CustomVariant *VariantJSON `json:"customVariant,omitempty"`
+}
+
+// GetModifier computes the integer modifier for the game options,
+// corresponding to the "ScoreModifier" constants in "constants.go"
+func (o *Options) GetModifier() Bitmask {
+	var modifier Bitmask
+
+	if o.DeckPlays {
+		modifier.AddFlag(ScoreModifierDeckPlays)
+	}
+	if o.EmptyClues {
+		modifier.AddFlag(ScoreModifierEmptyClues)
+	}
+	if o.OneExtraCard {
+		modifier.AddFlag(ScoreModifierOneExtraCard)
+	}
+	if o.OneLessCard {
+		modifier.AddFlag(ScoreModifierOneLessCard)
+	}
+	if o.AllOrNothing {
+		modifier.AddFlag(ScoreModifierAllOrNothing)
+	}
+	if o.StartingClues != MaxClueNum || o.MaxClues != MaxClueNum {
+		modifier.AddFlag(ScoreModifierClueLimits)
+	}
+	if o.MaxStrikes != MaxStrikeNum {
+		modifier.AddFlag(ScoreModifierStrikeLimit)
+	}
+	if o.Takeback {
+		modifier.AddFlag(ScoreModifierTakeback)
+	}
+
+	return modifier
+}
+
+// SetDefaultLimits fills in the clue and strike limits if they were not specified
+// (e.g. by an older client or by a table that was created before these options existed)
+// A game cannot have a maximum of 0 clues or 0 strikes, so 0 means that the value is missing
+// A game can start with 0 clues, so the starting clues are only filled in if the maximum clues
+// are missing as well
+// The caller must still check that the starting clues are not above the maximum clues
+func (o *Options) SetDefaultLimits() {
+	if o.MaxClues == 0 {
+		if o.StartingClues == 0 {
+			o.StartingClues = MaxClueNum
+		}
+		o.MaxClues = MaxClueNum
+	}
+	if o.MaxStrikes == 0 {
+		o.MaxStrikes = MaxStrikeNum
+	}
+}
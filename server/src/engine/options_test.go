@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestSetDefaultLimits(t *testing.T) {
+	// An older client does not send the limits at all
+	options := &Options{}
+	options.SetDefaultLimits()
+	if options.StartingClues != MaxClueNum ||
+		options.MaxClues != MaxClueNum ||
+		options.MaxStrikes != MaxStrikeNum {
+
+		t.Errorf("The limits are %d/%d/%d, expected the defaults.",
+			options.StartingClues, options.MaxClues, options.MaxStrikes)
+	}
+
+	// Limits that were specified are kept
+	options = &Options{
+		StartingClues: 2,
+		MaxClues:      4,
+		MaxStrikes:    5,
+	}
+	options.SetDefaultLimits()
+	if options.StartingClues != 2 || options.MaxClues != 4 || options.MaxStrikes != 5 {
+		t.Errorf("The limits are %d/%d/%d, expected 2/4/5.",
+			options.StartingClues, options.MaxClues, options.MaxStrikes)
+	}
+
+	// The starting clues are kept even if the maximum clues were not specified
+	options = &Options{
+		StartingClues: 3,
+	}
+	options.SetDefaultLimits()
+	if options.StartingClues != 3 || options.MaxClues != MaxClueNum {
+		t.Errorf("The clue limits are %d/%d, expected 3/%d.",
+			options.StartingClues, options.MaxClues, MaxClueNum)
+	}
+
+	// A game can start with no clues
+	options = &Options{
+		StartingClues: 0,
+		MaxClues:      4,
+	}
+	options.SetDefaultLimits()
+	if options.StartingClues != 0 {
+		t.Errorf("The starting clues are %d, expected 0.", options.StartingClues)
+	}
+}
+
+func TestGetModifierLimits(t *testing.T) {
+	options := testNewOptions(2, "No Variant")
+	if modifier := options.GetModifier(); modifier != 0 {
+		t.Errorf("The modifier of the default options is %d, expected 0.", modifier)
+	}
+
+	options.StartingClues = 4
+	if !options.GetModifier().HasFlag(ScoreModifierClueLimits) {
+		t.Error("Changing the starting clues did not set the clue limits modifier.")
+	}
+
+	options = testNewOptions(2, "No Variant")
+	options.MaxClues = 12
+	if !options.GetModifier().HasFlag(ScoreModifierClueLimits) {
+		t.Error("Changing the maximum clues did not set the clue limits modifier.")
+	}
+
+	options = testNewOptions(2, "No Variant")
+	options.MaxStrikes = 1
+	if !options.GetModifier().HasFlag(ScoreModifierStrikeLimit) {
+		t.Error("Changing the strike limit did not set the strike limit modifier.")
+	}
+}
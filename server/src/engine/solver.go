@@ -0,0 +1,351 @@
+// A solver that finds the highest score that is reachable on a particular deal when every player
+// can see every card (including their own cards and the cards in the deck)
+// This is useful to tell whether a loss was the fault of the players or the fault of the deal
+//
+// The solver makes the following simplifications:
+// - A clue can always be given if there is a clue token available and someone else has a card
+//   (with perfect information, the content of the clue does not matter)
+// - Players never misplay on purpose, since it is never better than giving a clue or discarding
+// - The game options are assumed to be the defaults (e.g. no "One Extra Card")
+
+package engine
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	// The default amount of game states that the solver will explore before giving up
+	SolverDefaultMaxNodes = 200000
+
+	// Variants have at most 6 suits and ranks are never higher than "StartCardRank"
+	solverMaxCardID = 6 * 8
+)
+
+// SolverResult is the outcome of running the solver on a deal
+type SolverResult struct {
+	// The highest score that the solver was able to reach
+	MaxScore int
+	// True if the search explored every relevant line of play (or reached a perfect score)
+	// If false, "MaxScore" is only a lower bound
+	Complete bool
+}
+
+type solver struct {
+	variant    *Variant
+	deck       []*Card
+	numPlayers int
+	maxScore   int
+	maxClues   int
+	clueCost   int
+
+	memo     map[string]int
+	nodes    int
+	maxNodes int
+	aborted  bool
+	best     int
+}
+
+// solverState does not need to track the order of the cards in each hand,
+// since every player can see every card
+type solverState struct {
+	hands     [][]*Card
+	deckIndex int
+	stacks    []int // The amount of cards played on each stack
+	// The amount of copies of each card that are still in a hand or in the deck
+	// (indexed by "solverCardID()")
+	available []int
+	clues     int
+	active    int
+	turnsLeft int // The amount of turns left after the deck runs out, or -1 if there are cards left
+	score     int
+}
+
+// Solve deals the cards for the given seed and searches for the highest reachable score
+// "maxNodes" limits the amount of game states that are explored
+func Solve(variant *Variant, numPlayers int, seed string, maxNodes int) (*SolverResult, error) {
+	if variant.IsUpOrDown() {
+		return nil, errors.New("the solver does not support \"Up or Down\" variants")
+	}
+	if len(variant.Suits)*8 > solverMaxCardID {
+		return nil, errors.New("the solver does not support variants with more than 6 suits")
+	}
+	if numPlayers < 2 {
+		return nil, errors.New("the solver needs at least 2 players")
+	}
+
+	options := &Options{
+		NumPlayers:  numPlayers,
+		VariantName: variant.Name,
+	}
+	options.SetDefaultLimits()
+	playerNames := make([]string, numPlayers)
+	g := NewSeededGame(variant, options, seed, playerNames)
+
+	s := &solver{
+		variant:    variant,
+		deck:       g.Deck,
+		numPlayers: numPlayers,
+		maxScore:   len(variant.Suits) * PointsPerSuit,
+		maxClues:   variant.GetAdjustedClueTokens(options.MaxClues),
+		clueCost:   variant.GetAdjustedClueTokens(1),
+		memo:       make(map[string]int),
+		maxNodes:   maxNodes,
+	}
+	state := &solverState{
+		hands:     make([][]*Card, numPlayers),
+		deckIndex: g.DeckIndex,
+		stacks:    make([]int, len(variant.Suits)),
+		available: make([]int, len(variant.Suits)*8),
+		clues:     s.maxClues,
+		turnsLeft: -1,
+	}
+	for _, c := range g.Deck {
+		state.available[solverCardID(c)]++
+	}
+	for i, p := range g.Players {
+		state.hands[i] = append(state.hands[i], p.Hand...)
+	}
+
+	s.search(state)
+
+	return &SolverResult{
+		MaxScore: s.best,
+		Complete: !s.aborted || s.best == s.maxScore,
+	}, nil
+}
+
+// search returns the highest final score that is reachable from the given state
+func (s *solver) search(state *solverState) int {
+	if state.score > s.best {
+		s.best = state.score
+	}
+	if state.score == s.maxScore || state.turnsLeft == 0 {
+		return state.score
+	}
+	if s.best == s.maxScore || s.upperBound(state) <= s.best {
+		// This line of play cannot improve on what we have already found
+		return state.score
+	}
+
+	key := s.key(state)
+	if v, ok := s.memo[key]; ok {
+		return v
+	}
+
+	s.nodes++
+	if s.nodes > s.maxNodes {
+		s.aborted = true
+		return state.score
+	}
+
+	best := state.score
+	for _, next := range s.moves(state) {
+		if v := s.search(next); v > best {
+			best = v
+		}
+		if s.aborted || best == s.maxScore {
+			break
+		}
+	}
+
+	if !s.aborted {
+		s.memo[key] = best
+	}
+	return best
+}
+
+// moves returns every meaningfully different state that can follow the given state,
+// with the most promising moves first
+func (s *solver) moves(state *solverState) []*solverState {
+	moves := make([]*solverState, 0)
+	hand := state.hands[state.active]
+
+	// Playing a card
+	var seen [solverMaxCardID]bool
+	for i, c := range hand {
+		if seen[solverCardID(c)] {
+			continue
+		}
+		seen[solverCardID(c)] = true
+		if !s.isPlayable(state, c) {
+			continue
+		}
+
+		next := s.removeCard(state, i)
+		next.stacks[c.SuitIndex]++
+		next.score++
+		if next.stacks[c.SuitIndex] == PointsPerSuit &&
+			s.variant.ShouldGiveClueTokenForPlaying5() &&
+			next.clues < s.maxClues {
+
+			next.clues++
+		}
+		s.finishTurn(next, true)
+		moves = append(moves, next)
+	}
+
+	// Discarding a card
+	// All of the cards that are no longer needed are equivalent, so we only try one of them
+	discards := make([]*solverState, 0)
+	if state.clues < s.maxClues {
+		seen = [solverMaxCardID]bool{}
+		trashed := false
+		for i, c := range hand {
+			trash := s.isTrash(state, c)
+			if trash && trashed {
+				continue
+			}
+			if seen[solverCardID(c)] {
+				continue
+			}
+			seen[solverCardID(c)] = true
+
+			next := s.removeCard(state, i)
+			next.clues++
+			s.finishTurn(next, true)
+			if trash {
+				trashed = true
+				moves = append(moves, next)
+			} else {
+				discards = append(discards, next)
+			}
+		}
+	}
+
+	// Giving a clue
+	if state.clues >= s.clueCost && s.someoneElseHasCards(state) {
+		next := s.copyState(state)
+		next.clues -= s.clueCost
+		s.finishTurn(next, false)
+		moves = append(moves, next)
+	}
+
+	return append(moves, discards...)
+}
+
+func (s *solver) finishTurn(state *solverState, draw bool) {
+	if draw && state.deckIndex < len(s.deck) {
+		state.hands[state.active] = append(state.hands[state.active], s.deck[state.deckIndex])
+		state.deckIndex++
+		if state.deckIndex == len(s.deck) {
+			// Every player (including this one) gets one more turn
+			state.turnsLeft = s.numPlayers + 1
+		}
+	}
+	if state.turnsLeft > 0 {
+		state.turnsLeft--
+	}
+	state.active = (state.active + 1) % s.numPlayers
+}
+
+// upperBound returns the highest score that could possibly be reached from the given state
+func (s *solver) upperBound(state *solverState) int {
+	// Each stack can only progress until the first card that has had every copy discarded
+	remaining := 0
+	for suitIndex := range state.stacks {
+		for played := state.stacks[suitIndex]; played < PointsPerSuit; played++ {
+			rank := s.nextRank(suitIndex, played)
+			if state.available[suitIndex*8+rank] == 0 {
+				break
+			}
+			remaining++
+		}
+	}
+
+	// Every play draws a card, so only a limited amount of plays can happen before the game ends
+	maxPlays := state.turnsLeft
+	if maxPlays == -1 {
+		maxPlays = len(s.deck) - state.deckIndex + s.numPlayers
+	}
+	if maxPlays < remaining {
+		remaining = maxPlays
+	}
+
+	return state.score + remaining
+}
+
+func (s *solver) nextRank(suitIndex int, played int) int {
+	if s.variant.Suits[suitIndex].Reversed {
+		return PointsPerSuit - played
+	}
+	return played + 1
+}
+
+func (s *solver) isPlayable(state *solverState, c *Card) bool {
+	played := state.stacks[c.SuitIndex]
+	return played < PointsPerSuit && c.Rank == s.nextRank(c.SuitIndex, played)
+}
+
+// isTrash returns true if a card can never be played, either because it was already played or
+// because a card that must be played before it is gone
+func (s *solver) isTrash(state *solverState, c *Card) bool {
+	for played := state.stacks[c.SuitIndex]; played < PointsPerSuit; played++ {
+		rank := s.nextRank(c.SuitIndex, played)
+		if rank == c.Rank {
+			return false
+		}
+		if state.available[c.SuitIndex*8+rank] == 0 {
+			return true
+		}
+	}
+	return true
+}
+
+func (s *solver) someoneElseHasCards(state *solverState) bool {
+	for i, hand := range state.hands {
+		if i != state.active && len(hand) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *solver) copyState(state *solverState) *solverState {
+	next := *state
+	next.hands = make([][]*Card, len(state.hands))
+	for i, hand := range state.hands {
+		next.hands[i] = append(make([]*Card, 0, len(hand)+1), hand...)
+	}
+	next.stacks = append(make([]int, 0, len(state.stacks)), state.stacks...)
+	next.available = append(make([]int, 0, len(state.available)), state.available...)
+	return &next
+}
+
+// removeCard copies the state and removes the card at the given index from the active player's hand
+// (as a result of either a play or a discard)
+func (s *solver) removeCard(state *solverState, i int) *solverState {
+	next := s.copyState(state)
+	hand := next.hands[next.active]
+	next.available[solverCardID(hand[i])]--
+	next.hands[next.active] = append(hand[:i], hand[i+1:]...)
+	return next
+}
+
+// key serializes the parts of a state that affect the rest of the game
+// (the cards that were discarded can be derived from the rest of the state)
+func (s *solver) key(state *solverState) string {
+	key := make([]byte, 0, 64)
+	key = append(key, byte(state.deckIndex), byte(state.clues), byte(state.active),
+		byte(state.turnsLeft+1))
+	for _, played := range state.stacks {
+		key = append(key, byte(played))
+	}
+	for _, hand := range state.hands {
+		ids := make([]int, 0, len(hand))
+		for _, c := range hand {
+			ids = append(ids, solverCardID(c))
+		}
+		sort.Ints(ids)
+		key = append(key, 0xff)
+		for _, id := range ids {
+			key = append(key, byte(id))
+		}
+	}
+	return string(key)
+}
+
+func solverCardID(c *Card) int {
+	return c.SuitIndex*8 + c.Rank
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+)
+
+// LoadSuits reads the "suits.json" file and returns a map of every suit, keyed by name
+// A reversed version of every suit is automatically added
+func LoadSuits(filePath string, colors map[string]*Color) (map[string]*Suit, error) {
+	// Import the JSON file
+	var fileContents []byte
+	if v, err := ioutil.ReadFile(filePath); err != nil {
+		return nil, err
+	} else {
+		fileContents = v
+	}
+	var suitsArray []*Suit
+	if err := json.Unmarshal(fileContents, &suitsArray); err != nil {
+		return nil, err
+	}
+
+	// Convert the array to a map
+	suits := make(map[string]*Suit)
+	for _, suit := range suitsArray {
+		// Validate the suit name
+		if suit.Name == "" {
+			return nil, errors.New("there is a suit with an empty name")
+		}
+
+		// Validate that all of the names are unique
+		if _, ok := suits[suit.Name]; ok {
+			return nil, errors.New("there are two suits with the name of \"" + suit.Name + "\"")
+		}
+
+		// Validate that there is an abbreviation
+		// If it is not specified, use the abbreviation of the color with the same name
+		// Otherwise, assume that it is the first letter of the suit
+		if suit.Abbreviation == "" {
+			if len(suit.ClueColors) > 0 {
+				if color, ok := colors[suit.ClueColors[0]]; ok {
+					if color.Abbreviation != "" {
+						suit.Abbreviation = color.Abbreviation
+					}
+				}
+			}
+		}
+		if suit.Abbreviation == "" {
+			suit.Abbreviation = string([]rune(suit.Name)[0])
+		}
+		if len(suit.Abbreviation) != 1 {
+			return nil, errors.New("the \"" + suit.Name + "\" suit has an abbreviation " +
+				"that is not one letter long")
+		}
+
+		// Validate the clue colors (the colors that touch this suit)
+		if len(suit.ClueColors) > 0 {
+			for _, colorName := range suit.ClueColors {
+				if _, ok := colors[colorName]; !ok {
+					return nil, errors.New("the suit of \"" + suit.Name + "\" has a clue color of " +
+						"\"" + colorName + "\", but that color does not exist")
+				}
+			}
+		} else if !suit.AllClueColors && !suit.NoClueColors && !suit.Prism {
+			// The clue colors were not specified; by default, use the color of the same name
+			if _, ok := colors[suit.Name]; ok {
+				suit.ClueColors = []string{suit.Name}
+			} else if suit.Name != "Unknown" { // The "Unknown" suit is not supposed to have clue colors
+				return nil, errors.New("the suit of \"" + suit.Name + "\" " +
+					"has no clue colors defined and there is no color of the same name")
+			}
+		}
+
+		// Validate the pip
+		if suit.Pip == "" && suit.Name != "Unknown" {
+			return nil, errors.New("the suit of \"" + suit.Name + "\" does not have a pip specified")
+		}
+
+		// Add it to the map
+		suits[suit.Name] = suit
+	}
+
+	// For every suit, add a reversed version of that suit
+	for name, suit := range suits {
+		// In Go, this dereference assignment is a shallow copy
+		suitReversed := *suit
+		suitReversed.Reversed = true
+		suits[name+SuitReversedSuffix] = &suitReversed
+	}
+
+	return suits, nil
+}
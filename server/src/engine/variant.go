@@ -1,8 +1,4 @@
-package main
-
-import (
-	"strings"
-)
+package engine
 
 type Variant struct {
 	Name string
@@ -20,31 +16,39 @@ type Variant struct {
 	SpecialAllClueRanks    bool
 	SpecialNoClueColors    bool
 	SpecialNoClueRanks     bool
-	MaxScore               int
+	// Special rules that apply to the whole variant
+	// (see "validateRules()" in "variants.go" for the combinations that are not allowed)
+	AlternatingClues bool
+	ClueStarved      bool
+	CowAndPig        bool
+	Duck             bool
+	ThrowItInAHole   bool
+	UpOrDown         bool
+	MaxScore         int
 }
 
 func (v *Variant) IsAlternatingClues() bool {
-	return strings.HasPrefix(v.Name, "Alternating Clues")
+	return v.AlternatingClues
 }
 
 func (v *Variant) IsClueStarved() bool {
-	return strings.HasPrefix(v.Name, "Clue Starved")
+	return v.ClueStarved
 }
 
 func (v *Variant) IsCowAndPig() bool {
-	return strings.HasPrefix(v.Name, "Cow & Pig")
+	return v.CowAndPig
 }
 
 func (v *Variant) IsDuck() bool {
-	return strings.HasPrefix(v.Name, "Duck")
+	return v.Duck
 }
 
 func (v *Variant) IsThrowItInAHole() bool {
-	return strings.HasPrefix(v.Name, "Throw It in a Hole")
+	return v.ThrowItInAHole
 }
 
 func (v *Variant) IsUpOrDown() bool {
-	return strings.HasPrefix(v.Name, "Up or Down")
+	return v.UpOrDown
 }
 
 func (v *Variant) HasReversedSuits() bool {
@@ -86,8 +90,8 @@ func (v *Variant) GetAdjustedClueTokens(clueTokens int) int {
 	return clueTokens
 }
 
-func (v *Variant) AtMaxClueTokens(clueTokens int) bool {
-	return clueTokens >= v.GetAdjustedClueTokens(MaxClueNum)
+func (v *Variant) AtMaxClueTokens(clueTokens int, maxClues int) bool {
+	return clueTokens >= v.GetAdjustedClueTokens(maxClues)
 }
 
 func (v *Variant) ShouldGiveClueTokenForPlaying5() bool {
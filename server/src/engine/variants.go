@@ -0,0 +1,325 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+)
+
+// VariantJSON is very similar to Variant,
+// but the latter is comprised of some more complicated objects
+type VariantJSON struct {
+	Name  string   `json:"name"`
+	ID    int      `json:"id"`
+	Suits []string `json:"suits"`
+	// ClueColors and ClueRanks are optional elements
+	// Thus, they must be pointers so that we can tell if the values were specified or not
+	ClueColors             *[]string `json:"clueColors"`
+	ClueRanks              *[]int    `json:"clueRanks"`
+	ColorCluesTouchNothing bool      `json:"colorCluesTouchNothing"`
+	RankCluesTouchNothing  bool      `json:"rankCluesTouchNothing"`
+	SpecialRank            int       `json:"specialRank"` // For e.g. Rainbow-Ones
+	SpecialAllClueColors   bool      `json:"specialAllClueColors"`
+	SpecialAllClueRanks    bool      `json:"specialAllClueRanks"`
+	SpecialNoClueColors    bool      `json:"specialNoClueColors"`
+	SpecialNoClueRanks     bool      `json:"specialNoClueRanks"`
+	AlternatingClues       bool      `json:"alternatingClues"`
+	ClueStarved            bool      `json:"clueStarved"`
+	CowAndPig              bool      `json:"cowAndPig"`
+	Duck                   bool      `json:"duck"`
+	ThrowItInAHole         bool      `json:"throwItInAHole"`
+	UpOrDown               bool      `json:"upOrDown"`
+}
+
+// LoadVariants reads the "variants.json" file and returns every variant
+// (in the same order as they are listed in the file)
+func LoadVariants(
+	filePath string,
+	suits map[string]*Suit,
+	colors map[string]*Color,
+) ([]*Variant, error) {
+	// Import the JSON file
+	var fileContents []byte
+	if v, err := ioutil.ReadFile(filePath); err != nil {
+		return nil, err
+	} else {
+		fileContents = v
+	}
+	var variantsArray []VariantJSON
+	if err := json.Unmarshal(fileContents, &variantsArray); err != nil {
+		return nil, err
+	}
+
+	variantList := make([]*Variant, 0)
+	variantNameMap := make(map[string]struct{})
+	variantIDMap := make(map[int]struct{})
+	for _, variant := range variantsArray {
+		// Validate the name
+		if variant.Name == "" {
+			return nil, errors.New("there is a variant with an empty name")
+		}
+
+		// Validate the ID
+		if variant.ID < 0 { // The first variant has an ID of 0
+			return nil, errors.New("the \"" + variant.Name + "\" variant has an invalid ID")
+		}
+
+		// Validate that all of the names are unique
+		if _, ok := variantNameMap[variant.Name]; ok {
+			return nil, errors.New("there are two variants with the name of " +
+				"\"" + variant.Name + "\"")
+		}
+		variantNameMap[variant.Name] = struct{}{}
+
+		// Validate that all of the ID's are unique
+		if _, ok := variantIDMap[variant.ID]; ok {
+			return nil, errors.New("there are two variants with the ID of " +
+				"\"" + strconv.Itoa(variant.ID) + "\"")
+		}
+		variantIDMap[variant.ID] = struct{}{}
+
+		// Convert the JSON variant into a variant object
+		if v, err := NewVariant(&variant, suits, colors); err != nil {
+			return nil, err
+		} else {
+			variantList = append(variantList, v)
+		}
+	}
+
+	// Validate that there are no skipped ID numbers
+	for i := 0; i < len(variantList); i++ {
+		if _, ok := variantIDMap[i]; !ok {
+			return nil, errors.New("there is no variant with an ID of \"" + strconv.Itoa(i) + "\" " +
+				"(variant IDs must be sequential)")
+		}
+	}
+
+	return variantList, nil
+}
+
+// NewVariant converts a variant from the JSON format into a variant object,
+// validating that all of its suits and clue colors exist
+// This is used both for the variants in the "variants.json" file and for custom variants
+func NewVariant(
+	variantJSON *VariantJSON,
+	suits map[string]*Suit,
+	colors map[string]*Color,
+) (*Variant, error) {
+	// Validate that there is at least one suit
+	if len(variantJSON.Suits) < 1 {
+		return nil, errors.New("the variant of \"" + variantJSON.Name + "\" " +
+			"does not have at least one suit")
+	}
+
+	// Validate that all of the suits exist and convert suit strings to objects
+	variantSuits := make([]*Suit, 0)
+	for _, suitName := range variantJSON.Suits {
+		if suit, ok := suits[suitName]; !ok {
+			return nil, errors.New("the suit of \"" + suitName + "\" " +
+				"in variant \"" + variantJSON.Name + "\" does not exist")
+		} else {
+			variantSuits = append(variantSuits, suit)
+		}
+	}
+
+	// Derive the card ranks (the ranks that the cards of each suit will be)
+	// By default, assume ranks 1 through 5
+	variantRanks := []int{1, 2, 3, 4, 5}
+	if variantJSON.UpOrDown {
+		// The "Up or Down" variants have START cards
+		// ("StartCardRank" is defined in the "variants_reversible.go" file)
+		variantRanks = append(variantRanks, StartCardRank)
+	}
+
+	// Validate or derive the clue colors (the colors available to clue in this variant)
+	clueColors := variantJSON.ClueColors
+	if clueColors == nil {
+		// The clue colors were not specified in the JSON, so derive them from the suits
+		derivedClueColors := make([]string, 0)
+		for _, suit := range variantSuits {
+			if suit.AllClueColors {
+				// If a suit is touched by all colors,
+				// then we don't want to add every single clue color to the variant clue list
+				continue
+			}
+			for _, color := range suit.ClueColors {
+				if !stringInSlice(color, derivedClueColors) {
+					derivedClueColors = append(derivedClueColors, color)
+				}
+			}
+		}
+		clueColors = &derivedClueColors
+	} else {
+		// The clue colors were specified in the JSON, so validate that they map to colors
+		for _, colorName := range *variantJSON.ClueColors {
+			if _, ok := colors[colorName]; !ok {
+				return nil, errors.New("the variant of \"" + variantJSON.Name + "\" " +
+					"has a clue color of \"" + colorName + "\", but that color does not exist")
+			}
+		}
+	}
+
+	// Validate or derive the clue ranks (the ranks available to clue in this variant)
+	clueRanks := variantJSON.ClueRanks
+	if clueRanks == nil {
+		// The clue ranks were not specified in the JSON,
+		// so just assume that we can clue ranks 1 through 5
+		clueRanks = &[]int{1, 2, 3, 4, 5}
+	} else {
+		// The clue ranks were specified in the JSON, so validate that they are normal ranks
+		for _, rank := range *variantJSON.ClueRanks {
+			if rank < 1 || rank > 5 {
+				return nil, errors.New("the variant of \"" + variantJSON.Name + "\" " +
+					"has an invalid clue rank of \"" + strconv.Itoa(rank) + "\"")
+			}
+		}
+	}
+
+	// The default value of "SpecialRank" is -1, not 0
+	specialRank := variantJSON.SpecialRank
+	if specialRank == 0 {
+		specialRank = -1
+	} else if specialRank < 1 || specialRank > 5 {
+		return nil, errors.New("the variant of \"" + variantJSON.Name + "\" " +
+			"has an invalid special rank of \"" + strconv.Itoa(specialRank) + "\"")
+	}
+
+	// Convert the JSON variant into a variant object
+	variant := &Variant{
+		Name:                   variantJSON.Name,
+		ID:                     variantJSON.ID,
+		Suits:                  variantSuits,
+		Ranks:                  variantRanks,
+		ClueColors:             *clueColors,
+		ClueRanks:              *clueRanks,
+		ColorCluesTouchNothing: variantJSON.ColorCluesTouchNothing,
+		RankCluesTouchNothing:  variantJSON.RankCluesTouchNothing,
+		SpecialRank:            specialRank,
+		SpecialAllClueColors:   variantJSON.SpecialAllClueColors,
+		SpecialAllClueRanks:    variantJSON.SpecialAllClueRanks,
+		SpecialNoClueColors:    variantJSON.SpecialNoClueColors,
+		SpecialNoClueRanks:     variantJSON.SpecialNoClueRanks,
+		AlternatingClues:       variantJSON.AlternatingClues,
+		ClueStarved:            variantJSON.ClueStarved,
+		CowAndPig:              variantJSON.CowAndPig,
+		Duck:                   variantJSON.Duck,
+		ThrowItInAHole:         variantJSON.ThrowItInAHole,
+		UpOrDown:               variantJSON.UpOrDown,
+		MaxScore:               len(variantSuits) * PointsPerSuit,
+	}
+
+	// Validate that the special rules do not contradict each other
+	if err := validateRules(variant); err != nil {
+		return nil, errors.New("the variant of \"" + variantJSON.Name + "\" " + err.Error())
+	}
+
+	return variant, nil
+}
+
+// validateRules returns an error if a variant has a combination of rules that cannot work together
+// (the text of the error is meant to follow the name of the variant)
+func validateRules(v *Variant) error {
+	if v.CowAndPig && v.Duck {
+		return errors.New("cannot have both the \"cowAndPig\" rule and the \"duck\" rule")
+	}
+
+	if v.AlternatingClues &&
+		(len(v.ClueColors) == 0 || len(v.ClueRanks) == 0 ||
+			v.ColorCluesTouchNothing || v.RankCluesTouchNothing) {
+
+		// Otherwise, the players would be stuck after the first clue
+		return errors.New("has the \"alternatingClues\" rule, " +
+			"so it must have both color clues and rank clues that touch cards")
+	}
+
+	if v.UpOrDown {
+		for _, suit := range v.Suits {
+			// In "Up or Down", the direction of every stack is decided by the players
+			if suit.Reversed {
+				return errors.New("has the \"upOrDown\" rule, " +
+					"so it cannot have the reversed suit of \"" + suit.Name + "\"")
+			}
+
+			// "GetDeckSize()" and "InitDeck()" assume that every suit has a "START" card in
+			// addition to a single 1 and a single 5
+			if suit.OneOfEach {
+				return errors.New("has the \"upOrDown\" rule, " +
+					"so it cannot have the one-of-each suit of \"" + suit.Name + "\"")
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsCardTouched returns true if a clue will touch a particular suit
+// For example, a yellow clue will not touch a green card in a normal game,
+// but it will the "Dual-Color" variant
+// This mirrors the function "touchesCard()" in "clues.ts"
+func (v *Variant) IsCardTouched(clue Clue, card *Card) bool {
+	suit := v.Suits[card.SuitIndex]
+
+	if clue.Type == ClueTypeColor {
+		if v.ColorCluesTouchNothing {
+			return false
+		}
+
+		if suit.AllClueColors {
+			return true
+		}
+		if suit.NoClueColors {
+			return false
+		}
+
+		if v.SpecialRank == card.Rank {
+			if v.SpecialAllClueColors {
+				return true
+			}
+			if v.SpecialNoClueColors {
+				return false
+			}
+		}
+
+		clueColorName := v.ClueColors[clue.Value]
+
+		if suit.Prism {
+			// The color that touches a prism card is contingent upon the card's rank
+			prismColorIndex := (card.Rank - 1) % len(v.ClueColors)
+			if card.Rank == StartCardRank {
+				// "START" cards count as rank 0, so they are touched by the final color
+				prismColorIndex = len(v.ClueColors) - 1
+			}
+			prismColorName := v.ClueColors[prismColorIndex]
+			return clueColorName == prismColorName
+		}
+
+		return stringInSlice(clueColorName, suit.ClueColors)
+	}
+
+	if clue.Type == ClueTypeRank {
+		if v.RankCluesTouchNothing {
+			return false
+		}
+
+		if suit.AllClueRanks {
+			return true
+		}
+		if suit.NoClueRanks {
+			return false
+		}
+
+		if v.SpecialRank == card.Rank {
+			if v.SpecialAllClueRanks {
+				return true
+			}
+			if v.SpecialNoClueRanks {
+				return false
+			}
+		}
+
+		return clue.Value == card.Rank
+	}
+
+	return false
+}
@@ -2,7 +2,7 @@
 // (e.g. 5 --> 4 --> 3 --> 2 --> 1)
 // Currently used for "Up Or Down" and "Reversed" variants
 
-package main
+package engine
 
 // iota starts at 0 and counts upwards
 // i.e. stackDirectionUndecided = 0, stackDirectionUp = 1, etc.
@@ -23,9 +23,6 @@ const (
 )
 
 func variantReversiblePlay(g *Game, c *Card) bool {
-	// Local variables
-	variant := variants[g.Options.VariantName]
-
 	var failed bool
 	if g.PlayStackDirections[c.SuitIndex] == StackDirectionUndecided {
 		// If the stack direction is undecided,
@@ -64,7 +61,7 @@ func variantReversiblePlay(g *Game, c *Card) bool {
 			g.PlayStackDirections[c.SuitIndex] = StackDirectionFinished
 		}
 	} else if g.PlayStackDirections[c.SuitIndex] == StackDirectionDown {
-		if !variant.IsUpOrDown() && g.Stacks[c.SuitIndex] == 0 {
+		if !g.Variant.IsUpOrDown() && g.Stacks[c.SuitIndex] == 0 {
 			// The first card in a down stack must be a 5
 			// except on "Up or Down", where the stack direction starts Undecided
 			failed = c.Rank != 5
@@ -87,14 +84,11 @@ func variantReversiblePlay(g *Game, c *Card) bool {
 // variantReversibleGetMaxScore calculates what the maximum score is,
 // accounting for stacks that cannot be completed due to discarded cards
 func variantReversibleGetMaxScore(g *Game) int {
-	// Local variables
-	variant := variants[g.Options.VariantName]
-
 	maxScore := 0
 	for suitIndex := range g.Stacks {
 		// Make a map that shows if all of some particular rank in this suit has been discarded
 		ranks := []int{1, 2, 3, 4, 5}
-		if variant.IsUpOrDown() {
+		if g.Variant.IsUpOrDown() {
 			ranks = append(ranks, StartCardRank)
 		}
 
@@ -122,13 +116,10 @@ func variantReversibleGetMaxScore(g *Game) int {
 
 // A helper function for "variantReversibleGetMaxScore()"
 func variantReversibleWalkUp(g *Game, allDiscarded map[int]bool) int {
-	// Local variables
-	variant := variants[g.Options.VariantName]
-
 	cardsThatCanStillBePlayed := 0
 
 	// First, check to see if the stack can still be started
-	if variant.IsUpOrDown() {
+	if g.Variant.IsUpOrDown() {
 		if allDiscarded[1] && allDiscarded[StartCardRank] {
 			// In "Up or Down" variants, you can start with 1 or START when going up
 			return 0
@@ -154,13 +145,10 @@ func variantReversibleWalkUp(g *Game, allDiscarded map[int]bool) int {
 
 // A helper function for "variantReversibleGetMaxScore()"
 func variantReversibleWalkDown(g *Game, allDiscarded map[int]bool) int {
-	// Local variables
-	variant := variants[g.Options.VariantName]
-
 	cardsThatCanStillBePlayed := 0
 
 	// First, check to see if the stack can still be started
-	if variant.IsUpOrDown() {
+	if g.Variant.IsUpOrDown() {
 		if allDiscarded[5] && allDiscarded[StartCardRank] {
 			// In "Up or Down" variants, you can start with 5 or START when going down
 			return 0
@@ -186,9 +174,6 @@ func variantReversibleWalkDown(g *Game, allDiscarded map[int]bool) int {
 
 // variantReversibleCheckAllDead returns true if no more cards can be played on the stacks
 func variantReversibleCheckAllDead(g *Game) bool {
-	// Local variables
-	variant := variants[g.Options.VariantName]
-
 	for suitIndex, stackRank := range g.Stacks {
 		neededRanks := make([]int, 0)
 		if g.PlayStackDirections[suitIndex] == StackDirectionUndecided {
@@ -202,7 +187,7 @@ func variantReversibleCheckAllDead(g *Game) bool {
 		} else if g.PlayStackDirections[suitIndex] == StackDirectionUp {
 			neededRanks = append(neededRanks, stackRank+1)
 		} else if g.PlayStackDirections[suitIndex] == StackDirectionDown {
-			if !variant.IsUpOrDown() && stackRank == 0 {
+			if !g.Variant.IsUpOrDown() && stackRank == 0 {
 				// On "Reversed", the Down stacks start with 5
 				neededRanks = []int{5}
 			} else {
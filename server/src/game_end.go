@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strconv"
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 func (g *Game) End() {
@@ -11,11 +13,18 @@ func (g *Game) End() {
 	t := g.Table
 
 	g.DatetimeFinished = time.Now()
-	if g.EndCondition > EndConditionNormal {
+	if g.EndCondition > engine.EndConditionNormal {
 		g.Score = 0
 	}
 	logger.Info(t.GetName() + "Ended with a score of " + strconv.Itoa(g.Score) + ".")
 
+	// Nobody can run out of time or vote on a takeback once the game is over
+	// (the idle timeout remains, since the table will become a shared replay)
+	g.TakebackRequested = false
+	scheduler.Cancel(t, TaskTurnTimer)
+	scheduler.Cancel(t, TaskTakeback)
+	scheduler.Cancel(t, TaskBotTurn)
+
 	// There will be no times associated with a replay, so don't bother with the rest of the code
 	if g.ExtraOptions.NoWriteToDatabase {
 		return
@@ -30,12 +39,11 @@ func (g *Game) End() {
 		playerTimes = append(playerTimes, milliseconds)
 	}
 	duration := int64(g.DatetimeFinished.Sub(g.DatetimeStarted) / time.Millisecond)
-	g.Actions = append(g.Actions, ActionPlayerTimes{
+	g.AddAction(engine.ActionPlayerTimes{
 		Type:        "playerTimes",
 		PlayerTimes: playerTimes,
 		Duration:    duration,
 	})
-	t.NotifyGameAction()
 
 	// Notify everyone that the table was deleted
 	// (we will send a new table message later for the shared replay)
@@ -46,18 +54,47 @@ func (g *Game) End() {
 	// they will be manually set to having a "Shared Replay" status later
 	// after the game is converted)
 	for _, p := range t.Players {
-		if p.Session != nil {
+		if p.Session != nil && p.Bot == nil {
 			p.Session.Set("status", StatusLobby)
 			p.Session.Set("tableID", uint64(0))
 			notifyAllUser(p.Session)
 		}
 	}
 
+	// Players of "!seed" games often want to know whether a loss was the fault of the deal
+	if g.ExtraOptions.SetSeedSuffix != "" {
+		go announceSeedAnalysis(t.ID, g.Seed, g.Options)
+	}
+
+	// Games with bots are only for practice and are not recorded in the database
+	// (bots do not have an entry in the users table)
+	// The humans can still review the game in a shared replay
+	if t.HasBots() {
+		t.ConvertToSharedReplay()
+		return
+	}
+
 	// Record the game in the database
 	if err := g.WriteDatabase(); err != nil {
 		return
 	}
 
+	// The game is now on the leaderboard for the daily challenge
+	if g.ExtraOptions.DailyChallenge {
+		protocol := "http"
+		if useTLS {
+			protocol += "s"
+		}
+		msg := "Your game was added to the daily challenge leaderboard: " +
+			protocol + "://" + domain + "/daily"
+		chatServerSend(msg, t.GetRoomName())
+	}
+
+	// Tournament games count towards the standings of the team
+	if g.ExtraOptions.TournamentID != 0 {
+		g.WriteTournamentResult()
+	}
+
 	// Send a "gameHistory" message to all the players in the game
 	var numGamesOnThisSeed int
 	if v, err := models.Seeds.GetNumGames(g.Seed); err != nil {
@@ -67,10 +104,15 @@ func (g *Game) End() {
 		numGamesOnThisSeed = v
 	}
 	playerNames := make([]string, 0)
+	botNames := make([]string, 0)
 	for _, p := range t.Players {
 		playerNames = append(playerNames, p.Name)
+		if p.BotAccount {
+			botNames = append(botNames, p.Name)
+		}
 	}
 	sortStringsCaseInsensitive(playerNames)
+	sortStringsCaseInsensitive(botNames)
 	gameHistoryList := make([]*GameHistory, 0)
 	gameHistoryList = append(gameHistoryList, &GameHistory{
 		// The ID is recorded in the "WriteDatabase()" function above
@@ -84,6 +126,7 @@ func (g *Game) End() {
 		DatetimeFinished:   g.DatetimeFinished,
 		NumGamesOnThisSeed: numGamesOnThisSeed,
 		PlayerNames:        playerNames,
+		BotNames:           botNames,
 		IncrementNumGames:  true,
 	})
 	for _, p := range t.Players {
@@ -112,6 +155,7 @@ func (g *Game) WriteDatabase() error {
 		EndCondition:     g.EndCondition,
 		DatetimeStarted:  g.DatetimeStarted,
 		DatetimeFinished: g.DatetimeFinished,
+		Bot:              t.HasBotAccounts(),
 	}
 	if v, err := models.Games.Insert(row); err != nil {
 		logger.Error("Failed to insert the game row:", err)
@@ -265,13 +309,26 @@ func (g *Game) WriteDatabase() error {
 func (g *Game) WriteDatabaseStats() {
 	// Local variables
 	t := g.Table
-	variant := variants[g.Options.VariantName]
+	variant := getVariant(g.Options.VariantName)
 	// 2-player is at index 0, 3-player is at index 1, etc.
 	bestScoreIndex := g.Options.NumPlayers - 2
 
+	// Custom variants are one-off variants, so they do not have any variant-specific stats
+	if variant.ID < 0 {
+		return
+	}
+
+	// Games with bot accounts are excluded from the stats for humans (and from the variant stats)
+	// Bot accounts still get their own stats updated
+	hasBotAccounts := t.HasBotAccounts()
+
 	// Update the variant-specific stats for each player
 	modifier := g.Options.GetModifier()
 	for _, p := range t.Players {
+		if hasBotAccounts && !p.BotAccount {
+			continue
+		}
+
 		// Get their current best scores
 		var userStats *UserStatsRow
 		if v, err := models.UserStats.Get(p.ID, variant.ID); err != nil {
@@ -300,6 +357,10 @@ func (g *Game) WriteDatabaseStats() {
 		}
 	}
 
+	if hasBotAccounts {
+		return
+	}
+
 	// Get the current stats for this variant
 	var variantStats VariantStatsRow
 	if v, err := models.VariantStats.Get(variant.ID); err != nil {
@@ -309,6 +370,10 @@ func (g *Game) WriteDatabaseStats() {
 		variantStats = v
 	}
 
+	// Update the ratings of the players
+	// (this must happen before the stats for the variant are updated)
+	g.WriteDatabaseRatings(variant, variantStats)
+
 	// If the game was played with no modifiers, update the stats for this variant
 	if modifier == 0 {
 		bestScore := variantStats.BestScores[bestScoreIndex]
@@ -332,6 +397,10 @@ func (t *Table) ConvertToSharedReplay() {
 	t.Replay = true
 	t.InitialName = t.Name
 	t.Name = "Shared replay for game #" + strconv.Itoa(t.ExtraOptions.DatabaseID)
+	if t.HasBots() {
+		// Games with bots are not recorded in the database, so they do not have a database ID
+		t.Name = "Shared replay for bot game \"" + t.InitialName + "\""
+	}
 	// Update the "EndTurn" field (since we incremented the final turn above in an artificial way)
 	g.EndTurn = g.Turn
 	// Initialize the shared replay on the 2nd to last turn (since the end times are not important)
@@ -341,6 +410,11 @@ func (t *Table) ConvertToSharedReplay() {
 	// Turn the players into spectators
 	ownerOffline := false
 	for _, p := range t.Players {
+		// Bots do not stick around to watch the replay
+		if p.Bot != nil {
+			continue
+		}
+
 		// Skip offline players and players in the lobby;
 		// if they re-login, then they will just stay in the lobby
 		if !p.Present {
@@ -356,7 +430,7 @@ func (t *Table) ConvertToSharedReplay() {
 
 		// If this game was ended due to idleness,
 		// skip conversion so that the shared replay gets deleted below
-		if g.EndCondition == EndConditionIdleTimeout {
+		if g.EndCondition == engine.EndConditionIdleTimeout {
 			continue
 		}
 
@@ -387,7 +461,7 @@ func (t *Table) ConvertToSharedReplay() {
 		// Default to making the first player the leader,
 		// or the second player if the first is away, etc.
 		for _, p := range t.Players {
-			if p.Present {
+			if p.Present && p.Bot == nil {
 				t.Owner = p.ID
 				logger.Info("Set the new leader to be:", p.Name)
 				break
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// Takeback rolls back the game to the state that it was in before the most recent action
+// and then sends the new action history to everyone at the table
+func (g *Game) Takeback() error {
+	// Local variables
+	t := g.Table
+
+	var engineGame *engine.Game
+	if v, err := g.Game.Rewind(g.CharacterAssignments, len(g.Actions2)-1); err != nil {
+		return err
+	} else {
+		engineGame = v
+	}
+
+	// Swap in the rebuilt game state, keeping the clocks and the notes of the players
+	g.Game = engineGame
+	for i, p := range g.Players {
+		p.GamePlayer = engineGame.Players[i]
+	}
+	g.InitActionCallback()
+
+	// Mark that this game can no longer count towards best scores
+	g.Options.Takeback = true
+
+	logger.Info(t.GetName() + "Took back the last move. It is now " +
+		g.Players[g.ActivePlayerIndex].Name + "'s turn.")
+
+	// The turn of the active player starts over
+	// (this replaces the deadline of the player whose move was taken back)
+	g.DatetimeTurnBegin = time.Now()
+	g.TurnTimeBeforePause = 0
+	if t.Options.Timed && !t.ExtraOptions.NoWriteToDatabase && !g.Paused {
+		g.ScheduleTimer()
+	}
+
+	// Send everyone the new list of actions
+	for _, p := range t.Players {
+		if p.Bot == nil && p.Present {
+			p.Session.NotifyGameActionList(t)
+		}
+	}
+	for _, sp := range t.Spectators {
+		sp.Session.NotifyGameActionList(t)
+	}
+	t.NotifyTime()
+
+	// Bots keep track of the game as it goes, so they need to start over from the beginning
+	t.StartBots()
+	t.CheckBotTurn()
+
+	return nil
+}
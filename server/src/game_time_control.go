@@ -0,0 +1,162 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+// GetTurnTimeSpent returns how long the active player has taken on their turn so far
+// (not counting the time that the game was paused)
+func (g *Game) GetTurnTimeSpent() time.Duration {
+	return g.TurnTimeBeforePause + time.Since(g.DatetimeTurnBegin)
+}
+
+// GetUnusedDelay returns how much of the delay the active player has left on their turn
+// (this is 0 unless the game uses the delay time control)
+func (g *Game) GetUnusedDelay(turnTimeSpent time.Duration) time.Duration {
+	if !g.Options.Timed || g.Options.TimeControl != engine.TimeControlDelay {
+		return 0
+	}
+
+	delay := time.Duration(g.Options.TimePerTurn) * time.Second
+	if turnTimeSpent >= delay {
+		return 0
+	}
+	return delay - turnTimeSpent
+}
+
+// ChargeTurnTime deducts the time that the active player has spent since "DatetimeTurnBegin" from
+// their clock (e.g. when they take their turn or when the game is paused) and returns the total
+// time that they have spent on the turn so far
+// The time that is spent within the delay is never deducted
+func (g *Game) ChargeTurnTime() time.Duration {
+	gp := g.Players[g.ActivePlayerIndex]
+	timeSpentBefore := g.TurnTimeBeforePause
+	timeSpent := g.GetTurnTimeSpent()
+
+	// Only the part of the time that is beyond the delay is deducted
+	delayUsed := g.GetUnusedDelay(timeSpentBefore) - g.GetUnusedDelay(timeSpent)
+	gp.Time -= timeSpent - timeSpentBefore - delayUsed
+
+	return timeSpent
+}
+
+// GiveTimeForTurn adds time to the clocks in a timed game after a player has performed an action
+// (the time that they spent has already been deducted from their clock)
+func (g *Game) GiveTimeForTurn(gp *GamePlayer, timeSpent time.Duration) {
+	timePerTurn := time.Duration(g.Options.TimePerTurn) * time.Second
+
+	if g.Options.TimeControl == engine.TimeControlDelay {
+		// The time that they spent within the delay was never deducted, so there is nothing to give
+		return
+	} else if g.Options.TimeControl == engine.TimeControlHourglass {
+		// The time that they spent is split evenly between the other players
+		share := timeSpent / time.Duration(len(g.Players)-1)
+		for _, p := range g.Players {
+			if p != gp {
+				p.Time += share
+			}
+		}
+	} else {
+		gp.Time += timePerTurn
+	}
+}
+
+// EndTurnTimeLimit is called when a player has reached the turn time limit in a timed game
+// Instead of ending the game, a move is automatically made for them
+// If no move can be made, the game ends as if they had run out of time
+func (g *Game) EndTurnTimeLimit(gp *GamePlayer) {
+	// Local variables
+	t := g.Table
+
+	logger.Info(t.GetName() + "The turn time limit was reached for \"" + gp.Name + "\".")
+
+	a := g.getTurnTimeLimitAction(gp)
+	if a == nil {
+		g.EndTimer(gp)
+		return
+	}
+
+	// Get the session of this player
+	p := t.Players[gp.Index]
+	s := p.Session
+	if s == nil {
+		// A player's session should never be nil
+		// They might be in the process of reconnecting,
+		// so make a fake session that will represent them
+		s = newFakeSession(p.ID, p.Name)
+		logger.Info("Created a new fake session in the \"EndTurnTimeLimit()\" function.")
+	}
+
+	msg := gp.Name + " reached the turn time limit, so "
+	if a.Type == engine.ActionTypeDiscard {
+		msg += "their chop card was automatically discarded."
+	} else {
+		msg += "a clue was automatically given."
+	}
+	chatServerSend(msg, t.GetRoomName())
+
+	numActions := len(g.Actions2)
+	commandAction(s, &CommandData{ // Manual invocation
+		TableID: t.ID,
+		Type:    a.Type,
+		Target:  a.Target,
+		Value:   a.Value,
+		NoLock:  true,
+	})
+
+	// The move might have been forbidden by the variant or by a detrimental character
+	if len(g.Actions2) == numActions && g.EndCondition == engine.EndConditionInProgress {
+		g.EndTimer(gp)
+	}
+}
+
+// getTurnTimeLimitAction returns the move that will be made for a player who has reached the turn
+// time limit
+// Normally, this is to discard their chop card; if the team is at the maximum amount of clues,
+// it is the first clue (starting from the next player) that touches at least one card
+func (g *Game) getTurnTimeLimitAction(gp *GamePlayer) *engine.GameAction {
+	if len(gp.Hand) > 0 && !g.Variant.AtMaxClueTokens(g.ClueTokens, g.Options.MaxClues) {
+		return &engine.GameAction{
+			Type:   engine.ActionTypeDiscard,
+			Target: gp.Hand[gp.GetChopIndex()].Order,
+		}
+	}
+
+	if g.ClueTokens < g.Variant.GetAdjustedClueTokens(1) {
+		return nil
+	}
+
+	for offset := 1; offset < len(g.Players); offset++ {
+		target := g.Players[(gp.Index+offset)%len(g.Players)]
+		for _, rank := range g.Variant.ClueRanks {
+			clue := engine.Clue{
+				Type:  engine.ClueTypeRank,
+				Value: rank,
+			}
+			if len(target.FindCardsTouchedByClue(clue)) > 0 {
+				return &engine.GameAction{
+					Type:   engine.ActionTypeRankClue,
+					Target: target.Index,
+					Value:  rank,
+				}
+			}
+		}
+		for i := range g.Variant.ClueColors {
+			clue := engine.Clue{
+				Type:  engine.ClueTypeColor,
+				Value: i,
+			}
+			if len(target.FindCardsTouchedByClue(clue)) > 0 {
+				return &engine.GameAction{
+					Type:   engine.ActionTypeColorClue,
+					Target: target.Index,
+					Value:  i,
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const (
+	testTimeBase    = 60 * time.Second
+	testTimePerTurn = 30 * time.Second
+
+	// How far off a deadline can be due to the time that the test itself takes
+	testTimeTolerance = time.Second
+)
+
+// testNewTimedGame creates a 2-player timed game in the same way that "commandTableStart()" does
+func testNewTimedGame(t *testing.T, timeControl int) *Game {
+	t.Helper()
+
+	table := testAddTable(t)
+	table.Options.NumPlayers = 2
+	table.Options.VariantName = "No Variant"
+	table.Options.Timed = true
+	table.Options.TimeBase = int(testTimeBase / time.Second)
+	table.Options.TimePerTurn = int(testTimePerTurn / time.Second)
+	table.Options.TimeControl = timeControl
+
+	g := NewGame(table, "")
+	for _, name := range []string{"Alice", "Bob"} {
+		gp := &GamePlayer{
+			GamePlayer: g.AddPlayer(name),
+			Game:       g,
+		}
+		gp.InitTime(table.Options)
+		g.Players = append(g.Players, gp)
+	}
+
+	return g
+}
+
+// testPauseAfter pauses the game as if the active player had been thinking for "turnTime" since
+// the turn began (or since the last unpause), in the same way that "pause()" does
+func testPauseAfter(g *Game, turnTime time.Duration) {
+	g.DatetimeTurnBegin = time.Now().Add(-turnTime)
+	g.CancelTimer()
+	g.TurnTimeBeforePause = g.ChargeTurnTime()
+}
+
+// testUnpause unpauses the game in the same way that "pause()" does
+func testUnpause(g *Game) {
+	g.DatetimeTurnBegin = time.Now()
+	g.ScheduleTimer()
+}
+
+func testExpectTimerIn(t *testing.T, g *Game, expected time.Duration) {
+	t.Helper()
+
+	tasks := testGetTableTasks(g.Table)
+	if len(tasks) != 1 || tasks[0].Name != TaskTurnTimer {
+		t.Fatalf("The pending tasks are %v, expected only the turn timer.", tasks)
+	}
+
+	timeLeft := time.Until(tasks[0].Deadline)
+	if timeLeft > expected || timeLeft < expected-testTimeTolerance {
+		t.Errorf("The player will run out of time in %v, expected %v.", timeLeft, expected)
+	}
+}
+
+func testExpectClock(t *testing.T, gp *GamePlayer, expected time.Duration) {
+	t.Helper()
+
+	if gp.Time > expected || gp.Time < expected-testTimeTolerance {
+		t.Errorf("The clock of %s is at %v, expected %v.", gp.Name, gp.Time, expected)
+	}
+}
+
+func TestDelayIsNotChargedOnPause(t *testing.T) {
+	g := testNewTimedGame(t, engine.TimeControlDelay)
+
+	// Pausing within the delay does not touch their clock
+	testPauseAfter(g, 20*time.Second)
+	testExpectClock(t, g.Players[0], testTimeBase)
+
+	// Only the 10 seconds that are left of the delay are added after unpausing
+	testUnpause(g)
+	testExpectTimerIn(t, g, testTimeBase+10*time.Second)
+}
+
+func TestDelayIsUsedUpAcrossPauses(t *testing.T) {
+	g := testNewTimedGame(t, engine.TimeControlDelay)
+
+	testPauseAfter(g, 20*time.Second)
+	testUnpause(g)
+
+	// The second pause goes 15 seconds past the delay
+	testPauseAfter(g, 25*time.Second)
+	testExpectClock(t, g.Players[0], testTimeBase-15*time.Second)
+
+	// There is no delay left to add
+	testUnpause(g)
+	testExpectTimerIn(t, g, testTimeBase-15*time.Second)
+
+	// Ending the turn does not give anything back
+	g.DatetimeTurnBegin = time.Now().Add(-5 * time.Second)
+	timeSpent := g.ChargeTurnTime()
+	g.GiveTimeForTurn(g.Players[0], timeSpent)
+	if timeSpent < 50*time.Second || timeSpent > 50*time.Second+testTimeTolerance {
+		t.Errorf("The time spent on the turn is %v, expected 50s.", timeSpent)
+	}
+	testExpectClock(t, g.Players[0], testTimeBase-20*time.Second)
+}
+
+func TestPauseChargesTheActivePlayer(t *testing.T) {
+	g := testNewTimedGame(t, engine.TimeControlIncrement)
+
+	// It does not matter who paused the game; the time is taken from the player whose turn it is
+	g.ActivePlayerIndex = 1
+	testPauseAfter(g, 20*time.Second)
+	testExpectClock(t, g.Players[0], testTimeBase)
+	testExpectClock(t, g.Players[1], testTimeBase-20*time.Second)
+
+	testUnpause(g)
+	testExpectTimerIn(t, g, testTimeBase-20*time.Second)
+}
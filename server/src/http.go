@@ -33,6 +33,7 @@ type TemplateData struct {
 	// History
 	History      []*GameHistory
 	SpecificSeed bool
+	SeedAnalysis string // Used on the "Seed" page
 	Tags         map[int][]string
 
 	// Scores
@@ -44,6 +45,8 @@ type TemplateData struct {
 	NumMaxScores               int
 	TotalMaxScores             int
 	PercentageMaxScores        string
+	Ratings                    []*RatingData
+	TeamRatings                []*RatingData
 	RequestedNumPlayers        int      // Used on the "Missing Scores" page
 	NumMaxScoresPerType        []int    // Used on the "Missing Scores" page
 	PercentageMaxScoresPerType []string // Used on the "Missing Scores" page
@@ -62,6 +65,16 @@ type TemplateData struct {
 	NumStrikeouts int
 	StrikeoutRate string
 	RecentGames   []*GameHistory
+
+	// Daily Challenge
+	DailyChallengeDate         string
+	DailyChallengeVariant      string
+	DailyChallengeLeaderboards []*DailyChallengeLeaderboard
+
+	// Tournaments
+	Tournament          *Tournament
+	TournamentRounds    []*TournamentRoundResults
+	TournamentStandings []*TournamentStanding
 }
 
 const (
@@ -234,6 +247,8 @@ func httpInit() {
 	httpRouter.GET("/history/:player1/:player2/:player3/:player4", httpHistory)
 	httpRouter.GET("/history/:player1/:player2/:player3/:player4/:player5", httpHistory)
 	httpRouter.GET("/history/:player1/:player2/:player3/:player4/:player5/:player6", httpHistory)
+	httpRouter.GET("/history/:player1/:player2/:player3/:player4/:player5/:player6/:player7", httpHistory)
+	httpRouter.GET("/history/:player1/:player2/:player3/:player4/:player5/:player6/:player7/:player8", httpHistory)
 	httpRouter.GET("/missing-scores", httpMissingScores)
 	httpRouter.GET("/missing-scores/:player1", httpMissingScores)
 	httpRouter.GET("/missing-scores/:player1/:numPlayers", httpMissingScores)
@@ -244,8 +259,12 @@ func httpInit() {
 	httpRouter.GET("/shared-missing-scores/:player1/:player2/:player3/:player4", httpSharedMissingScores)
 	httpRouter.GET("/shared-missing-scores/:player1/:player2/:player3/:player4/:player5", httpSharedMissingScores)
 	httpRouter.GET("/shared-missing-scores/:player1/:player2/:player3/:player4/:player5/:player6", httpSharedMissingScores)
+	httpRouter.GET("/shared-missing-scores/:player1/:player2/:player3/:player4/:player5/:player6/:player7", httpSharedMissingScores)
+	httpRouter.GET("/shared-missing-scores/:player1/:player2/:player3/:player4/:player5/:player6/:player7/:player8", httpSharedMissingScores)
 	httpRouter.GET("/tags", httpTags)
 	httpRouter.GET("/tags/:player1", httpTags)
+	httpRouter.GET("/daily", httpDailyChallenge)
+	httpRouter.GET("/daily/:date", httpDailyChallenge)
 	httpRouter.GET("/seed", httpSeed)
 	httpRouter.GET("/seed/:seed", httpSeed) // Display all games played on a given seed
 	httpRouter.GET("/stats", httpStats)
@@ -253,13 +272,16 @@ func httpInit() {
 	httpRouter.GET("/variant/:id", httpVariant)
 	httpRouter.GET("/tag", httpTag)
 	httpRouter.GET("/tag/:tag", httpTag)
+	httpRouter.GET("/tournament/:id", httpTournament)
 	httpRouter.GET("/videos", httpVideos)
 	httpRouter.GET("/password-reset", httpPasswordReset)
 	httpRouter.POST("/password-reset", httpPasswordResetPost)
+	httpRouter.GET("/account-export", httpAccountExport)
 
 	// Path handlers for bots, developers, researchers, etc.
 	httpRouter.GET("/export", httpExport)
 	httpRouter.GET("/export/:game", httpExport)
+	apiInit(httpRouter) // The "/api/v1" routes
 
 	// Other
 	httpRouter.Static("/public", path.Join(projectPath, "public"))
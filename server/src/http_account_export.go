@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	gsessions "github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// httpAccountExport serves the archive that was generated with the "/exportdata" command
+// (see "account_export.go")
+func httpAccountExport(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	// Archives can only be downloaded by the user that they belong to
+	session := gsessions.Default(c)
+	var userID int
+	if v := session.Get("userID"); v == nil {
+		http.Error(w, "Error: You must be logged in to download your data.", http.StatusUnauthorized)
+		return
+	} else {
+		userID = v.(int)
+	}
+
+	var username string
+	if v, err := models.Users.GetUsername(userID); err == pgx.ErrNoRows {
+		http.Error(w, "Error: You must be logged in to download your data.", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		logger.Error("Failed to get the username for user "+strconv.Itoa(userID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		username = v
+	}
+
+	filePath := accountExportGetPath(userID)
+	if info, err := os.Stat(filePath); os.IsNotExist(err) {
+		http.Error(
+			w,
+			"Error: You do not have an archive to download. "+
+				"Type \"/exportdata\" in the lobby to create one.",
+			http.StatusNotFound,
+		)
+		return
+	} else if err != nil {
+		logger.Error("Failed to check if the \""+filePath+"\" file exists:", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if time.Since(info.ModTime()) > AccountExportExpiration {
+		if err := os.Remove(filePath); err != nil {
+			logger.Error("Failed to remove the expired archive \""+filePath+"\":", err)
+		}
+		http.Error(
+			w,
+			"Error: Your archive has expired. "+
+				"Type \"/exportdata\" in the lobby to create a new one.",
+			http.StatusNotFound,
+		)
+		return
+	}
+
+	// Personal data should not be stored in any caches
+	w.Header().Set("Cache-Control", "no-store")
+	c.FileAttachment(filePath, "hanab-live-"+username+"-"+time.Now().Format("2006-01-02")+".zip")
+}
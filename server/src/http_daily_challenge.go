@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/gin-gonic/gin"
+)
+
+type DailyChallengeLeaderboard struct {
+	NumPlayers int            `json:"numPlayers"`
+	Games      []*GameHistory `json:"games"`
+}
+
+func httpDailyChallenge(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	// Parse the date from the URL (it defaults to today)
+	var date time.Time
+	if dateString := c.Param("date"); dateString == "" {
+		date = getDailyChallenge().Date
+	} else if v, err := time.Parse(DailyChallengeDateFormat, dateString); err != nil {
+		http.Error(w, "Error: The date must be in the form of \"YYYY-MM-DD\".", http.StatusBadRequest)
+		return
+	} else {
+		date = v
+	}
+
+	var dc *DailyChallenge
+	if exists, v, err := models.DailyChallenges.GetVariantID(date); err != nil {
+		logger.Error("Failed to get the daily challenge for "+
+			date.Format(DailyChallengeDateFormat)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if !exists {
+		http.Error(w, "Error: There was no daily challenge on that date.", http.StatusNotFound)
+		return
+	} else if variantName, ok := getVariantNameFromID(v); !ok {
+		logger.Error("Failed to find a definition for variant " + strconv.Itoa(v) + ".")
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		dc = &DailyChallenge{
+			Date:        date,
+			VariantName: variantName,
+		}
+	}
+
+	// Each player count has its own seed and its own leaderboard
+	leaderboards := make([]*DailyChallengeLeaderboard, 0)
+	for numPlayers := engine.MinPlayers; numPlayers <= engine.MaxPlayers; numPlayers++ {
+		seed := dc.GetSeed(numPlayers)
+
+		var gameIDs []int
+		if v, err := models.Games.GetGameIDsSeed(seed); err != nil {
+			logger.Error("Failed to get the game IDs for seed \""+seed+"\":", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else {
+			gameIDs = v
+		}
+		if len(gameIDs) == 0 {
+			continue
+		}
+
+		var gameHistoryList []*GameHistory
+		if v, err := models.Games.GetHistoryCustomSort(gameIDs, DailyChallengeSort); err != nil {
+			logger.Error("Failed to get the history:", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else {
+			gameHistoryList = v
+		}
+
+		leaderboards = append(leaderboards, &DailyChallengeLeaderboard{
+			NumPlayers: numPlayers,
+			Games:      gameHistoryList,
+		})
+	}
+
+	if _, ok := c.Request.URL.Query()["api"]; ok {
+		type DailyChallengeData struct {
+			Date         string                       `json:"date"`
+			Variant      string                       `json:"variant"`
+			Leaderboards []*DailyChallengeLeaderboard `json:"leaderboards"`
+		}
+		c.JSON(http.StatusOK, &DailyChallengeData{
+			Date:         dc.Date.Format(DailyChallengeDateFormat),
+			Variant:      dc.VariantName,
+			Leaderboards: leaderboards,
+		})
+		return
+	}
+
+	data := TemplateData{
+		Title: "Daily Challenge",
+
+		DailyChallengeDate:         dc.Date.Format(DailyChallengeDateFormat),
+		DailyChallengeVariant:      dc.VariantName,
+		DailyChallengeLeaderboards: leaderboards,
+	}
+	httpServeTemplate(w, data, "daily")
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/gin-gonic/gin"
 )
 
@@ -41,11 +42,13 @@ func httpExport(c *gin.Context) {
 		return
 	}
 
-	// Get the players from the database
-	var dbPlayers []*DBPlayer
-	if v, err := models.Games.GetPlayers(gameID); err != nil {
-		logger.Error("Failed to get the players from the database for game "+
-			strconv.Itoa(gameID)+":", err)
+	// Deck specification for a particular game are not stored in the database
+	// Thus, we must recalculate the deck order based on the seed of the game
+	// Get the seed from the database
+	var seed string
+	if v, err := models.Games.GetSeed(gameID); err != nil {
+		logger.Error("Failed to get the seed for game "+
+			"\""+strconv.Itoa(gameID)+"\":", err)
 		http.Error(
 			w,
 			http.StatusText(http.StatusInternalServerError),
@@ -53,37 +56,39 @@ func httpExport(c *gin.Context) {
 		)
 		return
 	} else {
-		dbPlayers = v
-	}
-
-	// Make a list of their names
-	playerNames := make([]string, 0)
-	for _, dbP := range dbPlayers {
-		playerNames = append(playerNames, dbP.Name)
+		seed = v
 	}
 
-	// Get the options from the database
-	var options *Options
-	if v, err := models.Games.GetOptions(gameID); err != nil {
-		logger.Error("Failed to get the options from the database for game "+
-			strconv.Itoa(gameID)+":", err)
+	// The deals of tournament rounds are hidden until the round is over to prevent spoilers
+	if inProgress, err := models.TournamentRounds.IsSeedInProgress(seed); err != nil {
+		logger.Error("Failed to check to see if seed \""+seed+"\" is in a tournament:", err)
 		http.Error(
 			w,
 			http.StatusText(http.StatusInternalServerError),
 			http.StatusInternalServerError,
 		)
 		return
-	} else {
-		options = v
+	} else if inProgress {
+		http.Error(
+			w,
+			"Error: That game is from a tournament round that is still in progress.",
+			http.StatusForbidden,
+		)
+		return
 	}
 
-	// Deck specification for a particular game are not stored in the database
-	// Thus, we must recalculate the deck order based on the seed of the game
-	// Get the seed from the database
-	var seed string
-	if v, err := models.Games.GetSeed(gameID); err != nil {
-		logger.Error("Failed to get the seed for game "+
-			"\""+strconv.Itoa(gameID)+"\":", err)
+	// The deals of daily challenges are hidden until the day is over to prevent spoilers
+	if isDailyChallengeSeedHidden(seed) {
+		http.Error(
+			w,
+			"Error: That game is from a daily challenge that is still in progress.",
+			http.StatusForbidden,
+		)
+		return
+	}
+
+	var gameJSON *GameJSON
+	if v, ok := getGameJSON(gameID, seed); !ok {
 		http.Error(
 			w,
 			http.StatusText(http.StatusInternalServerError),
@@ -91,47 +96,64 @@ func httpExport(c *gin.Context) {
 		)
 		return
 	} else {
-		seed = v
+		gameJSON = v
 	}
 
-	// Make a deck and shuffle it
-	g := &Game{
-		Options:      options,
-		ExtraOptions: &ExtraOptions{},
-		Seed:         seed,
+	c.JSON(http.StatusOK, gameJSON)
+}
+
+// getGameJSON builds the JSON representation of a game from the database
+// Any errors are logged
+func getGameJSON(gameID int, seed string) (*GameJSON, bool) {
+	// Get the players from the database
+	var dbPlayers []*DBPlayer
+	if v, err := models.Games.GetPlayers(gameID); err != nil {
+		logger.Error("Failed to get the players from the database for game "+
+			strconv.Itoa(gameID)+":", err)
+		return nil, false
+	} else {
+		dbPlayers = v
+	}
+
+	// Make a list of their names
+	playerNames := make([]string, 0)
+	for _, dbP := range dbPlayers {
+		playerNames = append(playerNames, dbP.Name)
+	}
+
+	// Get the options from the database
+	var options *engine.Options
+	if v, err := models.Games.GetOptions(gameID); err != nil {
+		logger.Error("Failed to get the options from the database for game "+
+			strconv.Itoa(gameID)+":", err)
+		return nil, false
+	} else {
+		options = v
 	}
-	g.InitDeck()
-	setSeed(g.Seed) // Seed the random number generator
+
+	// Make a deck and shuffle it
+	variant := getVariant(options.VariantName)
+	g := engine.NewGame(variant, options, seed)
+	g.InitDeck(nil)
 	g.ShuffleDeck()
 
 	// Get the actions from the database
-	var actions []*GameAction
+	var actions []*engine.GameAction
 	if v, err := models.GameActions.GetAll(gameID); err != nil {
 		logger.Error("Failed to get the actions from the database for game "+
 			strconv.Itoa(gameID)+":", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
-		return
+		return nil, false
 	} else {
 		actions = v
 	}
 
 	// Get the notes from the database
-	variant := variants[g.Options.VariantName]
 	noteSize := variant.GetDeckSize() + len(variant.Suits)
 	var notes [][]string
 	if v, err := models.Games.GetNotes(gameID, len(dbPlayers), noteSize); err != nil {
 		logger.Error("Failed to get the notes from the database for game "+
 			strconv.Itoa(gameID)+":", err)
-		http.Error(
-			w,
-			http.StatusText(http.StatusInternalServerError),
-			http.StatusInternalServerError,
-		)
-		return
+		return nil, false
 	} else {
 		notes = v
 	}
@@ -151,9 +173,19 @@ func httpExport(c *gin.Context) {
 		notes = nil
 	}
 
+	// Get the annotations from the database
+	var annotations []*GameAnnotation
+	if v, err := models.GameAnnotations.GetAll(gameID); err != nil {
+		logger.Error("Failed to get the annotations from the database for game "+
+			strconv.Itoa(gameID)+":", err)
+		return nil, false
+	} else {
+		annotations = v
+	}
+
 	// If this was a game with the "Detrimental Characters" option turned on,
 	// make a list of the characters for each player
-	var characterAssignments []*CharacterAssignment
+	var characterAssignments []*engine.CharacterAssignment
 	if options.DetrimentalCharacters {
 		characterAssignments = getCharacterAssignmentsFromDBPlayers(dbPlayers)
 	}
@@ -170,11 +202,18 @@ func httpExport(c *gin.Context) {
 	if options.VariantName != "No Variant" {
 		optionsJSON.Variant = &variant.Name
 		allDefaultOptions = false
+		optionsJSON.CustomVariant = options.CustomVariant
 	}
 	if options.Timed {
 		optionsJSON.Timed = &options.Timed
 		optionsJSON.TimeBase = &options.TimeBase
 		optionsJSON.TimePerTurn = &options.TimePerTurn
+		if options.TimeControl != engine.TimeControlIncrement {
+			optionsJSON.TimeControl = &options.TimeControl
+		}
+		if options.TurnTimeLimit != 0 {
+			optionsJSON.TurnTimeLimit = &options.TurnTimeLimit
+		}
 		allDefaultOptions = false
 	}
 	if options.Speedrun {
@@ -209,21 +248,34 @@ func httpExport(c *gin.Context) {
 		optionsJSON.DetrimentalCharacters = &options.DetrimentalCharacters
 		allDefaultOptions = false
 	}
+	if options.StartingClues != engine.MaxClueNum {
+		optionsJSON.StartingClues = &options.StartingClues
+		allDefaultOptions = false
+	}
+	if options.MaxClues != engine.MaxClueNum {
+		optionsJSON.MaxClues = &options.MaxClues
+		allDefaultOptions = false
+	}
+	if options.MaxStrikes != engine.MaxStrikeNum {
+		optionsJSON.MaxStrikes = &options.MaxStrikes
+		allDefaultOptions = false
+	}
 	if allDefaultOptions {
 		optionsJSON = nil
 	}
 
 	// Create a JSON game
 	gameJSON := &GameJSON{
-		ID:         gameID,
-		Players:    playerNames,
-		Deck:       g.CardIdentities,
-		Actions:    actions,
-		Options:    optionsJSON,
-		Notes:      notes,
-		Characters: characterAssignments,
-		Seed:       seed,
+		ID:          gameID,
+		Players:     playerNames,
+		Deck:        g.CardIdentities,
+		Actions:     actions,
+		Options:     optionsJSON,
+		Notes:       notes,
+		Characters:  characterAssignments,
+		Seed:        seed,
+		Annotations: annotations,
 	}
 
-	c.JSON(http.StatusOK, gameJSON)
+	return gameJSON, true
 }
@@ -35,21 +35,27 @@ func httpLocalhostInit() {
 
 	// Path handlers
 	httpRouter.POST("/ban", httpLocalhostUserAction)
+	httpRouter.POST("/botToken", httpLocalhostBotToken)
 	httpRouter.GET("/cancel", httpLocalhostCancel)
 	httpRouter.GET("/clearEmptyTables", httpLocalhostClearEmptyTables)
 	httpRouter.GET("/debug", httpLocalhostDebug)
+	httpRouter.POST("/deleteAccount", httpLocalhostUserAction)
 	httpRouter.GET("/maintenance", httpLocalhostMaintenance)
 	httpRouter.POST("/mute", httpLocalhostUserAction)
 	httpRouter.GET("/print", httpLocalhostPrint)
+	httpRouter.GET("/rebuildRatings", httpLocalhostRebuildRatings)
 	httpRouter.GET("/restart", httpLocalhostRestart)
 	httpRouter.GET("/saveTables", httpLocalhostSaveTables)
+	httpRouter.GET("/scheduler", httpLocalhostScheduler)
 	httpRouter.POST("/sendWarning", httpLocalhostUserAction)
 	httpRouter.POST("/sendError", httpLocalhostUserAction)
 	httpRouter.GET("/shutdown", httpLocalhostShutdown)
 	httpRouter.GET("/terminate", httpLocalhostTerminate)
+	httpRouter.POST("/tournament", httpLocalhostTournament)
 	httpRouter.GET("/timeLeft", httpLocalhostTimeLeft)
 	httpRouter.GET("/uptime", httpLocalhostUptime)
 	httpRouter.GET("/version", httpLocalhostVersion)
+	httpRouter.GET("/verifyReplays", httpLocalhostVerifyReplays)
 	httpRouter.GET("/unmaintenance", httpLocalhostUnmaintenance)
 
 	// We need to create a new http.Server because the default one has no timeouts
@@ -113,6 +119,8 @@ func httpLocalhostUserAction(c *gin.Context) {
 		httpLocalhostSendWarning(c, userID)
 	} else if strings.HasPrefix(path, "/sendError") {
 		httpLocalhostSendError(c, userID)
+	} else if strings.HasPrefix(path, "/deleteAccount") {
+		httpLocalhostDeleteAccount(c, username, userID)
 	} else {
 		http.Error(w, "Error: Invalid URL.", http.StatusNotFound)
 	}
@@ -129,6 +137,11 @@ func logoutUser(userID int) {
 		return
 	}
 
+	// They should not be able to resume the session after it is closed
+	if stream := s.Stream(); stream != nil {
+		stream.End()
+	}
+
 	if err := s.Close(); err != nil {
 		logger.Error("Failed to manually close the WebSocket session for user "+
 			strconv.Itoa(userID)+":", err)
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APITokenLength = 32 // In bytes
+)
+
+// httpLocalhostBotToken creates a new bot account (or converts an existing account to a bot account)
+// and gives it a new API token
+// Any previous API token for the account is invalidated
+// The token is only displayed once, since we only store a hash of it
+func httpLocalhostBotToken(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	// Validate the username
+	username := c.PostForm("username")
+	if username == "" {
+		http.Error(w, "Error: You must specify a username.", http.StatusBadRequest)
+		return
+	}
+
+	// Check to see if this username exists in the database
+	var exists bool
+	var user User
+	if v1, v2, err := models.Users.Get(username); err != nil {
+		logger.Error("Failed to get user \""+username+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		exists = v1
+		user = v2
+	}
+
+	if !exists {
+		// Prevent username-spoofing attacks in the same way that we do for humans in "httpLogin()"
+		normalizedUsername := normalizeString(username)
+		if normalizedExists, similarUsername, err := models.Users.NormalizedUsernameExists(
+			normalizedUsername,
+		); err != nil {
+			logger.Error("Failed to check for normalized username uniqueness for "+
+				"\""+username+"\":", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else if normalizedExists {
+			c.String(http.StatusOK, "The username of \""+username+"\" is too similar to the "+
+				"existing user of \""+similarUsername+"\".\n")
+			return
+		}
+
+		if v, err := models.Users.InsertBot(username, normalizedUsername, "127.0.0.1"); err != nil {
+			logger.Error("Failed to insert bot \""+username+"\":", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else {
+			user = v
+		}
+	}
+
+	var apiToken string
+	if v, err := generateAPIToken(); err != nil {
+		logger.Error("Failed to generate an API token:", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		apiToken = v
+	}
+
+	if err := models.Users.UpdateAPIToken(user.ID, hashAPIToken(apiToken)); err != nil {
+		logger.Error("Failed to set the API token for \""+username+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	// If the bot is currently online, it needs to reconnect with the new token
+	logoutUser(user.ID)
+
+	if exists && !user.Bot {
+		logger.Info("Converted user \"" + user.Username + "\" to a bot account.")
+	}
+	c.String(http.StatusOK, apiToken+"\n")
+}
+
+func generateAPIToken() (string, error) {
+	bytes := make([]byte, APITokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func hashAPIToken(apiToken string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(apiToken)))
+}
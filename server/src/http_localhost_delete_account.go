@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func httpLocalhostDeleteAccount(c *gin.Context, username string, userID int) {
+	// Local variables
+	w := c.Writer
+
+	if msg, err := deleteAccount(userID, username); err != nil {
+		logger.Error("Failed to delete the account for user \""+username+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if msg != "" {
+		c.String(http.StatusOK, msg+"\n")
+		return
+	}
+
+	c.String(http.StatusOK, "success\n")
+}
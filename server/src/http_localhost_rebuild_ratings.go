@@ -0,0 +1,23 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// httpLocalhostRebuildRatings recalculates the rating of every player from the game history
+// This is done in the background since it takes much longer than the HTTP write timeout
+func httpLocalhostRebuildRatings(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	if !rebuildingRatings.SetToIf(false, true) {
+		http.Error(w, "The ratings are already being rebuilt.", http.StatusBadRequest)
+		return
+	}
+	go rebuildRatings()
+
+	c.String(http.StatusOK, "Rebuilding the ratings in the background. "+
+		"Check the log for the results.\n")
+}
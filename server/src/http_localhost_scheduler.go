@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// httpLocalhostScheduler lists every pending deadline, ordered by when it will fire
+func httpLocalhostScheduler(c *gin.Context) {
+	tasks := scheduler.GetTasks()
+	if len(tasks) == 0 {
+		c.String(http.StatusOK, "There are no scheduled tasks.\n")
+		return
+	}
+
+	msg := ""
+	for _, task := range tasks {
+		timeLeft := time.Until(task.Deadline).Round(time.Millisecond)
+		msg += "Table " + strconv.FormatUint(task.Table.ID, 10) + " - " + task.Name + " - " +
+			timeLeft.String() + " (" + task.Deadline.Format(time.RFC3339) + ")\n"
+	}
+
+	c.String(http.StatusOK, msg)
+}
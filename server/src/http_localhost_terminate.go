@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/gin-gonic/gin"
 )
 
@@ -48,9 +49,9 @@ func httpLocalhostTerminate(c *gin.Context) {
 	s := t.GetOwnerSession()
 	commandAction(s, &CommandData{ // Manual invocation
 		TableID: t.ID,
-		Type:    ActionTypeEndGame,
+		Type:    engine.ActionTypeEndGame,
 		Target:  -1,
-		Value:   EndConditionTerminated,
+		Value:   engine.EndConditionTerminated,
 		NoLock:  true,
 	})
 }
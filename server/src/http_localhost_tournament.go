@@ -0,0 +1,364 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/gin-gonic/gin"
+)
+
+// httpLocalhostTournament is used by the "tournament.sh" script to run a tournament
+func httpLocalhostTournament(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	action := c.PostForm("action")
+	if action == "create" {
+		httpLocalhostTournamentCreate(c)
+		return
+	}
+
+	// All of the other actions apply to an existing tournament
+	var tournamentID int
+	if v, err := strconv.Atoi(c.PostForm("tournamentID")); err != nil {
+		http.Error(w, "Error: The tournament ID must be a number.", http.StatusBadRequest)
+		return
+	} else {
+		tournamentID = v
+	}
+
+	var tournament *Tournament
+	if exists, v, err := models.Tournaments.Get(tournamentID); err != nil {
+		logger.Error("Failed to get tournament "+strconv.Itoa(tournamentID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if !exists {
+		c.String(http.StatusOK, "Tournament "+strconv.Itoa(tournamentID)+" does not exist.\n")
+		return
+	} else {
+		tournament = v
+	}
+
+	if tournament.DatetimeFinished != nil {
+		c.String(http.StatusOK, "Tournament "+strconv.Itoa(tournamentID)+" is already finished.\n")
+		return
+	}
+
+	if action == "register" {
+		httpLocalhostTournamentRegister(c, tournament)
+	} else if action == "startRound" {
+		httpLocalhostTournamentStartRound(c, tournament)
+	} else if action == "closeRound" {
+		httpLocalhostTournamentCloseRound(c, tournament)
+	} else if action == "finish" {
+		httpLocalhostTournamentFinish(c, tournament)
+	} else {
+		http.Error(w, "Error: That is not a valid tournament action.", http.StatusBadRequest)
+	}
+}
+
+func httpLocalhostTournamentCreate(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	name := strings.TrimSpace(c.PostForm("name"))
+	if name == "" {
+		http.Error(w, "Error: You must specify a tournament name.", http.StatusBadRequest)
+		return
+	}
+
+	variantName := c.PostForm("variant")
+	var variant *engine.Variant
+	if v := getVariant(variantName); v == nil {
+		http.Error(w, "Error: \""+variantName+"\" is not a valid variant.", http.StatusBadRequest)
+		return
+	} else {
+		variant = v
+	}
+
+	var numPlayers int
+	if v, err := strconv.Atoi(c.PostForm("numPlayers")); err != nil {
+		http.Error(w, "Error: The number of players must be a number.", http.StatusBadRequest)
+		return
+	} else {
+		numPlayers = v
+	}
+	if numPlayers < engine.MinPlayers || numPlayers > engine.MaxPlayers {
+		http.Error(w, "Error: The number of players must be between "+
+			strconv.Itoa(engine.MinPlayers)+" and "+strconv.Itoa(engine.MaxPlayers)+".",
+			http.StatusBadRequest)
+		return
+	}
+
+	var tournamentID int
+	if v, err := models.Tournaments.Insert(name, variant.ID, numPlayers); err != nil {
+		logger.Error("Failed to insert the tournament \""+name+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		tournamentID = v
+	}
+
+	c.String(http.StatusOK, "Created tournament "+strconv.Itoa(tournamentID)+".\n")
+}
+
+func httpLocalhostTournamentRegister(c *gin.Context, tournament *Tournament) {
+	// Local variables
+	w := c.Writer
+
+	teamName := strings.TrimSpace(c.PostForm("team"))
+	if teamName == "" {
+		http.Error(w, "Error: You must specify a team name.", http.StatusBadRequest)
+		return
+	}
+
+	if exists, err := models.TournamentTeams.Exists(tournament.ID, teamName); err != nil {
+		logger.Error("Failed to check to see if team \""+teamName+"\" exists:", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if exists {
+		c.String(http.StatusOK, "Team \""+teamName+"\" is already registered.\n")
+		return
+	}
+
+	// The players are specified as a comma-separated list of usernames
+	usernames := strings.Split(c.PostForm("players"), ",")
+	if len(usernames) != tournament.NumPlayers {
+		c.String(http.StatusOK, "This tournament is for teams of "+
+			strconv.Itoa(tournament.NumPlayers)+" players.\n")
+		return
+	}
+
+	userIDs := make([]int, 0)
+	for _, username := range usernames {
+		username = strings.TrimSpace(username)
+
+		var userID int
+		if exists, v, err := models.Users.Get(username); err != nil {
+			logger.Error("Failed to get user \""+username+"\":", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else if !exists {
+			c.String(http.StatusOK, "User \""+username+"\" does not exist in the database.\n")
+			return
+		} else {
+			userID = v.ID
+		}
+
+		for _, otherUserID := range userIDs {
+			if otherUserID == userID {
+				c.String(http.StatusOK, "User \""+username+"\" is listed more than once.\n")
+				return
+			}
+		}
+
+		if isRegistered, err := models.TournamentTeamMembers.IsRegistered(
+			tournament.ID,
+			userID,
+		); err != nil {
+			logger.Error("Failed to check to see if \""+username+"\" is registered in "+
+				"tournament "+strconv.Itoa(tournament.ID)+":", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else if isRegistered {
+			c.String(http.StatusOK, "User \""+username+"\" is already on a team in this "+
+				"tournament.\n")
+			return
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	var teamID int
+	if v, err := models.TournamentTeams.Insert(tournament.ID, teamName); err != nil {
+		logger.Error("Failed to insert team \""+teamName+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		teamID = v
+	}
+
+	if err := models.TournamentTeamMembers.BulkInsert(teamID, userIDs); err != nil {
+		logger.Error("Failed to insert the members of team \""+teamName+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	// The new team should appear in the standings right away
+	if err := models.TournamentStandings.Update(tournament.ID); err != nil {
+		logger.Error("Failed to update the standings for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	c.String(http.StatusOK, "success\n")
+}
+
+func httpLocalhostTournamentStartRound(c *gin.Context, tournament *Tournament) {
+	// Local variables
+	w := c.Writer
+
+	var round int
+	if v1, v2, err := models.TournamentRounds.GetCurrent(tournament.ID); err != nil {
+		logger.Error("Failed to get the current round for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if v2 {
+		c.String(http.StatusOK, "Round "+strconv.Itoa(v1)+" is still in progress.\n")
+		return
+	} else {
+		round = v1 + 1
+	}
+
+	// Every team plays the same seed, which is reserved so that it cannot be played with "!seed"
+	// (e.g. "p2v0st1r1")
+	variant := getVariant(tournament.VariantName)
+	seed := "p" + strconv.Itoa(tournament.NumPlayers) +
+		"v" + strconv.Itoa(variant.ID) +
+		"s" + getTournamentSeedSuffix(tournament.ID, round)
+
+	if err := models.TournamentRounds.Insert(tournament.ID, round, seed); err != nil {
+		logger.Error("Failed to insert round "+strconv.Itoa(round)+" for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	msg := "Round " + strconv.Itoa(round) + " of the tournament \"" + tournament.Name + "\" " +
+		"has started. Participants can play it by creating a table named: " +
+		"!tournament " + strconv.Itoa(tournament.ID)
+	chatServerSendAll(msg)
+
+	c.String(http.StatusOK, "Started round "+strconv.Itoa(round)+" with seed \""+seed+"\".\n")
+}
+
+func httpLocalhostTournamentCloseRound(c *gin.Context, tournament *Tournament) {
+	// Local variables
+	w := c.Writer
+
+	var round int
+	if v1, v2, err := models.TournamentRounds.GetCurrent(tournament.ID); err != nil {
+		logger.Error("Failed to get the current round for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if !v2 {
+		c.String(http.StatusOK, "There is no round in progress.\n")
+		return
+	} else {
+		round = v1
+	}
+
+	if err := models.TournamentRounds.Close(tournament.ID, round); err != nil {
+		logger.Error("Failed to close round "+strconv.Itoa(round)+" for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	if err := models.TournamentStandings.Update(tournament.ID); err != nil {
+		logger.Error("Failed to update the standings for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	protocol := "http"
+	if useTLS {
+		protocol += "s"
+	}
+	msg := "Round " + strconv.Itoa(round) + " of the tournament \"" + tournament.Name + "\" " +
+		"is over. The standings are available at: " +
+		protocol + "://" + domain + "/tournament/" + strconv.Itoa(tournament.ID)
+	chatServerSendAll(msg)
+
+	c.String(http.StatusOK, "success\n")
+}
+
+func httpLocalhostTournamentFinish(c *gin.Context, tournament *Tournament) {
+	// Local variables
+	w := c.Writer
+
+	if _, open, err := models.TournamentRounds.GetCurrent(tournament.ID); err != nil {
+		logger.Error("Failed to get the current round for tournament "+
+			strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if open {
+		c.String(http.StatusOK, "You must close the current round before finishing the "+
+			"tournament.\n")
+		return
+	}
+
+	if err := models.Tournaments.SetFinished(tournament.ID); err != nil {
+		logger.Error("Failed to finish tournament "+strconv.Itoa(tournament.ID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+
+	c.String(http.StatusOK, "success\n")
+}
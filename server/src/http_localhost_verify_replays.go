@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// httpLocalhostVerifyReplays re-simulates games from the database and reports the games whose
+// stored score, end condition, or number of turns do not match the simulation
+// If a "gameID" query parameter is provided, only that game is verified and the result is returned
+// Otherwise, every game is verified in the background and the results are written to the log
+// (since it takes much longer than the HTTP write timeout)
+func httpLocalhostVerifyReplays(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	gameIDString := c.Query("gameID")
+	if gameIDString == "" {
+		if !verifyingReplays.SetToIf(false, true) {
+			http.Error(w, "The replays are already being verified.", http.StatusBadRequest)
+			return
+		}
+		go verifyAllReplays()
+		c.String(http.StatusOK, "Verifying every replay in the background. "+
+			"Check the log for the results.\n")
+		return
+	}
+
+	var gameID int
+	if v, err := strconv.Atoi(gameIDString); err != nil {
+		http.Error(w, "Error: The game ID must be a number.", http.StatusBadRequest)
+		return
+	} else {
+		gameID = v
+	}
+
+	var rv *ReplayVerification
+	if v, err := verifyReplay(gameID); err != nil {
+		logger.Error("Failed to load game "+strconv.Itoa(gameID)+" from the database:", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		rv = v
+	}
+
+	c.String(http.StatusOK, rv.String()+"\n")
+}
@@ -58,6 +58,37 @@ func httpLogin(c *gin.Context) {
 	}
 
 	if exists {
+		// Bot accounts do not have a password
+		// (they skip this function entirely and connect to "/ws" with an API token)
+		if user.Bot {
+			http.Error(
+				w,
+				"That is a bot account, so it must log in with an API token.",
+				http.StatusUnauthorized,
+			)
+			return
+		}
+
+		// Deleted accounts do not have a password either
+		// (the password hash is removed when the account is anonymized)
+		var deleted bool
+		if v, err := models.Users.IsDeleted(user.ID); err != nil {
+			logger.Error("Failed to check to see if user \""+data.Username+"\" is deleted:", err)
+			http.Error(
+				w,
+				http.StatusText(http.StatusInternalServerError),
+				http.StatusInternalServerError,
+			)
+			return
+		} else {
+			deleted = v
+		}
+		if deleted {
+			logger.Info("User \"" + data.Username + "\" tried to log in to a deleted account.")
+			http.Error(w, "That account was deleted.", http.StatusUnauthorized)
+			return
+		}
+
 		// First, check to see if they have a a legacy password hash stored in the database
 		if user.OldPasswordHash.Valid {
 			// This is the first time that they are logging in after the password hash transition
@@ -3,10 +3,19 @@ package main
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// RatingData is used to show a player rating or a team rating on the "Scores" page
+type RatingData struct {
+	Names        string // Only used for team ratings
+	VariantClass string
+	Rating       string
+	NumGames     int
+}
+
 type UserVariantStats struct {
 	ID            int
 	Name          string
@@ -107,6 +116,46 @@ func httpScores(c *gin.Context) {
 		statsMap = v
 	}
 
+	// Get their ratings
+	ratings := make([]*RatingData, 0)
+	if v, err := models.UserRatings.GetAll(user.ID); err != nil {
+		logger.Error("Failed to get the ratings for player \""+user.Username+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		for _, rating := range v {
+			ratings = append(ratings, &RatingData{
+				VariantClass: ratingClassNames[rating.VariantClass],
+				Rating:       rating.String(),
+				NumGames:     rating.NumGames,
+			})
+		}
+	}
+
+	teamRatings := make([]*RatingData, 0)
+	if v, err := models.TeamRatings.GetAllForUser(user.ID); err != nil {
+		logger.Error("Failed to get the team ratings for player \""+user.Username+"\":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		for _, teamRating := range v {
+			teamRatings = append(teamRatings, &RatingData{
+				Names:        strings.Join(teamRating.Names, ", "),
+				VariantClass: ratingClassNames[teamRating.VariantClass],
+				Rating:       teamRating.String(),
+				NumGames:     teamRating.NumGames,
+			})
+		}
+	}
+
 	numMaxScores, numMaxScoresPerType, variantStatsList := httpGetVariantStatsList(statsMap)
 	percentageMaxScoresString, percentageMaxScoresPerType := httpGetPercentageMaxScores(
 		numMaxScores,
@@ -122,8 +171,10 @@ func httpScores(c *gin.Context) {
 		NumGamesSpeedrun:           profileStats.NumGamesSpeedrun,
 		TimePlayedSpeedrun:         timePlayedSpeedrun,
 		NumMaxScores:               numMaxScores,
-		TotalMaxScores:             len(variantNames) * 5, // For 2 to 6 players
+		TotalMaxScores:             len(variantNames) * NumBestScores, // For every amount of players
 		PercentageMaxScores:        percentageMaxScoresString,
+		Ratings:                    ratings,
+		TeamRatings:                teamRatings,
 		NumMaxScoresPerType:        numMaxScoresPerType,
 		PercentageMaxScoresPerType: percentageMaxScoresPerType,
 
@@ -17,6 +17,34 @@ func httpSeed(c *gin.Context) {
 		return
 	}
 
+	// Seeds of tournament rounds are hidden until the round is over to prevent spoilers
+	if inProgress, err := models.TournamentRounds.IsSeedInProgress(seed); err != nil {
+		logger.Error("Failed to check to see if seed \""+seed+"\" is in a tournament:", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if inProgress {
+		http.Error(
+			w,
+			"Error: That seed is being played in a tournament round that is still in progress.",
+			http.StatusForbidden,
+		)
+		return
+	}
+
+	// Seeds of daily challenges are hidden until the day is over to prevent spoilers
+	if isDailyChallengeSeedHidden(seed) {
+		http.Error(
+			w,
+			"Error: That seed is a daily challenge, so it will be shown after the day is over.",
+			http.StatusForbidden,
+		)
+		return
+	}
+
 	// Get the list of game IDs played on this seed
 	var gameIDs []int
 	if v, err := models.Games.GetGameIDsSeed(seed); err != nil {
@@ -51,11 +79,29 @@ func httpSeed(c *gin.Context) {
 		return
 	}
 
+	// Show whether the deal is winnable with perfect information
+	// (we only analyze seeds that have been played to prevent people from making the server run the
+	// solver on arbitrary strings)
+	// The solver is never run in the request itself
+	var seedAnalysisDescription string
+	if len(gameHistoryList) > 0 {
+		if v, ok, err := getCachedSeedAnalysis(seed); err != nil {
+			logger.Error("Failed to get the analysis for seed \""+seed+"\":", err)
+		} else if ok && v == nil {
+			seedAnalysisDescription = "This deal is being analyzed. " +
+				"Refresh the page to see the result."
+		} else if ok {
+			variant := getVariant(gameHistoryList[0].Options.VariantName)
+			seedAnalysisDescription = v.Description(variant) + " (with the default game options)"
+		}
+	}
+
 	data := TemplateData{
 		Title:        "History",
 		History:      gameHistoryList,
 		NamesTitle:   "seed: " + seed,
 		SpecificSeed: true,
+		SeedAnalysis: seedAnalysisDescription,
 	}
 	httpServeTemplate(w, data, "profile", "history")
 }
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/gin-gonic/gin"
 )
 
@@ -51,7 +52,7 @@ func httpParsePlayerNames(c *gin.Context) ([]int, []string, bool) {
 	playerIDs := make([]int, 0)
 	playerNames := make([]string, 0)
 	playerNormalizedNames := make([]string, 0)
-	for i := 1; i <= 6; i++ {
+	for i := 1; i <= engine.MaxPlayers; i++ {
 		player := c.Param("player" + strconv.Itoa(i))
 		if player == "" {
 			if i == 1 {
@@ -108,11 +109,11 @@ func httpGetVariantStatsList(statsMap map[int]*UserStatsRow) (int, []int, []*Use
 	// Convert the map (statsMap) to a slice (variantStatsList),
 	// filling in any non-played variants with 0 values
 	numMaxScores := 0
-	numMaxScoresPerType := make([]int, 5) // For 2-player, 3-player, etc.
+	numMaxScoresPerType := make([]int, NumBestScores) // For 2-player, 3-player, etc.
 	variantStatsList := make([]*UserVariantStats, 0)
 	for _, name := range variantNames {
 		variant := variants[name]
-		maxScore := len(variant.Suits) * PointsPerSuit
+		maxScore := len(variant.Suits) * engine.PointsPerSuit
 		variantStats := &UserVariantStats{
 			ID:       variant.ID,
 			Name:     name,
@@ -170,8 +171,9 @@ func httpGetPercentageMaxScores(numMaxScores int, numMaxScoresPerType []int) (st
 		percentageMaxScoresPerType = append(percentageMaxScoresPerType, percentageString)
 	}
 
-	percentageMaxScores := float64(numMaxScores) / float64(len(variantNames)*5) * 100
-	// (we multiply by 5 because there are max scores for 2 to 6 players)
+	percentageMaxScores := float64(numMaxScores) / float64(len(variantNames)*NumBestScores) * 100
+	// (we multiply by the number of best scores because there is a max score for each amount
+	// of players)
 	percentageMaxScoresString := fmt.Sprintf("%.1f", percentageMaxScores)
 	percentageMaxScoresString = strings.TrimSuffix(percentageMaxScoresString, ".0")
 
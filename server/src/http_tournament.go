@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TournamentRoundResults struct {
+	*TournamentRound
+	// The results of a round are hidden until the round is over
+	Games []*TournamentGame `json:"games"`
+}
+
+func httpTournament(c *gin.Context) {
+	// Local variables
+	w := c.Writer
+
+	// Parse the tournament ID from the URL
+	var tournamentID int
+	if v, err := strconv.Atoi(c.Param("id")); err != nil {
+		http.Error(w, "Error: The tournament ID must be a number.", http.StatusBadRequest)
+		return
+	} else {
+		tournamentID = v
+	}
+
+	var tournament *Tournament
+	if exists, v, err := models.Tournaments.Get(tournamentID); err != nil {
+		logger.Error("Failed to get tournament "+strconv.Itoa(tournamentID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else if !exists {
+		http.Error(w, "Error: That tournament does not exist.", http.StatusNotFound)
+		return
+	} else {
+		tournament = v
+	}
+
+	var rounds []*TournamentRound
+	if v, err := models.TournamentRounds.GetAll(tournamentID); err != nil {
+		logger.Error("Failed to get the rounds for tournament "+
+			strconv.Itoa(tournamentID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		rounds = v
+	}
+
+	var tournamentGames []*TournamentGame
+	if v, err := models.TournamentGames.GetAll(tournamentID); err != nil {
+		logger.Error("Failed to get the games for tournament "+
+			strconv.Itoa(tournamentID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		tournamentGames = v
+	}
+
+	var standings []*TournamentStanding
+	if v, err := models.TournamentStandings.GetAll(tournamentID); err != nil {
+		logger.Error("Failed to get the standings for tournament "+
+			strconv.Itoa(tournamentID)+":", err)
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
+		return
+	} else {
+		standings = v
+	}
+
+	roundResults := make([]*TournamentRoundResults, 0)
+	for _, round := range rounds {
+		results := &TournamentRoundResults{
+			TournamentRound: round,
+			Games:           make([]*TournamentGame, 0),
+		}
+		if round.DatetimeClosed != nil {
+			for _, tournamentGame := range tournamentGames {
+				if tournamentGame.Round == round.Round {
+					results.Games = append(results.Games, tournamentGame)
+				}
+			}
+		}
+		roundResults = append(roundResults, results)
+	}
+
+	if _, ok := c.Request.URL.Query()["api"]; ok {
+		type TournamentData struct {
+			*Tournament
+			Rounds    []*TournamentRoundResults `json:"rounds"`
+			Standings []*TournamentStanding     `json:"standings"`
+		}
+		c.JSON(http.StatusOK, &TournamentData{
+			Tournament: tournament,
+			Rounds:     roundResults,
+			Standings:  standings,
+		})
+		return
+	}
+
+	data := TemplateData{
+		Title: "Tournament",
+
+		Tournament:          tournament,
+		TournamentRounds:    roundResults,
+		TournamentStandings: standings,
+	}
+	httpServeTemplate(w, data, "tournament")
+}
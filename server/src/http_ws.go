@@ -4,6 +4,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -30,6 +31,8 @@ var (
 // and then the user's website data will be initialized in "websocketConnect.go"
 // If anything fails in this function, we want to delete the user's cookie in order to force them to
 // start authentication from the beginning
+// Bot accounts skip part 1 entirely and instead provide an API token in the "Authorization" header
+// (e.g. "Authorization: Bearer [token]")
 func httpWS(c *gin.Context) {
 	// Local variables
 	r := c.Request
@@ -47,6 +50,41 @@ func httpWS(c *gin.Context) {
 
 	logger.Debug("Entered the \"httpWS()\" function for IP: " + ip)
 
+	// Negotiate the version of the WebSocket protocol (see "protocol.go")
+	// This is checked before authentication so that the cookie is not deleted
+	var protocolVersion int
+	if v, ok := protocolParseVersion(c); !ok {
+		logger.Info("IP \"" + ip + "\" tried to establish a WebSocket connection with an " +
+			"unsupported protocol version of \"" + c.Query("protocolVersion") + "\".")
+		http.Error(
+			w,
+			"Unsupported protocol version. This server supports versions "+
+				strconv.Itoa(MinProtocolVersion)+" through "+strconv.Itoa(ProtocolVersion)+".",
+			http.StatusBadRequest,
+		)
+		return
+	} else {
+		protocolVersion = v
+	}
+
+	// Clients that use sequence numbers can ask to resume their previous session
+	// (see "websocket_resume.go")
+	var resumeStreamID, resumeLastSeq uint64
+	if c.Query("resume") != "" {
+		if v, err := strconv.ParseUint(c.Query("resume"), 10, 64); err != nil {
+			http.Error(w, "The \"resume\" parameter must be a number.", http.StatusBadRequest)
+			return
+		} else {
+			resumeStreamID = v
+		}
+		if v, err := strconv.ParseUint(c.Query("lastSeq"), 10, 64); err != nil {
+			http.Error(w, "The \"lastSeq\" parameter must be a number.", http.StatusBadRequest)
+			return
+		} else {
+			resumeLastSeq = v
+		}
+	}
+
 	// Check to see if their IP is banned
 	if banned, err := models.BannedIPs.Check(ip); err != nil {
 		msg := "Failed to check to see if the IP \"" + ip + "\" is banned:"
@@ -75,16 +113,42 @@ func httpWS(c *gin.Context) {
 		muted = v
 	}
 
-	// If they have a valid cookie, it should have the "userID" value that we set in "httpLogin()"
-	session := gsessions.Default(c)
 	var userID int
-	if v := session.Get("userID"); v == nil {
-		msg := "Unauthorized WebSocket handshake detected from \"" + ip + "\". " +
-			"This likely means that their cookie has expired."
-		httpWSDeny(c, msg)
-		return
+	bot := false
+	if apiToken := getAPITokenFromHeader(r); apiToken != "" {
+		// This is a bot account
+		if exists, user, err := models.Users.GetFromAPIToken(hashAPIToken(apiToken)); err != nil {
+			msg := "Failed to get the user for an API token from \"" + ip + "\":"
+			httpWSError(c, msg, err)
+			return
+		} else if !exists {
+			msg := "WebSocket handshake with an invalid API token detected from \"" + ip + "\"."
+			httpWSDeny(c, msg)
+			return
+		} else {
+			userID = user.ID
+			bot = true
+		}
+
+		// Update the database with "datetime_last_login" and "last_ip"
+		// (for humans, this happens in "httpLogin()")
+		if err := models.Users.Update(userID, ip); err != nil {
+			msg := "Failed to set the login values for user " + strconv.Itoa(userID) + ":"
+			httpWSError(c, msg, err)
+			return
+		}
 	} else {
-		userID = v.(int)
+		// If they have a valid cookie, it should have the "userID" value that we set in
+		// "httpLogin()"
+		session := gsessions.Default(c)
+		if v := session.Get("userID"); v == nil {
+			msg := "Unauthorized WebSocket handshake detected from \"" + ip + "\". " +
+				"This likely means that their cookie has expired."
+			httpWSDeny(c, msg)
+			return
+		} else {
+			userID = v.(int)
+		}
 	}
 
 	// Get the username for this user
@@ -107,6 +171,18 @@ func httpWS(c *gin.Context) {
 		username = v
 	}
 
+	// Deleted accounts keep their row in the database, so the cookie must be rejected explicitly
+	if deleted, err := models.Users.IsDeleted(userID); err != nil {
+		msg := "Failed to check to see if user " + strconv.Itoa(userID) + " is deleted:"
+		httpWSError(c, msg, err)
+		return
+	} else if deleted {
+		msg := "User from \"" + ip + "\" tried to login with a cookie for the deleted user ID " +
+			"of " + strconv.Itoa(userID) + ". Deleting their cookie."
+		httpWSDeny(c, msg)
+		return
+	}
+
 	// Get their friends and reverse friends
 	var friendsMap map[int]struct{}
 	if v, err := models.UserFriends.GetMap(userID); err != nil {
@@ -149,6 +225,13 @@ func httpWS(c *gin.Context) {
 	keys["friends"] = friendsMap
 	keys["reverseFriends"] = reverseFriendsMap
 	keys["hyphenated"] = hyphenated
+	keys["bot"] = bot
+	keys["protocolVersion"] = protocolVersion
+	keys["resumeStreamID"] = resumeStreamID
+	keys["resumeLastSeq"] = resumeLastSeq
+	if bot {
+		keys["rateLimitAllowance"] = BotRateLimitRate
+	}
 
 	// Validation succeeded; establish the WebSocket connection
 	// "HandleRequestWithKeys()" will call the "websocketConnect()" function if successful;
@@ -207,9 +290,25 @@ func defaultSessionKeys() map[string]interface{} {
 	keys["hyphenated"] = false
 	keys["inactive"] = false
 	keys["fakeUser"] = false
+	keys["bot"] = false
 	keys["rateLimitAllowance"] = RateLimitRate
 	keys["rateLimitLastCheck"] = time.Now()
 	keys["banned"] = false
+	keys["protocolVersion"] = MinProtocolVersion
+	keys["stream"] = (*SessionStream)(nil) // Created in "websocketConnect()"
+	keys["resumeStreamID"] = uint64(0)
+	keys["resumeLastSeq"] = uint64(0)
 
 	return keys
 }
+
+// getAPITokenFromHeader returns the API token from an "Authorization: Bearer [token]" header,
+// or an empty string if there is no such header
+func getAPITokenFromHeader(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	prefix := "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(header, prefix))
+}
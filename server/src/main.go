@@ -84,6 +84,18 @@ func main() {
 		return
 	}
 
+	// Check to see if the "account_exports" directory exists
+	accountExportsPath = path.Join(dataPath, "account_exports")
+	if _, err := os.Stat(accountExportsPath); os.IsNotExist(err) {
+		if err2 := os.MkdirAll(accountExportsPath, 0700); err2 != nil {
+			logger.Fatal("Failed to create the \""+accountExportsPath+"\" directory:", err2)
+			return
+		}
+	} else if err != nil {
+		logger.Fatal("Failed to check if the \""+accountExportsPath+"\" file exists:", err)
+		return
+	}
+
 	// Check to see if the "specific_deals" directory exists
 	specificDealsPath = path.Join(dataPath, "specific_deals")
 	if _, err := os.Stat(tablesPath); os.IsNotExist(err) {
@@ -167,9 +179,6 @@ func main() {
 	suitsInit()    // (in "suits.go")
 	variantsInit() // (in "variants.go")
 
-	// Initialize the action functions command map (in "command_action.go")
-	actionsFunctionsInit()
-
 	// Initialize the replay action functions command map (in "command_replay_action.go")
 	replayActionsFunctionsInit()
 
@@ -188,6 +197,12 @@ func main() {
 	// Initialize chat commands (in "chatCommand.go")
 	chatCommandInit()
 
+	// Publish the daily challenge (in "daily_challenge.go")
+	dailyChallengeInit()
+
+	// Validate the variants that can be chosen by the matchmaking queue (in "matchmaking.go")
+	matchmakingInit()
+
 	// Record the time that the server started
 	datetimeStarted = time.Now()
 
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+// TestMain sets up the parts of the server that do not need a database or a network connection
+func TestMain(m *testing.M) {
+	logger = NewLogger()
+
+	dataPath = path.Join("..", "..", "data")
+	colorsInit()
+	suitsInit()
+	variantsInit()
+
+	os.Exit(m.Run())
+}
+
+// testAddTable creates a table and adds it to the map in the same way that "commandTableCreate()"
+// does
+func testAddTable(t *testing.T) *Table {
+	t.Helper()
+
+	table := NewTable("test", 1)
+	tablesMutex.Lock()
+	tables[table.ID] = table
+	tablesMutex.Unlock()
+
+	t.Cleanup(func() {
+		scheduler.CancelTable(table)
+		tablesMutex.Lock()
+		delete(tables, table.ID)
+		tablesMutex.Unlock()
+	})
+
+	return table
+}
+
+// testInitDatabase connects to the database for the tests that need one
+// The tests are skipped unless the "TEST_DB_NAME" environment variable is set to the name of a
+// database that was created with the "install/database_schema.sql" file
+// (the other connection settings are read in the same way as they are for the server)
+// Never point it at a database with real data in it
+func testInitDatabase(t *testing.T) {
+	t.Helper()
+
+	testDBName := os.Getenv("TEST_DB_NAME")
+	if testDBName == "" {
+		t.Skip("TEST_DB_NAME is not set.")
+	}
+
+	if models != nil {
+		return
+	}
+
+	if err := os.Setenv("DB_NAME", testDBName); err != nil {
+		t.Fatal("Failed to set the database name:", err)
+	}
+	if v, err := modelsInit(); err != nil {
+		t.Fatal("Failed to connect to the database:", err)
+	} else {
+		models = v
+	}
+}
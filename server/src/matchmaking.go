@@ -0,0 +1,420 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const (
+	// Players in the queue can ask for any class by using this name
+	MatchmakingAnyClass = "any"
+
+	// The amount of time that the players have to confirm that they are ready once a match is found
+	MatchmakingReadyCheckTimeout = 30 * time.Second
+
+	// The time controls that are used for timed matchmaking games
+	// (these are the same as the defaults on the "Create a New Game" form)
+	MatchmakingTimeBase    = 120 // In seconds
+	MatchmakingTimePerTurn = 10  // In seconds
+)
+
+var (
+	// Players in the queue choose a class of variants instead of a specific variant
+	// When a match is found, a random variant is chosen from the class
+	matchmakingVariantClasses = map[string][]string{
+		"basic": {
+			"No Variant",
+			"6 Suits",
+		},
+		"easy": {
+			"Rainbow (5 Suits)",
+			"Pink (5 Suits)",
+			"White (5 Suits)",
+			"Brown (5 Suits)",
+			"Rainbow (6 Suits)",
+			"Pink (6 Suits)",
+			"Black (6 Suits)",
+		},
+		"hard": {
+			"Omni (5 Suits)",
+			"Null (5 Suits)",
+			"Muddy Rainbow (5 Suits)",
+			"Light Pink (5 Suits)",
+			"Prism (5 Suits)",
+			"Dark Rainbow (6 Suits)",
+			"Gray (6 Suits)",
+		},
+	}
+
+	matchmakingQueue      = make([]*MatchmakingEntry, 0) // Ordered by the time that they joined
+	matchmakingQueueMutex = sync.Mutex{}
+)
+
+// MatchmakingEntry is a player that is waiting in the matchmaking queue
+type MatchmakingEntry struct {
+	Session        *Session
+	VariantClass   string
+	MinPlayers     int
+	MaxPlayers     int
+	Timed          bool
+	DatetimeQueued time.Time
+}
+
+// MatchmakingReadyCheck is stored on tables that were created by the matchmaking queue until every
+// player confirms that they are ready
+// (the ready state itself is stored on each player)
+type MatchmakingReadyCheck struct {
+	// Used to put the players back in the queue if the match falls through
+	// (indexed by user ID)
+	Entries map[int]*MatchmakingEntry
+}
+
+func matchmakingInit() {
+	for class, variantNames := range matchmakingVariantClasses {
+		for _, variantName := range variantNames {
+			if getVariant(variantName) == nil {
+				logger.Fatal("The matchmaking variant of \"" + variantName + "\" in the class of " +
+					"\"" + class + "\" does not exist.")
+				return
+			}
+		}
+	}
+}
+
+func getMatchmakingVariantClasses() []string {
+	classes := make([]string, 0, len(matchmakingVariantClasses))
+	for class := range matchmakingVariantClasses {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+func isValidMatchmakingVariantClass(class string) bool {
+	if class == MatchmakingAnyClass {
+		return true
+	}
+	_, ok := matchmakingVariantClasses[class]
+	return ok
+}
+
+// Accepts returns true if the player is willing to play a game of the given class with the given
+// amount of players
+func (e *MatchmakingEntry) Accepts(class string, numPlayers int) bool {
+	return (e.VariantClass == class || e.VariantClass == MatchmakingAnyClass) &&
+		numPlayers >= e.MinPlayers &&
+		numPlayers <= e.MaxPlayers
+}
+
+func (e *MatchmakingEntry) String() string {
+	msg := "\"" + e.VariantClass + "\" variants with " + strconv.Itoa(e.MinPlayers)
+	if e.MaxPlayers != e.MinPlayers {
+		msg += " to " + strconv.Itoa(e.MaxPlayers)
+	}
+	msg += " players ("
+	if e.Timed {
+		msg += "timed"
+	} else {
+		msg += "untimed"
+	}
+	msg += ")"
+	return msg
+}
+
+// matchmakingAdd puts a player in the queue (or replaces their existing entry) and then checks to
+// see if a match can be made
+func matchmakingAdd(entry *MatchmakingEntry) {
+	matchmakingQueueMutex.Lock()
+	matchmakingRemoveLocked(entry.Session.UserID())
+	matchmakingQueue = append(matchmakingQueue, entry)
+	sort.SliceStable(matchmakingQueue, func(i, j int) bool {
+		return matchmakingQueue[i].DatetimeQueued.Before(matchmakingQueue[j].DatetimeQueued)
+	})
+	matchmakingQueueMutex.Unlock()
+
+	matchmakingMatch()
+}
+
+// matchmakingRemove returns true if the player was in the queue
+func matchmakingRemove(userID int) bool {
+	matchmakingQueueMutex.Lock()
+	defer matchmakingQueueMutex.Unlock()
+
+	return matchmakingRemoveLocked(userID)
+}
+
+func matchmakingRemoveLocked(userID int) bool {
+	for i, entry := range matchmakingQueue {
+		if entry.Session.UserID() == userID {
+			matchmakingQueue = append(matchmakingQueue[:i], matchmakingQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func matchmakingGetEntry(userID int) *MatchmakingEntry {
+	matchmakingQueueMutex.Lock()
+	defer matchmakingQueueMutex.Unlock()
+
+	for _, entry := range matchmakingQueue {
+		if entry.Session.UserID() == userID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// matchmakingReplaceSession is used when a user resumes their session from a new connection, so
+// that they keep their place in line (see "websocketResume()")
+func matchmakingReplaceSession(oldSession *Session, newSession *Session) {
+	matchmakingQueueMutex.Lock()
+	defer matchmakingQueueMutex.Unlock()
+
+	for _, entry := range matchmakingQueue {
+		if entry.Session != nil && entry.Session.Session == oldSession.Session {
+			entry.Session = newSession
+		}
+	}
+}
+
+func matchmakingGetNumQueued() int {
+	matchmakingQueueMutex.Lock()
+	defer matchmakingQueueMutex.Unlock()
+
+	return len(matchmakingQueue)
+}
+
+// matchmakingMatch creates tables for as many groups of compatible players as possible
+func matchmakingMatch() {
+	for {
+		matchmakingQueueMutex.Lock()
+		group, class := matchmakingFindGroup()
+		for _, entry := range group {
+			matchmakingRemoveLocked(entry.Session.UserID())
+		}
+		matchmakingQueueMutex.Unlock()
+
+		if group == nil {
+			return
+		}
+		matchmakingCreateTable(group, class)
+	}
+}
+
+// matchmakingFindGroup returns the group that contains the player who has been waiting the longest
+// (the largest game that they are willing to play is preferred)
+// The queue mutex must be held when calling this function
+func matchmakingFindGroup() ([]*MatchmakingEntry, string) {
+	for i, entry := range matchmakingQueue {
+		classes := []string{entry.VariantClass}
+		if entry.VariantClass == MatchmakingAnyClass {
+			classes = getMatchmakingVariantClasses()
+		}
+
+		for numPlayers := entry.MaxPlayers; numPlayers >= entry.MinPlayers; numPlayers-- {
+			for _, class := range classes {
+				group := []*MatchmakingEntry{entry}
+				for _, entry2 := range matchmakingQueue[i+1:] {
+					if entry2.Timed == entry.Timed && entry2.Accepts(class, numPlayers) {
+						group = append(group, entry2)
+						if len(group) == numPlayers {
+							return group, class
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// matchmakingCreateTable creates a table for the group in the same way that a player would and then
+// starts the ready check
+func matchmakingCreateTable(group []*MatchmakingEntry, class string) {
+	// Players who have gone offline or joined another table since they entered the queue are
+	// dropped, and everyone else goes back in the queue
+	for _, entry := range group {
+		if entry.Session.IsClosed() || entry.Session.GetJoinedTable() != nil {
+			logger.Info("Dropping \"" + entry.Session.Username() + "\" from the matchmaking " +
+				"queue since they are no longer available.")
+			for _, entry2 := range group {
+				if entry2 != entry {
+					matchmakingRequeue(entry2)
+				}
+			}
+			return
+		}
+	}
+
+	variantNames := matchmakingVariantClasses[class]
+	variantName := variantNames[getRandom(0, len(variantNames)-1)]
+	options := &engine.Options{
+		VariantName: variantName,
+	}
+	if group[0].Timed {
+		options.Timed = true
+		options.TimeBase = MatchmakingTimeBase
+		options.TimePerTurn = MatchmakingTimePerTurn
+	}
+
+	// The table is hidden from the lobby so that nobody else can join it
+	s := group[0].Session
+	commandTableCreate(s, &CommandData{ // Manual invocation
+		Name:        "Matchmaking " + variantName,
+		Options:     options,
+		HidePregame: true,
+	})
+
+	t := s.GetJoinedTable()
+	if t == nil {
+		// The user has already been sent a warning about why the table could not be created
+		logger.Error("Failed to create a matchmaking table for \"" + s.Username() + "\".")
+		for _, entry := range group[1:] {
+			matchmakingRequeue(entry)
+		}
+		return
+	}
+
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+
+	t.ExtraOptions.Matchmaking = true
+	t.RequireReady = true
+	t.ReadyCheck = &MatchmakingReadyCheck{
+		Entries: make(map[int]*MatchmakingEntry),
+	}
+	for _, entry := range group {
+		t.ReadyCheck.Entries[entry.Session.UserID()] = entry
+	}
+	for _, entry := range group[1:] {
+		// Someone may have joined another table while the table was being created
+		if entry.Session.IsClosed() || entry.Session.GetJoinedTable() != nil {
+			msg := entry.Session.Username() + " is no longer available."
+			matchmakingCancel(t, msg, []int{entry.Session.UserID()})
+			return
+		}
+		tableJoin(entry.Session, t)
+	}
+
+	logger.Info(t.GetName() + "Created a matchmaking table for " + strconv.Itoa(len(group)) +
+		" players.")
+
+	playerNames := make([]string, 0, len(t.Players))
+	for _, p := range t.Players {
+		playerNames = append(playerNames, p.Name)
+	}
+	msg := "A match was found for " + strings.Join(playerNames, ", ") + " on the variant of " +
+		"\"" + variantName + "\". Type \"/ready\" within " +
+		strconv.Itoa(int(MatchmakingReadyCheckTimeout/time.Second)) + " seconds to start the game."
+	chatServerSend(msg, t.GetRoomName())
+	for _, p := range t.Players {
+		p.Session.NotifySoundLobby("someone_joined")
+	}
+
+	scheduler.Schedule(t, TaskReadyCheck, MatchmakingReadyCheckTimeout, func(t *Table) {
+		if t.ReadyCheck == nil {
+			return
+		}
+
+		notReadyIDs := make([]int, 0)
+		for _, p := range t.Players {
+			if !p.Ready {
+				notReadyIDs = append(notReadyIDs, p.ID)
+			}
+		}
+		matchmakingCancel(t, "Not everyone was ready in time.", notReadyIDs)
+	})
+}
+
+// matchmakingRequeue puts a player back in the queue with their original place in line
+func matchmakingRequeue(entry *MatchmakingEntry) {
+	if entry.Session.IsClosed() {
+		return
+	}
+
+	matchmakingQueueMutex.Lock()
+	matchmakingRemoveLocked(entry.Session.UserID())
+	matchmakingQueue = append(matchmakingQueue, entry)
+	sort.SliceStable(matchmakingQueue, func(i, j int) bool {
+		return matchmakingQueue[i].DatetimeQueued.Before(matchmakingQueue[j].DatetimeQueued)
+	})
+	matchmakingQueueMutex.Unlock()
+
+	chatServerSendPM(entry.Session, "You have been put back in the matchmaking queue.", "lobby")
+}
+
+// matchmakingCheckReady is called when a player confirms that they are ready to play
+// The game starts automatically once everyone is ready
+// The table lock must be held when calling this function
+func matchmakingCheckReady(t *Table) {
+	if len(t.GetNotReadyNames()) > 0 {
+		// We are still waiting for someone to confirm
+		return
+	}
+
+	t.ReadyCheck = nil
+	scheduler.Cancel(t, TaskReadyCheck)
+
+	for _, p := range t.Players {
+		if p.ID == t.Owner {
+			commandTableStart(p.Session, &CommandData{ // Manual invocation
+				TableID: t.ID,
+				NoLock:  true,
+			})
+			return
+		}
+	}
+}
+
+// matchmakingCancel ends a ready check that did not succeed
+// Everyone is removed from the table and the players who are not at fault are put back in the
+// queue
+// The table lock must be held when calling this function
+func matchmakingCancel(t *Table, msg string, droppedIDs []int) {
+	readyCheck := t.ReadyCheck
+	if readyCheck == nil {
+		return
+	}
+	t.ReadyCheck = nil
+	scheduler.Cancel(t, TaskReadyCheck)
+	chatServerSend(msg+" The match has been canceled.", t.GetRoomName())
+
+	// Everyone must leave the table before they can be matched again
+	for len(t.Players) > 0 {
+		p := t.Players[0]
+		commandTableLeave(p.Session, &CommandData{ // Manual invocation
+			TableID: t.ID,
+			NoLock:  true,
+		})
+	}
+	if !t.Deleted {
+		deleteTable(t)
+	}
+
+	for userID, entry := range readyCheck.Entries {
+		dropped := false
+		for _, droppedID := range droppedIDs {
+			if userID == droppedID {
+				dropped = true
+				break
+			}
+		}
+		if dropped {
+			chatServerSendPM(entry.Session, "You have been removed from the matchmaking queue. "+
+				"(The match was canceled: "+msg+")", "lobby")
+		} else {
+			matchmakingRequeue(entry)
+		}
+	}
+
+	// Look for new matches for the players who were put back in the queue
+	// (this is done in a new goroutine since we are holding the lock for this table)
+	go matchmakingMatch()
+}
@@ -4,7 +4,6 @@ package main
 
 import (
 	"fmt"
-	"hash/crc64"
 	"io/ioutil"
 	"math"
 	"math/rand"
@@ -231,16 +230,6 @@ func secondsToDurationString(seconds int) (string, error) {
 	return msg, nil
 }
 
-// setSeed seeds the random number generator with a string
-// Golang's "rand.Seed()" function takes an int64, so we need to convert a string to an int64
-// We use the CRC64 hash function to do this
-// Also note that seeding with negative numbers will not work
-func setSeed(seed string) {
-	crc64Table := crc64.MakeTable(crc64.ECMA)
-	intSeed := crc64.Checksum([]byte(seed), crc64Table)
-	rand.Seed(int64(intSeed))
-}
-
 func stringInSlice(a string, slice []string) bool {
 	for _, b := range slice {
 		if b == a {
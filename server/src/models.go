@@ -20,17 +20,30 @@ type Models struct {
 	BannedIPs
 	ChatLog
 	ChatLogPM
+	CustomVariants
+	DailyChallenges
 	DiscordWaiters
 	GameActions
+	GameAnnotations
+	GameHypotheticals
 	GameParticipantNotes
 	GameParticipants
 	Games
 	GameTags
 	Metadata
 	MutedIPs
+	SeedAnalyses
 	Seeds
+	TeamRatings
+	TournamentGames
+	TournamentRounds
+	TournamentStandings
+	TournamentTeamMembers
+	TournamentTeams
+	Tournaments
 	Users
 	UserFriends
+	UserRatings
 	UserReverseFriends
 	UserSettings
 	UserStats
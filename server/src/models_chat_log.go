@@ -107,3 +107,41 @@ func (*ChatLog) Get(room string, count int) ([]DBChatMessage, error) {
 
 	return chatMessages, nil
 }
+
+type UserChatMessage struct {
+	Room     string    `json:"room"`
+	Message  string    `json:"message"`
+	Datetime time.Time `json:"datetime"`
+}
+
+// GetAllByUser gets every message that a user has sent, in the order that they were sent
+func (*ChatLog) GetAllByUser(userID int) ([]*UserChatMessage, error) {
+	chatMessages := make([]*UserChatMessage, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT room, message, datetime_sent
+		FROM chat_log
+		WHERE user_id = $1
+		ORDER BY id
+	`, userID); err != nil {
+		return chatMessages, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var message UserChatMessage
+		if err := rows.Scan(&message.Room, &message.Message, &message.Datetime); err != nil {
+			return chatMessages, err
+		}
+		chatMessages = append(chatMessages, &message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return chatMessages, err
+	}
+	rows.Close()
+
+	return chatMessages, nil
+}
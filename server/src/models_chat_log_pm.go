@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
 )
 
 type ChatLogPM struct{}
@@ -13,3 +16,51 @@ func (*ChatLogPM) Insert(userID int, message string, recipientID int) error {
 	`, userID, recipientID, message)
 	return err
 }
+
+type UserPM struct {
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Message  string    `json:"message"`
+	Datetime time.Time `json:"datetime"`
+}
+
+// GetAllByUser gets every private message that a user has sent or received,
+// in the order that they were sent
+func (*ChatLogPM) GetAllByUser(userID int) ([]*UserPM, error) {
+	pms := make([]*UserPM, 0)
+
+	// There is no foreign key for "recipient_id", so the recipient might not exist anymore
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			COALESCE(senders.username, ''),
+			COALESCE(recipients.username, ''),
+			chat_log_pm.message,
+			chat_log_pm.datetime_sent
+		FROM chat_log_pm
+			LEFT JOIN users AS senders ON senders.id = chat_log_pm.user_id
+			LEFT JOIN users AS recipients ON recipients.id = chat_log_pm.recipient_id
+		WHERE chat_log_pm.user_id = $1
+			OR chat_log_pm.recipient_id = $1
+		ORDER BY chat_log_pm.id
+	`, userID); err != nil {
+		return pms, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var pm UserPM
+		if err := rows.Scan(&pm.From, &pm.To, &pm.Message, &pm.Datetime); err != nil {
+			return pms, err
+		}
+		pms = append(pms, &pm)
+	}
+
+	if err := rows.Err(); err != nil {
+		return pms, err
+	}
+	rows.Close()
+
+	return pms, nil
+}
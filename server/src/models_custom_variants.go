@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type CustomVariants struct{}
+
+// GetOrInsert returns the ID of the custom variant with the given definition,
+// creating it if it does not exist yet
+// (two tables that use the same definition will share the same ID)
+func (cv *CustomVariants) GetOrInsert(definition string) (int, error) {
+	// Most tables use a definition that already exists, so look for it first
+	// (an insert would use up a value of the sequence even if there was a conflict)
+	if exists, id, err := cv.GetID(definition); err != nil {
+		return 0, err
+	} else if exists {
+		return id, nil
+	}
+
+	var id int
+	if err := db.QueryRow(context.Background(), `
+		INSERT INTO custom_variants (definition)
+		VALUES ($1)
+		ON CONFLICT (definition) DO NOTHING
+		RETURNING id
+	`, definition).Scan(&id); err == pgx.ErrNoRows {
+		// Another table inserted the same definition in the meantime
+		if _, id, err := cv.GetID(definition); err != nil {
+			return 0, err
+		} else {
+			return id, nil
+		}
+	} else if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetID returns false if there is no custom variant with the given definition
+func (*CustomVariants) GetID(definition string) (bool, int, error) {
+	var id int
+	if err := db.QueryRow(context.Background(), `
+		SELECT id
+		FROM custom_variants
+		WHERE definition = $1
+	`, definition).Scan(&id); err == pgx.ErrNoRows {
+		return false, 0, nil
+	} else if err != nil {
+		return false, 0, err
+	}
+
+	return true, id, nil
+}
+
+func (*CustomVariants) Get(id int) (string, error) {
+	var definition string
+	err := db.QueryRow(context.Background(), `
+		SELECT definition
+		FROM custom_variants
+		WHERE id = $1
+	`, id).Scan(&definition)
+	return definition, err
+}
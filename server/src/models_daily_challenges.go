@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type DailyChallenges struct{}
+
+// Insert does nothing if the challenge for that date was already published
+func (*DailyChallenges) Insert(date time.Time, variantID int) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO daily_challenges (date, variant_id)
+		VALUES ($1, $2)
+		ON CONFLICT (date) DO NOTHING
+	`, date, variantID)
+	return err
+}
+
+// GetVariantID returns false if no challenge was published on that date
+func (*DailyChallenges) GetVariantID(date time.Time) (bool, int, error) {
+	var variantID int
+	if err := db.QueryRow(context.Background(), `
+		SELECT variant_id
+		FROM daily_challenges
+		WHERE date = $1
+	`, date).Scan(&variantID); err == pgx.ErrNoRows {
+		return false, 0, nil
+	} else if err != nil {
+		return false, 0, err
+	}
+
+	return true, variantID, nil
+}
@@ -3,18 +3,12 @@ package main
 import (
 	"context"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/jackc/pgx/v4"
 )
 
 type GameActions struct{}
 
-// These fields are described in "database_schema.sql"
-type GameAction struct {
-	Type   int `json:"type"`
-	Target int `json:"target"`
-	Value  int `json:"value"`
-}
-
 // GameActionRow mirrors the "game_actions" table row
 type GameActionRow struct {
 	GameID int
@@ -53,8 +47,8 @@ func (*GameActions) BulkInsert(gameActionRows []*GameActionRow) error {
 	return err
 }
 
-func (*GameActions) GetAll(databaseID int) ([]*GameAction, error) {
-	actions := make([]*GameAction, 0)
+func (*GameActions) GetAll(databaseID int) ([]*engine.GameAction, error) {
+	actions := make([]*engine.GameAction, 0)
 
 	var rows pgx.Rows
 	if v, err := db.Query(context.Background(), `
@@ -73,7 +67,7 @@ func (*GameActions) GetAll(databaseID int) ([]*GameAction, error) {
 
 	// Iterate over all of the actions and add them to a slice
 	for rows.Next() {
-		var action GameAction
+		var action engine.GameAction
 		if err := rows.Scan(
 			&action.Type,
 			&action.Target,
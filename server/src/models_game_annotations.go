@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type GameAnnotations struct{}
+
+type GameAnnotation struct {
+	Turn            int       `json:"turn"`
+	Username        string    `json:"name"`
+	Annotation      string    `json:"text"`
+	DatetimeCreated time.Time `json:"-"`
+}
+
+func (*GameAnnotations) Insert(gameID int, userID int, turn int, annotation string) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO game_annotations (game_id, user_id, turn, annotation)
+		VALUES ($1, $2, $3, $4)
+	`, gameID, userID, turn, annotation)
+	return err
+}
+
+// Delete removes all of the annotations that a user left on a particular turn
+// It returns the number of annotations that were deleted
+func (*GameAnnotations) Delete(gameID int, userID int, turn int) (int, error) {
+	commandTag, err := db.Exec(context.Background(), `
+		DELETE FROM game_annotations
+		WHERE game_id = $1
+			AND user_id = $2
+			AND turn = $3
+	`, gameID, userID, turn)
+	return int(commandTag.RowsAffected()), err
+}
+
+// GetAll returns the annotations for a game, sorted by turn
+func (*GameAnnotations) GetAll(gameID int) ([]*GameAnnotation, error) {
+	annotations := make([]*GameAnnotation, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			game_annotations.turn,
+			users.username,
+			game_annotations.annotation,
+			game_annotations.datetime_created
+		FROM game_annotations
+			JOIN users ON users.id = game_annotations.user_id
+		WHERE game_annotations.game_id = $1
+		ORDER BY game_annotations.turn, game_annotations.id
+	`, gameID); err != nil {
+		return annotations, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var annotation GameAnnotation
+		if err := rows.Scan(
+			&annotation.Turn,
+			&annotation.Username,
+			&annotation.Annotation,
+			&annotation.DatetimeCreated,
+		); err != nil {
+			return annotations, err
+		}
+		annotations = append(annotations, &annotation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return annotations, err
+	}
+	rows.Close()
+
+	return annotations, nil
+}
+
+// Search returns the IDs of the games that have an annotation containing the provided text
+// (case-insensitive)
+func (*GameAnnotations) Search(text string) ([]int, error) {
+	gameIDs := make([]int, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT DISTINCT game_id
+		FROM game_annotations
+		WHERE STRPOS(LOWER(annotation), LOWER($1)) > 0
+		ORDER BY game_id
+	`, text); err != nil {
+		return gameIDs, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var gameID int
+		if err := rows.Scan(&gameID); err != nil {
+			return gameIDs, err
+		}
+		gameIDs = append(gameIDs, gameID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return gameIDs, err
+	}
+	rows.Close()
+
+	return gameIDs, nil
+}
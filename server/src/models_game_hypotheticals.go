@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type GameHypotheticals struct{}
+
+type GameHypothetical struct {
+	ID              int
+	GameID          int
+	ParentID        int // 0 if the line was started from the real game
+	Name            string
+	Segment         int
+	DrawnCardsShown bool
+	Actions         []string
+}
+
+func (*GameHypotheticals) Insert(h *GameHypothetical) (int, error) {
+	var actionsJSON []byte
+	if v, err := json.Marshal(h.Actions); err != nil {
+		return 0, err
+	} else {
+		actionsJSON = v
+	}
+
+	parentID := sql.NullInt32{
+		Int32: int32(h.ParentID),
+		Valid: h.ParentID != 0,
+	}
+
+	var id int
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO game_hypotheticals (
+			game_id,
+			parent_id,
+			name,
+			segment,
+			drawn_cards_shown,
+			actions
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`,
+		h.GameID,
+		parentID,
+		h.Name,
+		h.Segment,
+		h.DrawnCardsShown,
+		string(actionsJSON),
+	).Scan(&id)
+	return id, err
+}
+
+// Update overwrites the moves of an existing line
+// (the name and the parent of a line never change)
+func (*GameHypotheticals) Update(h *GameHypothetical) error {
+	var actionsJSON []byte
+	if v, err := json.Marshal(h.Actions); err != nil {
+		return err
+	} else {
+		actionsJSON = v
+	}
+
+	_, err := db.Exec(context.Background(), `
+		UPDATE game_hypotheticals
+		SET
+			segment = $1,
+			drawn_cards_shown = $2,
+			actions = $3,
+			datetime_updated = NOW()
+		WHERE id = $4
+	`, h.Segment, h.DrawnCardsShown, string(actionsJSON), h.ID)
+	return err
+}
+
+func (*GameHypotheticals) Delete(id int) error {
+	_, err := db.Exec(context.Background(), `
+		DELETE FROM game_hypotheticals
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+func (*GameHypotheticals) Get(gameID int, name string) (bool, *GameHypothetical, error) {
+	return getGameHypothetical(`
+		WHERE game_id = $1
+			AND name = $2
+	`, gameID, name)
+}
+
+func (*GameHypotheticals) GetByID(id int) (bool, *GameHypothetical, error) {
+	return getGameHypothetical(`
+		WHERE id = $1
+	`, id)
+}
+
+func getGameHypothetical(where string, args ...interface{}) (bool, *GameHypothetical, error) {
+	h := &GameHypothetical{}
+	var parentID sql.NullInt32
+	var actionsJSON string
+	if err := db.QueryRow(context.Background(), `
+		SELECT
+			id,
+			game_id,
+			parent_id,
+			name,
+			segment,
+			drawn_cards_shown,
+			actions
+		FROM game_hypotheticals
+	`+where, args...).Scan(
+		&h.ID,
+		&h.GameID,
+		&parentID,
+		&h.Name,
+		&h.Segment,
+		&h.DrawnCardsShown,
+		&actionsJSON,
+	); err == pgx.ErrNoRows {
+		return false, h, nil
+	} else if err != nil {
+		return false, h, err
+	}
+
+	h.ParentID = int(parentID.Int32)
+	if err := json.Unmarshal([]byte(actionsJSON), &h.Actions); err != nil {
+		return false, h, err
+	}
+
+	return true, h, nil
+}
+
+// GetAll returns the lines for a game without their moves, in the order that they were created
+func (*GameHypotheticals) GetAll(gameID int) ([]*GameHypothetical, error) {
+	hypotheticals := make([]*GameHypothetical, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT id, parent_id, name, segment
+		FROM game_hypotheticals
+		WHERE game_id = $1
+		ORDER BY id
+	`, gameID); err != nil {
+		return hypotheticals, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		h := &GameHypothetical{
+			GameID: gameID,
+		}
+		var parentID sql.NullInt32
+		if err := rows.Scan(&h.ID, &parentID, &h.Name, &h.Segment); err != nil {
+			return hypotheticals, err
+		}
+		h.ParentID = int(parentID.Int32)
+		hypotheticals = append(hypotheticals, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return hypotheticals, err
+	}
+	rows.Close()
+
+	return hypotheticals, nil
+}
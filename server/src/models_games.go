@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -16,18 +17,19 @@ type Games struct{}
 // (it contains a subset of the information in the Game struct)
 type GameRow struct {
 	Name             string
-	Options          *Options
+	Options          *engine.Options
 	Seed             string
 	Score            int
 	NumTurns         int
 	EndCondition     int
 	DatetimeStarted  time.Time
 	DatetimeFinished time.Time
+	Bot              bool
 }
 
 func (*Games) Insert(gameRow GameRow) (int, error) {
 	// Local variables
-	variant := variants[gameRow.Options.VariantName]
+	variant := getVariant(gameRow.Options.VariantName)
 
 	// https://www.postgresql.org/docs/9.5/dml-returning.html
 	// https://github.com/jackc/pgx/issues/411
@@ -42,6 +44,8 @@ func (*Games) Insert(gameRow GameRow) (int, error) {
 				timed,
 				time_base,
 				time_per_turn,
+				time_control,
+				turn_time_limit,
 				speedrun,
 				card_cycle,
 				deck_plays,
@@ -50,12 +54,17 @@ func (*Games) Insert(gameRow GameRow) (int, error) {
 				one_less_card,
 				all_or_nothing,
 				detrimental_characters,
+				starting_clues,
+				max_clues,
+				max_strikes,
+				takeback,
 				seed,
 				score,
 				num_turns,
 				end_condition,
 				datetime_started,
-				datetime_finished
+				datetime_finished,
+				bot
 			) VALUES (
 				$1,
 				$2,
@@ -76,7 +85,14 @@ func (*Games) Insert(gameRow GameRow) (int, error) {
 				$17,
 				$18,
 				$19,
-				$20
+				$20,
+				$21,
+				$22,
+				$23,
+				$24,
+				$25,
+				$26,
+				$27
 			)
 			RETURNING id
 		`,
@@ -88,6 +104,8 @@ func (*Games) Insert(gameRow GameRow) (int, error) {
 		gameRow.Options.Timed,
 		gameRow.Options.TimeBase,
 		gameRow.Options.TimePerTurn,
+		gameRow.Options.TimeControl,
+		gameRow.Options.TurnTimeLimit,
 		gameRow.Options.Speedrun,
 		gameRow.Options.CardCycle,
 		gameRow.Options.DeckPlays,
@@ -96,12 +114,17 @@ func (*Games) Insert(gameRow GameRow) (int, error) {
 		gameRow.Options.OneLessCard,
 		gameRow.Options.AllOrNothing,
 		gameRow.Options.DetrimentalCharacters,
+		gameRow.Options.StartingClues,
+		gameRow.Options.MaxClues,
+		gameRow.Options.MaxStrikes,
+		gameRow.Options.Takeback,
 		gameRow.Seed,
 		gameRow.Score,
 		gameRow.NumTurns,
 		gameRow.EndCondition,
 		gameRow.DatetimeStarted,
 		gameRow.DatetimeFinished,
+		gameRow.Bot,
 	).Scan(&id); err != nil {
 		return -1, err
 	}
@@ -125,18 +148,19 @@ func (*Games) Exists(databaseID int) (bool, error) {
 }
 
 type GameHistory struct {
-	ID                 int       `json:"id"`
-	Options            *Options  `json:"options"`
-	Seed               string    `json:"seed"`
-	Score              int       `json:"score"`
-	NumTurns           int       `json:"numTurns"`
-	EndCondition       int       `json:"endCondition"`
-	DatetimeStarted    time.Time `json:"datetimeStarted"`
-	DatetimeFinished   time.Time `json:"datetimeFinished"`
-	NumGamesOnThisSeed int       `json:"numGamesOnThisSeed"`
-	PlayerNames        []string  `json:"playerNames"`
-	IncrementNumGames  bool      `json:"incrementNumGames"`
-	Tags               string    `json:"tags"`
+	ID                 int             `json:"id"`
+	Options            *engine.Options `json:"options"`
+	Seed               string          `json:"seed"`
+	Score              int             `json:"score"`
+	NumTurns           int             `json:"numTurns"`
+	EndCondition       int             `json:"endCondition"`
+	DatetimeStarted    time.Time       `json:"datetimeStarted"`
+	DatetimeFinished   time.Time       `json:"datetimeFinished"`
+	NumGamesOnThisSeed int             `json:"numGamesOnThisSeed"`
+	PlayerNames        []string        `json:"playerNames"`
+	BotNames           []string        `json:"botNames"` // The subset of players that are bots
+	IncrementNumGames  bool            `json:"incrementNumGames"`
+	Tags               string          `json:"tags"`
 }
 
 func (g *Games) GetHistory(gameIDs []int) ([]*GameHistory, error) {
@@ -156,6 +180,8 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 			games1.timed,
 			games1.time_base,
 			games1.time_per_turn,
+			games1.time_control,
+			games1.turn_time_limit,
 			games1.speedrun,
 			games1.card_cycle,
 			games1.deck_plays,
@@ -164,6 +190,10 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 			games1.one_less_card,
 			games1.all_or_nothing,
 			games1.detrimental_characters,
+			games1.starting_clues,
+			games1.max_clues,
+			games1.max_strikes,
+			games1.takeback,
 			games1.seed,
 			games1.score,
 			games1.num_turns,
@@ -187,7 +217,14 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 				FROM game_participants
 					JOIN users ON users.id = game_participants.user_id
 				WHERE game_participants.game_id = games1.id
-			) AS player_names
+			) AS player_names,
+			(
+				SELECT COALESCE(STRING_AGG(users.username, ', '), '')
+				FROM game_participants
+					JOIN users ON users.id = game_participants.user_id
+				WHERE game_participants.game_id = games1.id
+					AND users.bot = TRUE
+			) AS bot_names
 		FROM games AS games1
 		/*
 		 * We must use the ANY operator for matching an array of IDs:
@@ -206,10 +243,11 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 
 	for rows.Next() {
 		gameHistory := GameHistory{
-			Options: &Options{},
+			Options: &engine.Options{},
 		}
 		var variantID int
 		var playerNamesString string
+		var botNamesString string
 		if err := rows.Scan(
 			&gameHistory.ID,
 			&gameHistory.Options.NumPlayers,
@@ -217,6 +255,8 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 			&gameHistory.Options.Timed,
 			&gameHistory.Options.TimeBase,
 			&gameHistory.Options.TimePerTurn,
+			&gameHistory.Options.TimeControl,
+			&gameHistory.Options.TurnTimeLimit,
 			&gameHistory.Options.Speedrun,
 			&gameHistory.Options.CardCycle,
 			&gameHistory.Options.DeckPlays,
@@ -225,6 +265,10 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 			&gameHistory.Options.OneLessCard,
 			&gameHistory.Options.AllOrNothing,
 			&gameHistory.Options.DetrimentalCharacters,
+			&gameHistory.Options.StartingClues,
+			&gameHistory.Options.MaxClues,
+			&gameHistory.Options.MaxStrikes,
+			&gameHistory.Options.Takeback,
 			&gameHistory.Seed,
 			&gameHistory.Score,
 			&gameHistory.NumTurns,
@@ -233,16 +277,18 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 			&gameHistory.DatetimeFinished,
 			&gameHistory.NumGamesOnThisSeed,
 			&playerNamesString,
+			&botNamesString,
 		); err != nil {
 			return games, err
 		}
 
 		// Get the name of the variant that corresponds to the variant ID
-		if variantName, ok := variantIDMap[variantID]; !ok {
+		if variantName, ok := getVariantNameFromID(variantID); !ok {
 			err := errors.New("the variant ID of " + strconv.Itoa(variantID) + " is not valid")
 			return games, err
 		} else {
 			gameHistory.Options.VariantName = variantName
+			gameHistory.Options.CustomVariant = getCustomVariantJSON(variantName)
 		}
 
 		// The players come from the database in a random order
@@ -251,6 +297,10 @@ func (*Games) GetHistoryCustomSort(gameIDs []int, sort string) ([]*GameHistory,
 		playerNames := strings.Split(playerNamesString, ", ")
 		playerNames = sortStringsCaseInsensitive(playerNames)
 		gameHistory.PlayerNames = playerNames
+		gameHistory.BotNames = make([]string, 0)
+		if botNamesString != "" {
+			gameHistory.BotNames = sortStringsCaseInsensitive(strings.Split(botNamesString, ", "))
+		}
 
 		games = append(games, &gameHistory)
 	}
@@ -439,6 +489,7 @@ func (*Games) GetGameIDsMultiUser(userIDs []int) ([]int, error) {
 	return gameIDs, nil
 }
 
+// GetGameIDsVariant returns every game played on the variant if the amount is 0
 func (*Games) GetGameIDsVariant(variantID int, amount int) ([]int, error) {
 	gameIDs := make([]int, 0)
 
@@ -448,7 +499,8 @@ func (*Games) GetGameIDsVariant(variantID int, amount int) ([]int, error) {
 		WHERE variant_id = $1
 		/* We must get the results in decending order for the limit to work properly */
 		ORDER BY id DESC
-		LIMIT $2
+		/* A limit of NULL is the same as no limit */
+		LIMIT NULLIF($2, 0)
 	`
 
 	var rows pgx.Rows
@@ -592,8 +644,8 @@ func (*Games) GetUserNumGames(userID int, includeSpeedrun bool) (int, error) {
 	return count, nil
 }
 
-func (*Games) GetOptions(databaseID int) (*Options, error) {
-	var options Options
+func (*Games) GetOptions(databaseID int) (*engine.Options, error) {
+	var options engine.Options
 	var variantID int
 	if err := db.QueryRow(context.Background(), `
 		SELECT
@@ -603,6 +655,8 @@ func (*Games) GetOptions(databaseID int) (*Options, error) {
 			timed,
 			time_base,
 			time_per_turn,
+			time_control,
+			turn_time_limit,
 			speedrun,
 			card_cycle,
 			deck_plays,
@@ -610,7 +664,11 @@ func (*Games) GetOptions(databaseID int) (*Options, error) {
 			one_extra_card,
 			one_less_card,
 			all_or_nothing,
-			detrimental_characters
+			detrimental_characters,
+			starting_clues,
+			max_clues,
+			max_strikes,
+			takeback
 		FROM games
 		WHERE games.id = $1
 	`, databaseID).Scan(
@@ -620,6 +678,8 @@ func (*Games) GetOptions(databaseID int) (*Options, error) {
 		&options.Timed,
 		&options.TimeBase,
 		&options.TimePerTurn,
+		&options.TimeControl,
+		&options.TurnTimeLimit,
 		&options.Speedrun,
 		&options.CardCycle,
 		&options.DeckPlays,
@@ -628,16 +688,21 @@ func (*Games) GetOptions(databaseID int) (*Options, error) {
 		&options.OneLessCard,
 		&options.AllOrNothing,
 		&options.DetrimentalCharacters,
+		&options.StartingClues,
+		&options.MaxClues,
+		&options.MaxStrikes,
+		&options.Takeback,
 	); err != nil {
 		return &options, err
 	}
 
 	// Validate that the variant exists
-	if v, ok := variantIDMap[variantID]; !ok {
+	if v, ok := getVariantNameFromID(variantID); !ok {
 		err := errors.New("failed to find a definition for variant " + strconv.Itoa(variantID))
 		return &options, err
 	} else {
 		options.VariantName = v
+		options.CustomVariant = getCustomVariantJSON(v)
 	}
 
 	return &options, nil
@@ -769,6 +834,20 @@ func (*Games) GetPlayerSeeds(userID int, variantID int) ([]string, error) {
 	return seeds, nil
 }
 
+func (*Games) HasPlayedSeed(userID int, seed string) (bool, error) {
+	var hasPlayed bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT games.id
+			FROM games
+				JOIN game_participants ON games.id = game_participants.game_id
+			WHERE game_participants.user_id = $1
+				AND games.seed = $2
+		)
+	`, userID, seed).Scan(&hasPlayed)
+	return hasPlayed, err
+}
+
 func (*Games) GetNotes(databaseID int, numPlayers int, noteSize int) ([][]string, error) {
 	allPlayersNotes := make([][]string, numPlayers)
 	for i := 0; i < numPlayers; i++ {
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type SeedAnalyses struct{}
+
+type SeedAnalysis struct {
+	MaxScore int  `json:"maxScore"`
+	Complete bool `json:"complete"`
+}
+
+// Get returns false if the seed has not been analyzed yet
+func (*SeedAnalyses) Get(seed string) (bool, SeedAnalysis, error) {
+	var analysis SeedAnalysis
+	if err := db.QueryRow(context.Background(), `
+		SELECT max_score, complete
+		FROM seed_analyses
+		WHERE seed = $1
+	`, seed).Scan(&analysis.MaxScore, &analysis.Complete); err == pgx.ErrNoRows {
+		return false, analysis, nil
+	} else if err != nil {
+		return false, analysis, err
+	}
+
+	return true, analysis, nil
+}
+
+func (*SeedAnalyses) Insert(seed string, analysis SeedAnalysis) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO seed_analyses (seed, max_score, complete)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (seed) DO UPDATE
+		SET max_score = $2, complete = $3, datetime_analyzed = NOW()
+	`, seed, analysis.MaxScore, analysis.Complete)
+	return err
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TeamRatings struct{}
+
+// TeamRating is the rating of a specific group of players that play together
+type TeamRating struct {
+	UserIDs      []int    `json:"-"` // Sorted (see "getTeamUserIDs()")
+	Names        []string `json:"names"`
+	VariantClass int      `json:"-"` // See the "RatingClass" constants
+	Rating       float64  `json:"rating"`
+	NumGames     int      `json:"numGames"`
+}
+
+// Get returns the default rating if the team has not played any rated games of the variant class
+// together yet
+func (*TeamRatings) Get(userIDs []int, variantClass int) (*TeamRating, error) {
+	rating := &TeamRating{
+		UserIDs:      userIDs,
+		VariantClass: variantClass,
+		Rating:       RatingDefault,
+	}
+
+	if err := db.QueryRow(context.Background(), `
+		SELECT rating, num_games
+		FROM team_ratings
+		WHERE user_ids = $1
+			AND variant_class = $2
+	`, userIDs, variantClass).Scan(&rating.Rating, &rating.NumGames); err == pgx.ErrNoRows {
+		return rating, nil
+	} else if err != nil {
+		return rating, err
+	}
+
+	return rating, nil
+}
+
+// GetAllForUser returns the rating of every team that the user has played on,
+// starting with the teams that have played the most games together
+func (*TeamRatings) GetAllForUser(userID int) ([]*TeamRating, error) {
+	ratings := make([]*TeamRating, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			team_ratings.user_ids,
+			ARRAY(
+				SELECT users.username
+				FROM users
+				WHERE users.id = ANY(team_ratings.user_ids)
+				ORDER BY users.username
+			) AS names,
+			team_ratings.variant_class,
+			team_ratings.rating,
+			team_ratings.num_games
+		FROM team_ratings
+		WHERE team_ratings.user_ids @> ARRAY[$1::INTEGER]
+		ORDER BY team_ratings.num_games DESC, team_ratings.rating DESC
+	`, userID); err != nil {
+		return ratings, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var rating TeamRating
+		if err := rows.Scan(
+			&rating.UserIDs,
+			&rating.Names,
+			&rating.VariantClass,
+			&rating.Rating,
+			&rating.NumGames,
+		); err != nil {
+			return ratings, err
+		}
+		ratings = append(ratings, &rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		return ratings, err
+	}
+	rows.Close()
+
+	return ratings, nil
+}
+
+func (*TeamRatings) Set(rating *TeamRating) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO team_ratings (user_ids, variant_class, rating, num_games)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_ids, variant_class) DO UPDATE
+		SET rating = EXCLUDED.rating, num_games = EXCLUDED.num_games
+	`, rating.UserIDs, rating.VariantClass, rating.Rating, rating.NumGames)
+	return err
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TournamentGames struct{}
+
+type TournamentGame struct {
+	Round    int    `json:"round"`
+	TeamID   int    `json:"teamID"`
+	TeamName string `json:"teamName"`
+	GameID   int    `json:"gameID"`
+	Score    int    `json:"score"`
+	NumTurns int    `json:"numTurns"`
+}
+
+// Insert records the game that a team played for a round
+// Only the first game of each team counts
+func (*TournamentGames) Insert(tournamentID int, round int, teamID int, gameID int) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO tournament_games (tournament_id, round, team_id, game_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tournament_id, round, team_id) DO NOTHING
+	`, tournamentID, round, teamID, gameID)
+	return err
+}
+
+func (*TournamentGames) Exists(tournamentID int, round int, teamID int) (bool, error) {
+	var exists bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT game_id
+			FROM tournament_games
+			WHERE tournament_id = $1
+				AND round = $2
+				AND team_id = $3
+		)
+	`, tournamentID, round, teamID).Scan(&exists)
+	return exists, err
+}
+
+func (*TournamentGames) GetAll(tournamentID int) ([]*TournamentGame, error) {
+	tournamentGames := make([]*TournamentGame, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			tournament_games.round,
+			tournament_games.team_id,
+			tournament_teams.name,
+			tournament_games.game_id,
+			games.score,
+			games.num_turns
+		FROM tournament_games
+			JOIN tournament_teams ON tournament_teams.id = tournament_games.team_id
+			JOIN games ON games.id = tournament_games.game_id
+		WHERE tournament_games.tournament_id = $1
+		ORDER BY tournament_games.round, games.score DESC, games.num_turns
+	`, tournamentID); err != nil {
+		return tournamentGames, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var tournamentGame TournamentGame
+		if err := rows.Scan(
+			&tournamentGame.Round,
+			&tournamentGame.TeamID,
+			&tournamentGame.TeamName,
+			&tournamentGame.GameID,
+			&tournamentGame.Score,
+			&tournamentGame.NumTurns,
+		); err != nil {
+			return tournamentGames, err
+		}
+		tournamentGames = append(tournamentGames, &tournamentGame)
+	}
+
+	if err := rows.Err(); err != nil {
+		return tournamentGames, err
+	}
+	rows.Close()
+
+	return tournamentGames, nil
+}
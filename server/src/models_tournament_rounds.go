@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TournamentRounds struct{}
+
+type TournamentRound struct {
+	Round           int        `json:"round"`
+	Seed            string     `json:"seed"`
+	DatetimeStarted time.Time  `json:"datetimeStarted"`
+	DatetimeClosed  *time.Time `json:"datetimeClosed"` // Nil if the round is still in progress
+}
+
+func (*TournamentRounds) Insert(tournamentID int, round int, seed string) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO tournament_rounds (tournament_id, round, seed)
+		VALUES ($1, $2, $3)
+	`, tournamentID, round, seed)
+	return err
+}
+
+// GetCurrent returns the latest round of the tournament and whether or not it is still open
+// (the round will be 0 if no rounds have been started yet)
+func (*TournamentRounds) GetCurrent(tournamentID int) (int, bool, error) {
+	var round int
+	var datetimeClosed *time.Time
+	if err := db.QueryRow(context.Background(), `
+		SELECT round, datetime_closed
+		FROM tournament_rounds
+		WHERE tournament_id = $1
+		ORDER BY round DESC
+		LIMIT 1
+	`, tournamentID).Scan(&round, &datetimeClosed); err == pgx.ErrNoRows {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	return round, datetimeClosed == nil, nil
+}
+
+func (*TournamentRounds) IsOpen(tournamentID int, round int) (bool, error) {
+	var isOpen bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT round
+			FROM tournament_rounds
+			WHERE tournament_id = $1
+				AND round = $2
+				AND datetime_closed IS NULL
+		)
+	`, tournamentID, round).Scan(&isOpen)
+	return isOpen, err
+}
+
+// IsSeedHidden returns true if the seed belongs to a round that is still in progress and the user
+// is participating in that tournament
+func (*TournamentRounds) IsSeedHidden(seed string, userID int) (bool, error) {
+	var isHidden bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT tournament_rounds.round
+			FROM tournament_rounds
+				JOIN tournament_teams
+					ON tournament_teams.tournament_id = tournament_rounds.tournament_id
+				JOIN tournament_team_members
+					ON tournament_team_members.team_id = tournament_teams.id
+			WHERE tournament_rounds.seed = $1
+				AND tournament_rounds.datetime_closed IS NULL
+				AND tournament_team_members.user_id = $2
+		)
+	`, seed, userID).Scan(&isHidden)
+	return isHidden, err
+}
+
+// IsSeedInProgress returns true if the seed belongs to any tournament round that is still in
+// progress
+func (*TournamentRounds) IsSeedInProgress(seed string) (bool, error) {
+	var inProgress bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT round
+			FROM tournament_rounds
+			WHERE seed = $1
+				AND datetime_closed IS NULL
+		)
+	`, seed).Scan(&inProgress)
+	return inProgress, err
+}
+
+func (*TournamentRounds) Close(tournamentID int, round int) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE tournament_rounds
+		SET datetime_closed = NOW()
+		WHERE tournament_id = $1
+			AND round = $2
+	`, tournamentID, round)
+	return err
+}
+
+func (*TournamentRounds) GetAll(tournamentID int) ([]*TournamentRound, error) {
+	rounds := make([]*TournamentRound, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT round, seed, datetime_started, datetime_closed
+		FROM tournament_rounds
+		WHERE tournament_id = $1
+		ORDER BY round
+	`, tournamentID); err != nil {
+		return rounds, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var round TournamentRound
+		if err := rows.Scan(
+			&round.Round,
+			&round.Seed,
+			&round.DatetimeStarted,
+			&round.DatetimeClosed,
+		); err != nil {
+			return rounds, err
+		}
+		rounds = append(rounds, &round)
+	}
+
+	if err := rows.Err(); err != nil {
+		return rounds, err
+	}
+	rows.Close()
+
+	return rounds, nil
+}
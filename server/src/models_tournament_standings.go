@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TournamentStandings struct{}
+
+type TournamentStanding struct {
+	Place      int      `json:"place"`
+	TeamID     int      `json:"teamID"`
+	TeamName   string   `json:"teamName"`
+	Players    []string `json:"players"`
+	NumGames   int      `json:"numGames"`
+	TotalScore int      `json:"totalScore"`
+	TotalTurns int      `json:"totalTurns"`
+}
+
+// Update recalculates the standings for every team in the tournament from the games that they
+// have played
+// Only rounds that are closed are counted, so that the standings do not spoil a round in progress
+// Teams are ranked by their total score, with ties broken by the fewest total turns
+func (*TournamentStandings) Update(tournamentID int) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO tournament_standings (
+			tournament_id,
+			team_id,
+			place,
+			num_games,
+			total_score,
+			total_turns
+		)
+		SELECT
+			totals.tournament_id,
+			totals.team_id,
+			RANK() OVER (ORDER BY totals.total_score DESC, totals.total_turns ASC),
+			totals.num_games,
+			totals.total_score,
+			totals.total_turns
+		FROM (
+			SELECT
+				tournament_teams.tournament_id,
+				tournament_teams.id AS team_id,
+				COUNT(games.id) AS num_games,
+				COALESCE(SUM(games.score), 0) AS total_score,
+				COALESCE(SUM(games.num_turns), 0) AS total_turns
+			FROM tournament_teams
+				LEFT JOIN tournament_games ON tournament_games.team_id = tournament_teams.id
+				LEFT JOIN tournament_rounds
+					ON tournament_rounds.tournament_id = tournament_games.tournament_id
+					AND tournament_rounds.round = tournament_games.round
+				LEFT JOIN games
+					ON games.id = tournament_games.game_id
+					AND tournament_rounds.datetime_closed IS NOT NULL
+			WHERE tournament_teams.tournament_id = $1
+			GROUP BY tournament_teams.id
+		) AS totals
+		ON CONFLICT (tournament_id, team_id) DO UPDATE
+		SET
+			place = EXCLUDED.place,
+			num_games = EXCLUDED.num_games,
+			total_score = EXCLUDED.total_score,
+			total_turns = EXCLUDED.total_turns
+	`, tournamentID)
+	return err
+}
+
+func (*TournamentStandings) GetAll(tournamentID int) ([]*TournamentStanding, error) {
+	standings := make([]*TournamentStanding, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			tournament_standings.place,
+			tournament_teams.id,
+			tournament_teams.name,
+			(
+				SELECT STRING_AGG(users.username, ', ' ORDER BY users.username)
+				FROM tournament_team_members
+					JOIN users ON users.id = tournament_team_members.user_id
+				WHERE tournament_team_members.team_id = tournament_teams.id
+			) AS player_names,
+			tournament_standings.num_games,
+			tournament_standings.total_score,
+			tournament_standings.total_turns
+		FROM tournament_standings
+			JOIN tournament_teams ON tournament_teams.id = tournament_standings.team_id
+		WHERE tournament_standings.tournament_id = $1
+		ORDER BY tournament_standings.place, tournament_teams.name
+	`, tournamentID); err != nil {
+		return standings, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var standing TournamentStanding
+		var playerNamesString string
+		if err := rows.Scan(
+			&standing.Place,
+			&standing.TeamID,
+			&standing.TeamName,
+			&playerNamesString,
+			&standing.NumGames,
+			&standing.TotalScore,
+			&standing.TotalTurns,
+		); err != nil {
+			return standings, err
+		}
+		standing.Players = strings.Split(playerNamesString, ", ")
+		standings = append(standings, &standing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return standings, err
+	}
+	rows.Close()
+
+	return standings, nil
+}
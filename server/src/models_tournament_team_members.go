@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TournamentTeamMembers struct{}
+
+// TournamentEntry describes a team that a user is playing on in a tournament that has a round in
+// progress
+type TournamentEntry struct {
+	TournamentID int
+	TeamID       int
+	Round        int
+	Seed         string
+	VariantID    int
+	NumPlayers   int
+}
+
+func (*TournamentTeamMembers) BulkInsert(teamID int, userIDs []int) error {
+	SQLString := `
+		INSERT INTO tournament_team_members (team_id, user_id)
+		VALUES %s
+	`
+	numArgsPerRow := 2
+	valueArgs := make([]interface{}, 0, numArgsPerRow*len(userIDs))
+	for _, userID := range userIDs {
+		valueArgs = append(valueArgs, teamID, userID)
+	}
+	SQLString = getBulkInsertSQLSimple(SQLString, numArgsPerRow, len(userIDs))
+
+	_, err := db.Exec(context.Background(), SQLString, valueArgs...)
+	return err
+}
+
+func (*TournamentTeamMembers) IsMember(teamID int, userID int) (bool, error) {
+	var isMember bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT user_id
+			FROM tournament_team_members
+			WHERE team_id = $1
+				AND user_id = $2
+		)
+	`, teamID, userID).Scan(&isMember)
+	return isMember, err
+}
+
+// IsRegistered returns true if the user is on any team in the tournament
+func (*TournamentTeamMembers) IsRegistered(tournamentID int, userID int) (bool, error) {
+	var isRegistered bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT tournament_team_members.user_id
+			FROM tournament_team_members
+				JOIN tournament_teams ON tournament_teams.id = tournament_team_members.team_id
+			WHERE tournament_teams.tournament_id = $1
+				AND tournament_team_members.user_id = $2
+		)
+	`, tournamentID, userID).Scan(&isRegistered)
+	return isRegistered, err
+}
+
+// GetEntries returns every team of the user that can currently play a tournament game
+func (*TournamentTeamMembers) GetEntries(userID int) ([]*TournamentEntry, error) {
+	entries := make([]*TournamentEntry, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			tournaments.id,
+			tournament_teams.id,
+			tournament_rounds.round,
+			tournament_rounds.seed,
+			tournaments.variant_id,
+			tournaments.num_players
+		FROM tournament_team_members
+			JOIN tournament_teams ON tournament_teams.id = tournament_team_members.team_id
+			JOIN tournaments ON tournaments.id = tournament_teams.tournament_id
+			JOIN tournament_rounds ON tournament_rounds.tournament_id = tournaments.id
+		WHERE tournament_team_members.user_id = $1
+			AND tournament_rounds.datetime_closed IS NULL
+		ORDER BY tournaments.id
+	`, userID); err != nil {
+		return entries, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var entry TournamentEntry
+		if err := rows.Scan(
+			&entry.TournamentID,
+			&entry.TeamID,
+			&entry.Round,
+			&entry.Seed,
+			&entry.VariantID,
+			&entry.NumPlayers,
+		); err != nil {
+			return entries, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entries, err
+	}
+	rows.Close()
+
+	return entries, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type TournamentTeams struct{}
+
+type TournamentTeam struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Players []string `json:"players"`
+}
+
+func (*TournamentTeams) Insert(tournamentID int, name string) (int, error) {
+	var id int
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO tournament_teams (tournament_id, name)
+		VALUES ($1, $2)
+		RETURNING id
+	`, tournamentID, name).Scan(&id)
+	return id, err
+}
+
+func (*TournamentTeams) Exists(tournamentID int, name string) (bool, error) {
+	var exists bool
+	err := db.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT id
+			FROM tournament_teams
+			WHERE tournament_id = $1
+				AND name = $2
+		)
+	`, tournamentID, name).Scan(&exists)
+	return exists, err
+}
+
+func (*TournamentTeams) GetAll(tournamentID int) ([]*TournamentTeam, error) {
+	teams := make([]*TournamentTeam, 0)
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT
+			tournament_teams.id,
+			tournament_teams.name,
+			STRING_AGG(users.username, ', ' ORDER BY users.username) AS player_names
+		FROM tournament_teams
+			JOIN tournament_team_members ON tournament_team_members.team_id = tournament_teams.id
+			JOIN users ON users.id = tournament_team_members.user_id
+		WHERE tournament_teams.tournament_id = $1
+		GROUP BY tournament_teams.id
+		ORDER BY tournament_teams.id
+	`, tournamentID); err != nil {
+		return teams, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var team TournamentTeam
+		var playerNamesString string
+		if err := rows.Scan(&team.ID, &team.Name, &playerNamesString); err != nil {
+			return teams, err
+		}
+		team.Players = strings.Split(playerNamesString, ", ")
+		teams = append(teams, &team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return teams, err
+	}
+	rows.Close()
+
+	return teams, nil
+}
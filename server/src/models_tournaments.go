@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type Tournaments struct{}
+
+type Tournament struct {
+	ID               int        `json:"id"`
+	Name             string     `json:"name"`
+	VariantName      string     `json:"variant"`
+	NumPlayers       int        `json:"numPlayers"`
+	DatetimeCreated  time.Time  `json:"datetimeCreated"`
+	DatetimeFinished *time.Time `json:"datetimeFinished"` // Nil if the tournament is still going
+}
+
+func (*Tournaments) Insert(name string, variantID int, numPlayers int) (int, error) {
+	var id int
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO tournaments (name, variant_id, num_players)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, name, variantID, numPlayers).Scan(&id)
+	return id, err
+}
+
+// Get returns false if the tournament does not exist
+func (*Tournaments) Get(tournamentID int) (bool, *Tournament, error) {
+	tournament := &Tournament{}
+	var variantID int
+	if err := db.QueryRow(context.Background(), `
+		SELECT id, name, variant_id, num_players, datetime_created, datetime_finished
+		FROM tournaments
+		WHERE id = $1
+	`, tournamentID).Scan(
+		&tournament.ID,
+		&tournament.Name,
+		&variantID,
+		&tournament.NumPlayers,
+		&tournament.DatetimeCreated,
+		&tournament.DatetimeFinished,
+	); err == pgx.ErrNoRows {
+		return false, tournament, nil
+	} else if err != nil {
+		return false, tournament, err
+	}
+
+	if v, ok := getVariantNameFromID(variantID); !ok {
+		err := errors.New("failed to find a definition for variant " + strconv.Itoa(variantID))
+		return false, tournament, err
+	} else {
+		tournament.VariantName = v
+	}
+
+	return true, tournament, nil
+}
+
+func (*Tournaments) SetFinished(tournamentID int) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE tournaments
+		SET datetime_finished = NOW()
+		WHERE id = $1
+	`, tournamentID)
+	return err
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+type UserRatings struct{}
+
+type UserRating struct {
+	UserID       int     `json:"-"`
+	VariantClass int     `json:"-"` // See the "RatingClass" constants
+	Rating       float64 `json:"rating"`
+	NumGames     int     `json:"numGames"`
+}
+
+// Get returns the default rating if the user has not played any rated games of the variant class
+// yet
+func (*UserRatings) Get(userID int, variantClass int) (*UserRating, error) {
+	rating := &UserRating{
+		UserID:       userID,
+		VariantClass: variantClass,
+		Rating:       RatingDefault,
+	}
+
+	if err := db.QueryRow(context.Background(), `
+		SELECT rating, num_games
+		FROM user_ratings
+		WHERE user_id = $1
+			AND variant_class = $2
+	`, userID, variantClass).Scan(&rating.Rating, &rating.NumGames); err == pgx.ErrNoRows {
+		return rating, nil
+	} else if err != nil {
+		return rating, err
+	}
+
+	return rating, nil
+}
+
+// GetAll returns a rating for every variant class, in order
+// (the rating is the default one for the classes that the user has not played yet)
+func (*UserRatings) GetAll(userID int) ([]*UserRating, error) {
+	ratings := make([]*UserRating, 0, NumRatingClasses)
+	for i := 0; i < NumRatingClasses; i++ {
+		ratings = append(ratings, &UserRating{
+			UserID:       userID,
+			VariantClass: i,
+			Rating:       RatingDefault,
+		})
+	}
+
+	var rows pgx.Rows
+	if v, err := db.Query(context.Background(), `
+		SELECT variant_class, rating, num_games
+		FROM user_ratings
+		WHERE user_id = $1
+	`, userID); err != nil {
+		return ratings, err
+	} else {
+		rows = v
+	}
+
+	for rows.Next() {
+		var variantClass int
+		var rating float64
+		var numGames int
+		if err := rows.Scan(&variantClass, &rating, &numGames); err != nil {
+			return ratings, err
+		}
+
+		// Ignore the classes that no longer exist
+		if variantClass < 0 || variantClass >= NumRatingClasses {
+			continue
+		}
+		ratings[variantClass].Rating = rating
+		ratings[variantClass].NumGames = numGames
+	}
+
+	if err := rows.Err(); err != nil {
+		return ratings, err
+	}
+	rows.Close()
+
+	return ratings, nil
+}
+
+func (*UserRatings) Set(rating *UserRating) error {
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO user_ratings (user_id, variant_class, rating, num_games)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, variant_class) DO UPDATE
+		SET rating = EXCLUDED.rating, num_games = EXCLUDED.num_games
+	`, rating.UserID, rating.VariantClass, rating.Rating, rating.NumGames)
+	return err
+}
+
+// ReplaceAll deletes every existing player rating and team rating and then inserts the provided
+// ones (this is used when the ratings are recalculated from the game history)
+// Everything happens in a single transaction so that nobody can see a partially rebuilt table
+func (*UserRatings) ReplaceAll(ratings []*UserRating, teamRatings []*TeamRating) error {
+	var tx pgx.Tx
+	if v, err := db.Begin(context.Background()); err != nil {
+		return err
+	} else {
+		tx = v
+	}
+	// Rolling back a transaction that has already been committed does nothing
+	defer tx.Rollback(context.Background()) // nolint: errcheck
+
+	if _, err := tx.Exec(context.Background(), "DELETE FROM user_ratings"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context.Background(), "DELETE FROM team_ratings"); err != nil {
+		return err
+	}
+
+	valueArgs := make([]interface{}, 0)
+	for _, rating := range ratings {
+		valueArgs = append(
+			valueArgs,
+			rating.UserID,
+			rating.VariantClass,
+			rating.Rating,
+			rating.NumGames,
+		)
+	}
+	if err := bulkInsertTx(tx, `
+		INSERT INTO user_ratings (user_id, variant_class, rating, num_games)
+		VALUES %s
+	`, 4, valueArgs); err != nil {
+		return err
+	}
+
+	valueArgs = make([]interface{}, 0)
+	for _, rating := range teamRatings {
+		valueArgs = append(
+			valueArgs,
+			rating.UserIDs,
+			rating.VariantClass,
+			rating.Rating,
+			rating.NumGames,
+		)
+	}
+	if err := bulkInsertTx(tx, `
+		INSERT INTO team_ratings (user_ids, variant_class, rating, num_games)
+		VALUES %s
+	`, 4, valueArgs); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+// bulkInsertTx inserts the rows in chunks,
+// since PostgreSQL only allows 65535 parameters in a single query
+func bulkInsertTx(tx pgx.Tx, SQLString string, numArgsPerRow int, valueArgs []interface{}) error {
+	chunkSize := 10000 * numArgsPerRow
+	for start := 0; start < len(valueArgs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(valueArgs) {
+			end = len(valueArgs)
+		}
+		chunk := valueArgs[start:end]
+
+		chunkSQLString := getBulkInsertSQLSimple(SQLString, numArgsPerRow, len(chunk)/numArgsPerRow)
+		if _, err := tx.Exec(context.Background(), chunkSQLString, chunk...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
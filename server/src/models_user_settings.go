@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -26,6 +27,8 @@ type Settings struct {
 	CreateTableTimed                 bool    `json:"createTableTimed"`
 	CreateTableTimeBaseMinutes       float64 `json:"createTableTimeBaseMinutes"`
 	CreateTableTimePerTurnSeconds    int     `json:"createTableTimePerTurnSeconds"`
+	CreateTableTimeControl           int     `json:"createTableTimeControl"`
+	CreateTableTurnTimeLimitSeconds  int     `json:"createTableTurnTimeLimitSeconds"`
 	CreateTableSpeedrun              bool    `json:"createTableSpeedrun"`
 	CreateTableCardCycle             bool    `json:"createTableCardCycle"`
 	CreateTableDeckPlays             bool    `json:"createTableDeckPlays"`
@@ -34,6 +37,9 @@ type Settings struct {
 	CreateTableOneLessCard           bool    `json:"createTableOneLessCard"`
 	CreateTableAllOrNothing          bool    `json:"createTableAllOrNothing"`
 	CreateTableDetrimentalCharacters bool    `json:"createTableDetrimentalCharacters"`
+	CreateTableStartingClues         int     `json:"createTableStartingClues"`
+	CreateTableMaxClues              int     `json:"createTableMaxClues"`
+	CreateTableMaxStrikes            int     `json:"createTableMaxStrikes"`
 }
 
 var (
@@ -45,6 +51,9 @@ var (
 		CreateTableVariant:            "No Variant",
 		CreateTableTimeBaseMinutes:    2,
 		CreateTableTimePerTurnSeconds: 20,
+		CreateTableStartingClues:      engine.MaxClueNum,
+		CreateTableMaxClues:           engine.MaxClueNum,
+		CreateTableMaxStrikes:         engine.MaxStrikeNum,
 	}
 )
 
@@ -70,6 +79,8 @@ func (*UserSettings) Get(userID int) (Settings, error) {
 			create_table_timed,
 			create_table_time_base_minutes,
 			create_table_time_per_turn_seconds,
+			create_table_time_control,
+			create_table_turn_time_limit_seconds,
 			create_table_speedrun,
 			create_table_card_cycle,
 			create_table_deck_plays,
@@ -77,7 +88,10 @@ func (*UserSettings) Get(userID int) (Settings, error) {
 			create_table_one_extra_card,
 			create_table_one_less_card,
 			create_table_all_or_nothing,
-			create_table_detrimental_characters
+			create_table_detrimental_characters,
+			create_table_starting_clues,
+			create_table_max_clues,
+			create_table_max_strikes
 		FROM user_settings
 		WHERE user_id = $1
 	`, userID).Scan(
@@ -98,6 +112,8 @@ func (*UserSettings) Get(userID int) (Settings, error) {
 		&settings.CreateTableTimed,
 		&settings.CreateTableTimeBaseMinutes,
 		&settings.CreateTableTimePerTurnSeconds,
+		&settings.CreateTableTimeControl,
+		&settings.CreateTableTurnTimeLimitSeconds,
 		&settings.CreateTableSpeedrun,
 		&settings.CreateTableCardCycle,
 		&settings.CreateTableDeckPlays,
@@ -106,6 +122,9 @@ func (*UserSettings) Get(userID int) (Settings, error) {
 		&settings.CreateTableOneLessCard,
 		&settings.CreateTableAllOrNothing,
 		&settings.CreateTableDetrimentalCharacters,
+		&settings.CreateTableStartingClues,
+		&settings.CreateTableMaxClues,
+		&settings.CreateTableMaxStrikes,
 	); err == pgx.ErrNoRows {
 		return defaultSettings, nil
 	} else if err != nil {
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -41,6 +42,10 @@ func (*UserStats) Get(userID int, variantID int) (*UserStatsRow, error) {
 			best_score5_mod,
 			best_score6,
 			best_score6_mod,
+			best_score7,
+			best_score7_mod,
+			best_score8,
+			best_score8_mod,
 			average_score,
 			num_strikeouts
 		FROM user_stats
@@ -58,6 +63,10 @@ func (*UserStats) Get(userID int, variantID int) (*UserStatsRow, error) {
 		&stats.BestScores[3].Modifier,
 		&stats.BestScores[4].Score, // 6-player
 		&stats.BestScores[4].Modifier,
+		&stats.BestScores[5].Score, // 7-player
+		&stats.BestScores[5].Modifier,
+		&stats.BestScores[6].Score, // 8-player
+		&stats.BestScores[6].Modifier,
 		&stats.AverageScore,
 		&stats.NumStrikeouts,
 	); err == pgx.ErrNoRows {
@@ -92,6 +101,10 @@ func (*UserStats) GetAll(userID int) (map[int]*UserStatsRow, error) {
 			best_score5_mod,
 			best_score6,
 			best_score6_mod,
+			best_score7,
+			best_score7_mod,
+			best_score8,
+			best_score8_mod,
 			average_score,
 			num_strikeouts
 		FROM user_stats
@@ -120,6 +133,10 @@ func (*UserStats) GetAll(userID int) (map[int]*UserStatsRow, error) {
 			&stats.BestScores[3].Modifier,
 			&stats.BestScores[4].Score, // 6-player
 			&stats.BestScores[4].Modifier,
+			&stats.BestScores[5].Score, // 7-player
+			&stats.BestScores[5].Modifier,
+			&stats.BestScores[6].Score, // 8-player
+			&stats.BestScores[6].Modifier,
 			&stats.AverageScore,
 			&stats.NumStrikeouts,
 		); err != nil {
@@ -143,9 +160,11 @@ func (*UserStats) GetAll(userID int) (map[int]*UserStatsRow, error) {
 // The stats passed in as an argument do not have to contain "NumGames", "AverageScore",
 // or "NumStrikeouts"; those will be calculated from the database
 func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
-	// Validate that the BestScores slice contains 5 entries
-	if len(stats.BestScores) != 5 {
-		return errors.New("BestScores does not contain 5 entries (for 2 to 6 players)")
+	// Validate that the BestScores slice contains an entry for each amount of players
+	if len(stats.BestScores) != NumBestScores {
+		return errors.New("BestScores does not contain " + strconv.Itoa(NumBestScores) +
+			" entries (for " + strconv.Itoa(engine.MinPlayers) + " to " +
+			strconv.Itoa(engine.MaxPlayers) + " players)")
 	}
 
 	// First, check to see if they have a row in the stats table for this variant already
@@ -173,6 +192,8 @@ func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
 		}
 	}
 
+	// Games with bot accounts are not counted for humans
+	// (bot accounts still count them, since those are the only games that they play)
 	_, err := db.Exec(
 		context.Background(),
 		`
@@ -183,9 +204,12 @@ func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
 					FROM games
 						JOIN game_participants
 							ON game_participants.game_id = games.id
+						JOIN users
+							ON users.id = game_participants.user_id
 					WHERE game_participants.user_id = $1
 						AND games.variant_id = $2
 						AND games.speedrun = FALSE
+						AND (games.bot = FALSE OR users.bot = TRUE)
 				),
 				best_score2 = $3,
 				best_score2_mod = $4,
@@ -197,6 +221,10 @@ func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
 				best_score5_mod = $10,
 				best_score6 = $11,
 				best_score6_mod = $12,
+				best_score7 = $13,
+				best_score7_mod = $14,
+				best_score8 = $15,
+				best_score8_mod = $16,
 				average_score = (
 					/*
 					 * We enclose this query in an "COALESCE" so that it defaults to 0
@@ -206,20 +234,26 @@ func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
 					FROM games
 						JOIN game_participants
 							ON game_participants.game_id = games.id
+						JOIN users
+							ON users.id = game_participants.user_id
 					WHERE game_participants.user_id = $1
 						AND games.score != 0
 						AND games.variant_id = $2
 						AND games.speedrun = FALSE
+						AND (games.bot = FALSE OR users.bot = TRUE)
 				),
 				num_strikeouts = (
 					SELECT COUNT(games.id)
 					FROM games
 						JOIN game_participants
 							ON game_participants.game_id = games.id
+						JOIN users
+							ON users.id = game_participants.user_id
 					WHERE game_participants.user_id = $1
 						AND games.score = 0
 						AND games.variant_id = $2
 						AND games.speedrun = FALSE
+						AND (games.bot = FALSE OR users.bot = TRUE)
 				)
 			WHERE user_id = $1
 				AND variant_id = $2
@@ -236,6 +270,10 @@ func (*UserStats) Update(userID int, variantID int, stats *UserStatsRow) error {
 		stats.BestScores[3].Modifier,
 		stats.BestScores[4].Score, // 6-player
 		stats.BestScores[4].Modifier,
+		stats.BestScores[5].Score, // 7-player
+		stats.BestScores[5].Modifier,
+		stats.BestScores[6].Score, // 8-player
+		stats.BestScores[6].Modifier,
 	)
 	return err
 }
@@ -303,7 +341,7 @@ func (us *UserStats) UpdateAll(highestVariantID int) error {
 			stats := NewUserStatsRow()
 			totalScore := 0
 			for _, gameHistory := range gameHistoryList {
-				variant := variants[gameHistory.Options.VariantName]
+				variant := getVariant(gameHistory.Options.VariantName)
 				if variant.ID != variantID {
 					continue
 				}
@@ -364,12 +402,16 @@ func (*UserStats) BulkInsert(userID int, statsMap map[int]*UserStatsRow) error {
 			best_score5_mod,
 			best_score6,
 			best_score6_mod,
+			best_score7,
+			best_score7_mod,
+			best_score8,
+			best_score8_mod,
 			average_score,
 			num_strikeouts
 		)
 		VALUES %s
 	`
-	numArgsPerRow := 15
+	numArgsPerRow := 19
 	valueArgs := make([]interface{}, 0, numArgsPerRow*len(statsMap))
 	for variantID, stats := range statsMap {
 		valueArgs = append(
@@ -387,6 +429,10 @@ func (*UserStats) BulkInsert(userID int, statsMap map[int]*UserStatsRow) error {
 			stats.BestScores[3].Modifier,
 			stats.BestScores[4].Score,
 			stats.BestScores[4].Modifier,
+			stats.BestScores[5].Score,
+			stats.BestScores[5].Modifier,
+			stats.BestScores[6].Score,
+			stats.BestScores[6].Modifier,
 			stats.AverageScore,
 			stats.NumStrikeouts,
 		)
@@ -402,20 +448,29 @@ func fillBestScores(bestScores []*BestScore) {
 	// so use the bitmask to set the boolean values
 	for i := range bestScores {
 		modifier := bestScores[i].Modifier
-		if modifier.HasFlag(ScoreModifierDeckPlays) {
+		if modifier.HasFlag(engine.ScoreModifierDeckPlays) {
 			bestScores[i].DeckPlays = true
 		}
-		if modifier.HasFlag(ScoreModifierEmptyClues) {
+		if modifier.HasFlag(engine.ScoreModifierEmptyClues) {
 			bestScores[i].EmptyClues = true
 		}
-		if modifier.HasFlag(ScoreModifierOneExtraCard) {
+		if modifier.HasFlag(engine.ScoreModifierOneExtraCard) {
 			bestScores[i].OneExtraCard = true
 		}
-		if modifier.HasFlag(ScoreModifierOneLessCard) {
+		if modifier.HasFlag(engine.ScoreModifierOneLessCard) {
 			bestScores[i].OneLessCard = true
 		}
-		if modifier.HasFlag(ScoreModifierAllOrNothing) {
+		if modifier.HasFlag(engine.ScoreModifierAllOrNothing) {
 			bestScores[i].AllOrNothing = true
 		}
+		if modifier.HasFlag(engine.ScoreModifierClueLimits) {
+			bestScores[i].ClueLimits = true
+		}
+		if modifier.HasFlag(engine.ScoreModifierStrikeLimit) {
+			bestScores[i].StrikeLimit = true
+		}
+		if modifier.HasFlag(engine.ScoreModifierTakeback) {
+			bestScores[i].Takeback = true
+		}
 	}
 }
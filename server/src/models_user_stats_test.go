@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const testStatsVariantName = "No Variant"
+
+// testInsertBot is the same as "testInsertUser()", but for a bot account
+func testInsertBot(t *testing.T) User {
+	t.Helper()
+
+	username := "bot" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	var user User
+	if v, err := models.Users.InsertBot(username, normalizeString(username), ""); err != nil {
+		t.Fatal("Failed to insert the bot:", err)
+	} else {
+		user = v
+	}
+
+	t.Cleanup(func() {
+		if _, err := db.Exec(context.Background(), `
+			DELETE FROM users WHERE id = $1
+		`, user.ID); err != nil {
+			t.Error("Failed to delete the bot:", err)
+		}
+	})
+
+	return user
+}
+
+// testInsertGame writes a finished 2-player game to the database in the same way that
+// "Game.WriteDatabase()" does
+func testInsertGame(t *testing.T, users []User, score int) int {
+	t.Helper()
+
+	options := &engine.Options{
+		NumPlayers:  len(users),
+		VariantName: testStatsVariantName,
+	}
+	options.SetDefaultLimits()
+
+	bot := false
+	for _, user := range users {
+		if user.Bot {
+			bot = true
+		}
+	}
+
+	var gameID int
+	if v, err := models.Games.Insert(GameRow{
+		Name:             "test",
+		Options:          options,
+		Seed:             "p2v0stest",
+		Score:            score,
+		NumTurns:         50,
+		EndCondition:     engine.EndConditionNormal,
+		DatetimeStarted:  time.Now(),
+		DatetimeFinished: time.Now(),
+		Bot:              bot,
+	}); err != nil {
+		t.Fatal("Failed to insert the game:", err)
+	} else {
+		gameID = v
+	}
+
+	t.Cleanup(func() {
+		if _, err := db.Exec(context.Background(), `
+			DELETE FROM games WHERE id = $1
+		`, gameID); err != nil {
+			t.Error("Failed to delete the game:", err)
+		}
+	})
+
+	participants := make([]*GameParticipantsRow, 0)
+	for i, user := range users {
+		participants = append(participants, &GameParticipantsRow{
+			GameID:              gameID,
+			UserID:              user.ID,
+			Seat:                i,
+			CharacterAssignment: -1,
+		})
+	}
+	if err := models.GameParticipants.BulkInsert(participants); err != nil {
+		t.Fatal("Failed to insert the game participants:", err)
+	}
+
+	return gameID
+}
+
+func testUpdateUserStats(t *testing.T, user User) *UserStatsRow {
+	t.Helper()
+
+	variant := getVariant(testStatsVariantName)
+	var stats *UserStatsRow
+	if v, err := models.UserStats.Get(user.ID, variant.ID); err != nil {
+		t.Fatal("Failed to get the stats:", err)
+	} else {
+		stats = v
+	}
+	if err := models.UserStats.Update(user.ID, variant.ID, stats); err != nil {
+		t.Fatal("Failed to update the stats:", err)
+	}
+	if v, err := models.UserStats.Get(user.ID, variant.ID); err != nil {
+		t.Fatal("Failed to get the stats:", err)
+	} else {
+		stats = v
+	}
+
+	return stats
+}
+
+func TestUserStatsUpdateSkipsBotGames(t *testing.T) {
+	testInitDatabase(t)
+
+	alice := testInsertUser(t, "alice")
+	bob := testInsertUser(t, "bob")
+	bot := testInsertBot(t)
+
+	testInsertGame(t, []User{alice, bob}, 20)
+	testInsertGame(t, []User{alice, bot}, 0)
+	testInsertGame(t, []User{alice, bot}, 10)
+
+	// The next human game recalculates the stats from every game that they played
+	testInsertGame(t, []User{alice, bob}, 24)
+	stats := testUpdateUserStats(t, alice)
+	if stats.NumGames != 2 {
+		t.Errorf("The number of games is %d, expected 2.", stats.NumGames)
+	}
+	if stats.AverageScore != 22 {
+		t.Errorf("The average score is %f, expected 22.", stats.AverageScore)
+	}
+	if stats.NumStrikeouts != 0 {
+		t.Errorf("The number of strikeouts is %d, expected 0.", stats.NumStrikeouts)
+	}
+
+	// Bot accounts still count the games that they played
+	stats = testUpdateUserStats(t, bot)
+	if stats.NumGames != 2 {
+		t.Errorf("The number of games for the bot is %d, expected 2.", stats.NumGames)
+	}
+}
@@ -15,6 +15,7 @@ type User struct {
 	Username        string
 	PasswordHash    sql.NullString
 	OldPasswordHash sql.NullString
+	Bot             bool
 }
 
 func (*Users) Insert(
@@ -42,6 +43,27 @@ func (*Users) Insert(
 	}, nil
 }
 
+// InsertBot creates a bot account, which does not have a password
+// (the API token is set separately with the "UpdateAPIToken()" function)
+func (*Users) InsertBot(username string, normalizedUsername string, lastIP string) (User, error) {
+	var user User
+
+	var id int
+	if err := db.QueryRow(context.Background(), `
+		INSERT INTO users (username, normalized_username, bot, last_ip)
+		VALUES ($1, $2, TRUE, $3)
+		RETURNING id
+	`, username, normalizedUsername, lastIP).Scan(&id); err != nil {
+		return user, err
+	}
+
+	return User{
+		ID:       id,
+		Username: username,
+		Bot:      true,
+	}, nil
+}
+
 // We need to return the existing username in case they submitted the wrong case
 func (*Users) Get(username string) (bool, User, error) {
 	var user User
@@ -50,7 +72,8 @@ func (*Users) Get(username string) (bool, User, error) {
 			id,
 			username,
 			password_hash,
-			old_password_hash
+			old_password_hash,
+			bot
 		FROM users
 		WHERE username = $1
 	`, username).Scan(
@@ -58,6 +81,31 @@ func (*Users) Get(username string) (bool, User, error) {
 		&user.Username,
 		&user.PasswordHash,
 		&user.OldPasswordHash,
+		&user.Bot,
+	); err == pgx.ErrNoRows {
+		return false, user, nil
+	} else if err != nil {
+		return false, user, err
+	}
+
+	return true, user, nil
+}
+
+// GetFromAPIToken finds the bot account that corresponds to a hashed API token
+func (*Users) GetFromAPIToken(apiTokenHash string) (bool, User, error) {
+	var user User
+	if err := db.QueryRow(context.Background(), `
+		SELECT
+			id,
+			username,
+			bot
+		FROM users
+		WHERE api_token_hash = $1
+			AND bot = TRUE
+	`, apiTokenHash).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Bot,
 	); err == pgx.ErrNoRows {
 		return false, user, nil
 	} else if err != nil {
@@ -154,3 +202,86 @@ func (*Users) UpdatePassword(userID int, passwordHash string) error {
 	`, passwordHash, userID)
 	return err
 }
+
+// UpdateAPIToken marks the user as a bot account and replaces their API token
+// Bot accounts cannot log in with a password, so the password is also cleared
+func (*Users) UpdateAPIToken(userID int, apiTokenHash string) error {
+	_, err := db.Exec(context.Background(), `
+		UPDATE users
+		SET
+			bot = TRUE,
+			api_token_hash = $1,
+			password_hash = NULL,
+			old_password_hash = NULL
+		WHERE id = $2
+	`, apiTokenHash, userID)
+	return err
+}
+
+func (*Users) IsDeleted(userID int) (bool, error) {
+	var deleted bool
+	err := db.QueryRow(context.Background(), `
+		SELECT deleted
+		FROM users
+		WHERE id = $1
+	`, userID).Scan(&deleted)
+	return deleted, err
+}
+
+// Anonymize removes all of the personal data for a user
+// The row in the "users" table is kept (with a placeholder name) so that the games that they
+// played in are not deleted along with it
+func (*Users) Anonymize(userID int, placeholderName string) error {
+	var tx pgx.Tx
+	if v, err := db.Begin(context.Background()); err != nil {
+		return err
+	} else {
+		tx = v
+	}
+	// Rolling back a transaction that has already been committed does nothing
+	defer tx.Rollback(context.Background()) // nolint: errcheck
+
+	if _, err := tx.Exec(context.Background(), `
+		UPDATE users
+		SET
+			username = $1,
+			normalized_username = $2,
+			password_hash = NULL,
+			old_password_hash = NULL,
+			api_token_hash = NULL,
+			last_ip = '',
+			deleted = TRUE
+		WHERE id = $3
+	`, placeholderName, normalizeString(placeholderName), userID); err != nil {
+		return err
+	}
+
+	SQLStrings := []string{
+		"DELETE FROM user_settings WHERE user_id = $1",
+		"DELETE FROM user_friends WHERE user_id = $1 OR friend_id = $1",
+		"DELETE FROM user_reverse_friends WHERE user_id = $1 OR friend_id = $1",
+		"DELETE FROM chat_log WHERE user_id = $1",
+		"DELETE FROM chat_log_pm WHERE user_id = $1 OR recipient_id = $1",
+		"DELETE FROM game_tags WHERE user_id = $1",
+		"DELETE FROM game_annotations WHERE user_id = $1",
+		`
+			DELETE FROM game_participant_notes
+			WHERE game_participant_id IN (
+				SELECT id
+				FROM game_participants
+				WHERE user_id = $1
+			)
+		`,
+		// IP bans and mutes still apply, but they are no longer associated with the user
+		"UPDATE banned_ips SET user_id = NULL WHERE user_id = $1",
+		"UPDATE muted_ips SET user_id = NULL WHERE user_id = $1",
+		"UPDATE throttled_ips SET user_id = NULL WHERE user_id = $1",
+	}
+	for _, SQLString := range SQLStrings {
+		if _, err := tx.Exec(context.Background(), SQLString, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context.Background())
+}
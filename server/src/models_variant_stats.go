@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -36,6 +37,8 @@ func (*VariantStats) Get(variantID int) (VariantStatsRow, error) {
 			best_score4,
 			best_score5,
 			best_score6,
+			best_score7,
+			best_score8,
 			num_max_scores,
 			average_score,
 			num_strikeouts
@@ -48,6 +51,8 @@ func (*VariantStats) Get(variantID int) (VariantStatsRow, error) {
 		&stats.BestScores[2].Score, // 4-player
 		&stats.BestScores[3].Score, // 5-player
 		&stats.BestScores[4].Score, // 6-player
+		&stats.BestScores[5].Score, // 7-player
+		&stats.BestScores[6].Score, // 8-player
 		&stats.NumMaxScores,
 		&stats.AverageScore,
 		&stats.NumStrikeouts,
@@ -73,6 +78,8 @@ func (*VariantStats) GetAll() (map[int]VariantStatsRow, error) {
 			best_score4,
 			best_score5,
 			best_score6,
+			best_score7,
+			best_score8,
 			num_max_scores,
 			average_score,
 			num_strikeouts
@@ -96,6 +103,8 @@ func (*VariantStats) GetAll() (map[int]VariantStatsRow, error) {
 			&stats.BestScores[2].Score, // 4-player
 			&stats.BestScores[3].Score, // 5-player
 			&stats.BestScores[4].Score, // 6-player
+			&stats.BestScores[5].Score, // 7-player
+			&stats.BestScores[6].Score, // 8-player
 			&stats.NumMaxScores,
 			&stats.AverageScore,
 			&stats.NumStrikeouts,
@@ -115,9 +124,11 @@ func (*VariantStats) GetAll() (map[int]VariantStatsRow, error) {
 }
 
 func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow) error {
-	// Validate that the BestScores slice contains 5 entries
-	if len(stats.BestScores) != 5 {
-		return errors.New("BestScores does not contain 5 entries (for 2 to 6 players)")
+	// Validate that the BestScores slice contains an entry for each amount of players
+	if len(stats.BestScores) != NumBestScores {
+		return errors.New("BestScores does not contain " + strconv.Itoa(NumBestScores) +
+			" entries (for " + strconv.Itoa(engine.MinPlayers) + " to " +
+			strconv.Itoa(engine.MaxPlayers) + " players)")
 	}
 
 	// First, check to see if there is a row in the table for this variant already
@@ -144,6 +155,7 @@ func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow)
 		}
 	}
 
+	// Games with bot accounts are not counted
 	_, err := db.Exec(
 		context.Background(),
 		`
@@ -154,18 +166,22 @@ func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow)
 					FROM games
 					WHERE variant_id = $1
 						AND speedrun = FALSE
+						AND bot = FALSE
 				),
 				best_score2 = $2,
 				best_score3 = $3,
 				best_score4 = $4,
 				best_score5 = $5,
 				best_score6 = $6,
+				best_score7 = $7,
+				best_score8 = $8,
 				num_max_scores = (
 					SELECT COUNT(id)
 					FROM games
 					WHERE variant_id = $1
-						AND score = $7
+						AND score = $9
 						AND speedrun = FALSE
+						AND bot = FALSE
 				),
 				average_score = (
 					/*
@@ -177,6 +193,7 @@ func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow)
 					 WHERE variant_id = $1
 						AND score != 0
 						AND speedrun = FALSE
+						AND bot = FALSE
 				),
 				num_strikeouts = (
 					SELECT COUNT(id)
@@ -184,6 +201,7 @@ func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow)
 					WHERE variant_id = $1
 						AND score = 0
 						AND speedrun = FALSE
+						AND bot = FALSE
 				)
 			WHERE variant_id = $1
 		`,
@@ -193,6 +211,8 @@ func (*VariantStats) Update(variantID int, maxScore int, stats VariantStatsRow)
 		stats.BestScores[2].Score, // 4-player
 		stats.BestScores[3].Score, // 5-player
 		stats.BestScores[4].Score, // 6-player
+		stats.BestScores[5].Score, // 7-player
+		stats.BestScores[6].Score, // 8-player
 		maxScore,                  // num_max_scores
 	)
 	return err
@@ -219,9 +239,9 @@ func (vs *VariantStats) UpdateAll(highestVariantID int, maxScores []int) error {
 			continue
 		}
 
-		// Update scores for players 2 through 6
+		// Update scores for every amount of players
 		stats := NewVariantStatsRow()
-		for numPlayers := 2; numPlayers <= 6; numPlayers++ {
+		for numPlayers := engine.MinPlayers; numPlayers <= engine.MaxPlayers; numPlayers++ {
 			overallBestScore := 0
 
 			// Get the score for this player count (using a modifier of 0)
@@ -240,7 +260,11 @@ func (vs *VariantStats) UpdateAll(highestVariantID int, maxScores []int) error {
 					AND games.one_extra_card = FALSE
 					AND games.one_less_card = FALSE
 					AND games.all_or_nothing = FALSE
-			`, variantID, numPlayers).Scan(&bestScore); err != nil {
+					AND games.starting_clues = $3
+					AND games.max_clues = $3
+					AND games.max_strikes = $4
+					AND games.takeback = FALSE
+			`, variantID, numPlayers, engine.MaxClueNum, engine.MaxStrikeNum).Scan(&bestScore); err != nil {
 				return err
 			}
 
@@ -248,7 +272,7 @@ func (vs *VariantStats) UpdateAll(highestVariantID int, maxScores []int) error {
 				overallBestScore = bestScore
 			}
 
-			i := numPlayers - 2
+			i := numPlayers - engine.MinPlayers
 			stats.BestScores[i].Score = overallBestScore
 		}
 
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+)
+
+func testUpdateVariantStats(t *testing.T) VariantStatsRow {
+	t.Helper()
+
+	variant := getVariant(testStatsVariantName)
+	var stats VariantStatsRow
+	if v, err := models.VariantStats.Get(variant.ID); err != nil {
+		t.Fatal("Failed to get the variant stats:", err)
+	} else {
+		stats = v
+	}
+	if err := models.VariantStats.Update(variant.ID, variant.MaxScore, stats); err != nil {
+		t.Fatal("Failed to update the variant stats:", err)
+	}
+	if v, err := models.VariantStats.Get(variant.ID); err != nil {
+		t.Fatal("Failed to get the variant stats:", err)
+	} else {
+		stats = v
+	}
+
+	return stats
+}
+
+func TestVariantStatsUpdateSkipsBotGames(t *testing.T) {
+	testInitDatabase(t)
+
+	alice := testInsertUser(t, "alice")
+	bob := testInsertUser(t, "bob")
+	bot := testInsertBot(t)
+
+	// (the test database might have other games on the variant)
+	before := testUpdateVariantStats(t)
+
+	testInsertGame(t, []User{alice, bot}, 0)
+	testInsertGame(t, []User{alice, bot}, 25)
+	after := testUpdateVariantStats(t)
+	if after.NumGames != before.NumGames ||
+		after.NumMaxScores != before.NumMaxScores ||
+		after.AverageScore != before.AverageScore ||
+		after.NumStrikeouts != before.NumStrikeouts {
+
+		t.Errorf("The bot games changed the variant stats from %+v to %+v.", before, after)
+	}
+
+	testInsertGame(t, []User{alice, bob}, 25)
+	after = testUpdateVariantStats(t)
+	if after.NumGames != before.NumGames+1 || after.NumMaxScores != before.NumMaxScores+1 {
+		t.Errorf("The human game was not counted in the variant stats.")
+	}
+}
@@ -1,28 +1,11 @@
 package main
 
-// Options are things that are specified about the game upon table creation (before the game starts)
-// All of these are stored in the database as columns of the "games" table
-// A pointer to these options is copied into the Game struct when the game starts for convenience
-type Options struct {
-	NumPlayers int `json:"numPlayers"`
-	// StartingPlayer is a legacy field for games prior to April 2020
-	StartingPlayer        int    `json:"startingPlayer"`
-	VariantName           string `json:"variantName"`
-	Timed                 bool   `json:"timed"`
-	TimeBase              int    `json:"timeBase"`
-	TimePerTurn           int    `json:"timePerTurn"`
-	Speedrun              bool   `json:"speedrun"`
-	CardCycle             bool   `json:"cardCycle"`
-	DeckPlays             bool   `json:"deckPlays"`
-	EmptyClues            bool   `json:"emptyClues"`
-	OneExtraCard          bool   `json:"oneExtraCard"`
-	OneLessCard           bool   `json:"oneLessCard"`
-	AllOrNothing          bool   `json:"allOrNothing"`
-	DetrimentalCharacters bool   `json:"detrimentalCharacters"`
-}
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
 
 // ExtraOptions are extra specifications for the game; they are not recorded in the database
-// Similar to Options, a pointer to ExtraOptions is copied into the Game struct for convenience
+// Similar to engine.Options, a pointer to ExtraOptions is copied into the Game struct for convenience
 type ExtraOptions struct {
 	// -1 if an ongoing game, 0 if a JSON replay,
 	// a positive number if a database replay (or a "!replay" table)
@@ -36,15 +19,24 @@ type ExtraOptions struct {
 	// Replays have some predetermined values
 	// Some special game types also use these fields (e.g. "!replay" games)
 	CustomNumPlayers           int
-	CustomCharacterAssignments []*CharacterAssignment
+	CustomCharacterAssignments []*engine.CharacterAssignment
 	CustomSeed                 string
-	CustomDeck                 []*CardIdentity
-	CustomActions              []*GameAction
+	CustomDeck                 []*engine.CardIdentity
+	CustomActions              []*engine.GameAction
 
 	Restarted     bool   // Whether or not this game was created by clicking "Restart" in a shared replay
 	SetSeedSuffix string // Parsed from the game name for "!seed" games
 	SetReplay     bool   // True during "!replay" games
 	SetReplayTurn int    // Parsed from the game name for "!replay" games
+
+	DailyChallenge bool // True during "!daily" games
+
+	// Tables created with the "!tournament" prefix record their result in the standings
+	TournamentID     int
+	TournamentRound  int
+	TournamentTeamID int
+
+	Matchmaking bool // True for tables that were created by the matchmaking queue
 }
 
 // To minimize JSON output, we need to use pointers to each option instead of the normal type
@@ -54,6 +46,8 @@ type OptionsJSON struct {
 	Timed                 *bool   `json:"timed,omitempty"`
 	TimeBase              *int    `json:"timeBase,omitempty"`
 	TimePerTurn           *int    `json:"timePerTurn,omitempty"`
+	TimeControl           *int    `json:"timeControl,omitempty"`
+	TurnTimeLimit         *int    `json:"turnTimeLimit,omitempty"`
 	Speedrun              *bool   `json:"speedrun,omitempty"`
 	CardCycle             *bool   `json:"cardCycle,omitempty"`
 	DeckPlays             *bool   `json:"deckPlays,omitempty"`
@@ -62,28 +56,10 @@ type OptionsJSON struct {
 	OneLessCard           *bool   `json:"oneLessCard,omitempty"`
 	AllOrNothing          *bool   `json:"allOrNothing,omitempty"`
 	DetrimentalCharacters *bool   `json:"detrimentalCharacters,omitempty"`
-}
-
-// GetModifier computes the integer modifier for the game options,
-// corresponding to the "ScoreModifier" constants in "constants.go"
-func (o *Options) GetModifier() Bitmask {
-	var modifier Bitmask
-
-	if o.DeckPlays {
-		modifier.AddFlag(ScoreModifierDeckPlays)
-	}
-	if o.EmptyClues {
-		modifier.AddFlag(ScoreModifierEmptyClues)
-	}
-	if o.OneExtraCard {
-		modifier.AddFlag(ScoreModifierOneExtraCard)
-	}
-	if o.OneLessCard {
-		modifier.AddFlag(ScoreModifierOneLessCard)
-	}
-	if o.AllOrNothing {
-		modifier.AddFlag(ScoreModifierAllOrNothing)
-	}
-
-	return modifier
+	StartingClues         *int    `json:"startingClues,omitempty"`
+	MaxClues              *int    `json:"maxClues,omitempty"`
+	MaxStrikes            *int    `json:"maxStrikes,omitempty"`
+	// The name of a custom variant is specific to this server,
+	// so exports also include the definition of the variant
+	CustomVariant *engine.VariantJSON `json:"customVariant,omitempty"`
 }
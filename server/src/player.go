@@ -1,9 +1,5 @@
 package main
 
-import (
-	"time"
-)
-
 // Player is the object that represents the player before the game has started
 // (we separate the player object into two different objects;
 // one for the table and one for the game)
@@ -13,11 +9,20 @@ type Player struct {
 	// The user session corresponding to the player is copied here for convenience
 	// Even if the user disconnects, the orphaned session will remain,
 	// and it is safe to manually perform actions on their behalf with the orphaned session
-	Session   *Session `json:"-"` // Skip when serializing
-	Present   bool
-	Stats     PregameStats
-	Typing    bool
-	LastTyped time.Time
+	Session *Session `json:"-"` // Skip when serializing
+	Present bool
+	Stats   PregameStats
+	Typing  bool
+	// Players can mark themselves as ready before the game starts
+	// (this is reset whenever the options for the table change)
+	Ready bool
+
+	// Only bots have a bot type (see "bot.go")
+	// The bot object is not serialized, so it is recreated from the type when a table is restored
+	BotType string
+	Bot     Bot `json:"-"`
+	// External bots log in with a bot account and connect over a WebSocket like a human does
+	BotAccount bool
 }
 type PregameStats struct {
 	NumGames int           `json:"numGames"`
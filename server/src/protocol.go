@@ -0,0 +1,224 @@
+package main
+
+// The WebSocket protocol is versioned so that bots and alternative clients have a stable contract
+// Clients choose a version when they connect by adding a query parameter to the WebSocket URL
+// (e.g. "/ws?protocolVersion=2"); the negotiated version is echoed back in the "welcome" message
+//
+// Version 1 - The original protocol; the data of a command is decoded into "CommandData" without any
+//             validation (this is the default for clients that do not specify a version)
+// Version 2 - The data of a command is validated against its typed request (see
+//             "command_requests.go"); unknown fields, missing required fields, fields of the
+//             wrong type, and values that are not in the "enum" of a field are rejected with a
+//             "warning" message
+// Version 3 - Every message from the server has a sequence number between the command and the data
+//             (e.g. "chat 42 {...}"), so that a client that loses its connection can resume its
+//             session and only get the messages that it missed (see "websocket_resume.go")
+//
+// The JSON Schema of every command is generated from the same types and is served from
+// "/api/v1/protocol.json"
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MinProtocolVersion = 1
+	ProtocolVersion    = 3 // The current version
+
+	// The first version where the data of each command is validated
+	ProtocolVersionTyped = 2
+	// The first version where messages have sequence numbers and sessions can be resumed
+	ProtocolVersionSequenced = 3
+)
+
+// protocolInit checks the typed request of every command and records which fields it has
+// It is called at the end of "commandInit()"
+func protocolInit() {
+	commandDataType := reflect.TypeOf(CommandData{})
+	commandDataFields := make(map[string]reflect.StructField)
+	protocolGetFields(commandDataType, commandDataFields)
+
+	for name, command := range commandMap {
+		requestType := reflect.TypeOf(command.Request)
+		if requestType.Kind() != reflect.Ptr || requestType.Elem().Kind() != reflect.Struct {
+			logger.Fatal("The request of the \"" + name + "\" command must be a pointer to a struct.")
+		}
+
+		requestFields := make(map[string]reflect.StructField)
+		protocolGetFields(requestType.Elem(), requestFields)
+
+		command.fields = make(map[string]struct{})
+		command.required = make([]string, 0)
+		command.enums = make(map[string][]string)
+		for fieldName, field := range requestFields {
+			// The data is passed to the command handler as a "CommandData",
+			// so every field must also exist there with the same type
+			if commandDataField, ok := commandDataFields[fieldName]; !ok {
+				logger.Fatal("The \"" + fieldName + "\" field of the \"" + name + "\" command " +
+					"does not exist in \"CommandData\".")
+			} else if commandDataField.Type != field.Type {
+				logger.Fatal("The \"" + fieldName + "\" field of the \"" + name + "\" command " +
+					"has a different type than the one in \"CommandData\".")
+			}
+
+			command.fields[fieldName] = struct{}{}
+			if field.Tag.Get("protocol") == "required" {
+				command.required = append(command.required, fieldName)
+			}
+			if enum := protocolGetEnum(field); enum != nil {
+				if field.Type.Kind() != reflect.String {
+					logger.Fatal("The \"" + fieldName + "\" field of the \"" + name + "\" command " +
+						"has an \"enum\" tag but is not a string.")
+				}
+				command.enums[fieldName] = enum
+			}
+		}
+		sort.Strings(command.required)
+	}
+}
+
+// protocolGetFields gets the fields of a struct by their JSON name
+// (the fields of embedded structs are promoted in the same way as "encoding/json")
+func protocolGetFields(t reflect.Type, fields map[string]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			protocolGetFields(field.Type, fields)
+			continue
+		}
+
+		// Unexported fields are not decoded
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+}
+
+// protocolGetEnum gets the valid values of a field from its "enum" tag,
+// or nil if any value is valid
+func protocolGetEnum(field reflect.StructField) []string {
+	tag := field.Tag.Get("enum")
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// protocolParseVersion gets the version from the "protocolVersion" query parameter of the
+// WebSocket URL
+func protocolParseVersion(c *gin.Context) (int, bool) {
+	value := c.Query("protocolVersion")
+	if value == "" {
+		return MinProtocolVersion, true
+	}
+
+	if v, err := strconv.Atoi(value); err != nil {
+		return 0, false
+	} else if v < MinProtocolVersion || v > ProtocolVersion {
+		return 0, false
+	} else {
+		return v, true
+	}
+}
+
+// protocolValidate checks the data of a command against its typed request
+// It returns a description of the problem, or an empty string if the data is valid
+func protocolValidate(command *Command, jsonData []byte) string {
+	var rawFields map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &rawFields); err != nil {
+		return "The data must be a JSON object."
+	}
+
+	// Report the unknown fields in a consistent order
+	names := make([]string, 0, len(rawFields))
+	for name := range rawFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := command.fields[name]; !ok {
+			return "The \"" + name + "\" field is not valid for this command."
+		}
+	}
+
+	for _, name := range command.required {
+		if _, ok := rawFields[name]; !ok {
+			return "The \"" + name + "\" field is required."
+		}
+	}
+
+	request := reflect.New(reflect.TypeOf(command.Request).Elem()).Interface()
+	if err := json.Unmarshal(jsonData, request); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+			return "The \"" + typeErr.Field + "\" field has the wrong type."
+		}
+		return "The data is not valid."
+	}
+
+	// The fields have the right types, so the values of the enums must be strings
+	for _, name := range names {
+		enum, ok := command.enums[name]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(rawFields[name], &value); err != nil || !stringInSlice(value, enum) {
+			return "The \"" + name + "\" field must be one of: " + strings.Join(enum, ", ") + "."
+		}
+	}
+
+	return ""
+}
+
+// protocolSchema serves the JSON Schema of the data of every command,
+// along with the messages that the server sends back directly
+// https://json-schema.org/
+func protocolSchema(c *gin.Context) {
+	commands := make(map[string]interface{})
+	messages := map[string]interface{}{
+		"warning": apiOpenAPISchema(reflect.TypeOf(WarningMessage{}), nil),
+		"error":   apiOpenAPISchema(reflect.TypeOf(ErrorMessage{}), nil),
+		"resumed": apiOpenAPISchema(reflect.TypeOf(ResumedMessage{}), nil),
+	}
+	for name, command := range commandMap {
+		schema := apiOpenAPISchema(reflect.TypeOf(command.Request), nil)
+		schema["additionalProperties"] = false
+		if len(command.required) > 0 {
+			schema["required"] = command.required
+		}
+		if command.Response != nil {
+			schema["x-response"] = command.Response.Command
+			messages[command.Response.Command] = apiOpenAPISchema(
+				reflect.TypeOf(command.Response.Data),
+				nil,
+			)
+		}
+		commands[name] = schema
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"$schema":            "http://json-schema.org/draft-07/schema#",
+		"title":              WebsiteName + " WebSocket protocol",
+		"protocolVersion":    ProtocolVersion,
+		"minProtocolVersion": MinProtocolVersion,
+		"commands":           commands,
+		"messages":           messages,
+	})
+}
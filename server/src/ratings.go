@@ -0,0 +1,460 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/tevino/abool"
+)
+
+const (
+	RatingDefault = 1500
+
+	// The maximum amount that a 2-player game can change a rating by
+	// (players with only a few rated games move faster so that they reach their real rating sooner)
+	RatingK                   = 32
+	RatingKProvisional        = 64
+	RatingNumProvisionalGames = 10
+
+	// Until a variant has enough games, its average score is not a good measure of its difficulty,
+	// so we assume that a typical team gets this fraction of the max score
+	RatingMinVariantGames         = 20
+	RatingDefaultExpectedFraction = 0.75
+
+	// How many games are loaded from the database at a time when rebuilding the ratings
+	RatingRebuildChunkSize = 1000
+)
+
+// Players have a separate rating for each class of variant,
+// since being good at one kind of variant does not mean that a player is good at another
+const (
+	// Variants that only have normal suits (e.g. "No Variant", "6 Suits")
+	RatingClassNormal = iota
+	// Variants that have at least one special suit (e.g. "Rainbow (5 Suits)", "Black (6 Suits)")
+	RatingClassSpecialSuits
+	// Variants that change how clues or plays work (e.g. "Up or Down", "Rainbow-Ones")
+	RatingClassSpecialRules
+
+	NumRatingClasses
+)
+
+var (
+	ratingClassNames = []string{
+		"Normal",
+		"Special Suits",
+		"Special Rules",
+	}
+)
+
+var (
+	// Used to prevent the ratings of the same player from being updated by two games at once and to
+	// prevent games from being rated while the ratings are being rebuilt
+	ratingsMutex = sync.Mutex{}
+
+	// The ID of the last game that was included in a rebuild
+	// (games that finish while a rebuild is happening will already be counted by the rebuild)
+	ratingsRebuiltThroughGameID int
+
+	// Used to prevent an administrator from starting two rebuilds at the same time
+	rebuildingRatings = abool.New()
+)
+
+// isGameRated returns false for games that are not comparable to a normal game
+// (e.g. games with options that make them easier or harder, or games that did not finish)
+func isGameRated(options *engine.Options, endCondition int) bool {
+	if variant := getVariant(options.VariantName); variant == nil || variant.ID < 0 {
+		return false
+	}
+
+	if options.Speedrun ||
+		options.DetrimentalCharacters ||
+		options.GetModifier() != 0 {
+
+		return false
+	}
+
+	return endCondition != engine.EndConditionTerminated &&
+		endCondition != engine.EndConditionIdleTimeout
+}
+
+// getRatingClass returns the "RatingClass" constant for the variant
+func getRatingClass(variant *engine.Variant) int {
+	if variant.SpecialRank != -1 ||
+		variant.ColorCluesTouchNothing ||
+		variant.RankCluesTouchNothing ||
+		variant.AlternatingClues ||
+		variant.ClueStarved ||
+		variant.CowAndPig ||
+		variant.Duck ||
+		variant.ThrowItInAHole ||
+		variant.UpOrDown {
+
+		return RatingClassSpecialRules
+	}
+
+	for _, suit := range variant.Suits {
+		if !isNormalSuit(suit) {
+			return RatingClassSpecialSuits
+		}
+	}
+
+	return RatingClassNormal
+}
+
+// isNormalSuit returns true for suits that are only touched by their own color and by rank clues
+// (e.g. red, teal)
+func isNormalSuit(suit *engine.Suit) bool {
+	return len(suit.ClueColors) == 1 &&
+		suit.ClueColors[0] == suit.Name &&
+		!suit.OneOfEach &&
+		!suit.Prism &&
+		!suit.Reversed &&
+		!suit.AllClueColors &&
+		!suit.AllClueRanks &&
+		!suit.NoClueColors &&
+		!suit.NoClueRanks
+}
+
+// getVariantRating converts the average score of a variant into the rating of a team that is
+// expected to get that score
+// e.g. a variant where teams average 75% of the max score has a rating of ~1309
+func getVariantRating(maxScore int, variantStats VariantStatsRow) float64 {
+	expectedFraction := RatingDefaultExpectedFraction
+	if variantStats.NumGames >= RatingMinVariantGames && maxScore > 0 {
+		expectedFraction = variantStats.AverageScore / float64(maxScore)
+	}
+	expectedFraction = math.Max(0.05, math.Min(0.95, expectedFraction))
+
+	return RatingDefault + 400*math.Log10((1-expectedFraction)/expectedFraction)
+}
+
+// getExpectedResult returns the fraction of the max score that a team with the given rating is
+// expected to get on a variant with the given rating
+func getExpectedResult(teamRating float64, variantRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (variantRating-teamRating)/400))
+}
+
+// getRatingK returns the maximum amount that a 2-player game can change a rating by
+func getRatingK(numGames int) float64 {
+	if numGames < RatingNumProvisionalGames {
+		return RatingKProvisional
+	}
+	return RatingK
+}
+
+// updateRatings modifies the provided ratings based on the result of a game
+// The team is treated as a single player with the average rating of its members and the variant is
+// treated as the opponent
+// The result of the game is the fraction of the max score that the team got
+func updateRatings(ratings []*UserRating, variantRating float64, score int, maxScore int) {
+	if len(ratings) == 0 || maxScore <= 0 {
+		return
+	}
+
+	teamRating := 0.0
+	for _, rating := range ratings {
+		teamRating += rating.Rating
+	}
+	teamRating /= float64(len(ratings))
+
+	expected := getExpectedResult(teamRating, variantRating)
+	actual := float64(score) / float64(maxScore)
+
+	// In bigger games, each player is less responsible for the result
+	playerShare := float64(engine.MinPlayers) / float64(len(ratings))
+
+	for _, rating := range ratings {
+		rating.Rating += getRatingK(rating.NumGames) * playerShare * (actual - expected)
+		rating.NumGames++
+	}
+}
+
+// updateTeamRating is the same as "updateRatings()", but for a team that always plays together
+// (the whole team is responsible for the result, so the player count does not matter)
+func updateTeamRating(rating *TeamRating, variantRating float64, score int, maxScore int) {
+	if maxScore <= 0 {
+		return
+	}
+
+	expected := getExpectedResult(rating.Rating, variantRating)
+	actual := float64(score) / float64(maxScore)
+
+	rating.Rating += getRatingK(rating.NumGames) * (actual - expected)
+	rating.NumGames++
+}
+
+// String returns the rating rounded to the nearest whole number, since the fractional part is
+// not meaningful to players
+func (r *UserRating) String() string {
+	return getRatingString(r.Rating, r.NumGames)
+}
+
+func (r *TeamRating) String() string {
+	return getRatingString(r.Rating, r.NumGames)
+}
+
+func getRatingString(rating float64, numGames int) string {
+	msg := strconv.Itoa(int(math.Round(rating)))
+	if numGames < RatingNumProvisionalGames {
+		msg += " (provisional)"
+	}
+	return msg
+}
+
+// getTeamUserIDs returns the IDs of the players in the order that they are stored in the database
+// (the same players are the same team regardless of where they sat)
+func getTeamUserIDs(userIDs []int) []int {
+	teamUserIDs := make([]int, len(userIDs))
+	copy(teamUserIDs, userIDs)
+	sort.Ints(teamUserIDs)
+	return teamUserIDs
+}
+
+// WriteDatabaseRatings is called from "Game.WriteDatabaseStats()" before the stats for the variant
+// are updated (so that this game does not affect its own expected score)
+func (g *Game) WriteDatabaseRatings(variant *engine.Variant, variantStats VariantStatsRow) {
+	// Local variables
+	t := g.Table
+
+	if !isGameRated(g.Options, g.EndCondition) {
+		return
+	}
+
+	ratingsMutex.Lock()
+	defer ratingsMutex.Unlock()
+
+	if t.ExtraOptions.DatabaseID <= ratingsRebuiltThroughGameID {
+		return
+	}
+
+	ratingClass := getRatingClass(variant)
+	ratings := make([]*UserRating, 0)
+	userIDs := make([]int, 0)
+	for _, p := range t.Players {
+		if v, err := models.UserRatings.Get(p.ID, ratingClass); err != nil {
+			logger.Error("Failed to get the rating for user "+p.Name+":", err)
+			return
+		} else {
+			ratings = append(ratings, v)
+		}
+		userIDs = append(userIDs, p.ID)
+	}
+
+	var teamRating *TeamRating
+	if v, err := models.TeamRatings.Get(getTeamUserIDs(userIDs), ratingClass); err != nil {
+		logger.Error("Failed to get the team rating for game "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+		return
+	} else {
+		teamRating = v
+	}
+
+	variantRating := getVariantRating(variant.MaxScore, variantStats)
+	updateRatings(ratings, variantRating, g.Score, variant.MaxScore)
+	updateTeamRating(teamRating, variantRating, g.Score, variant.MaxScore)
+
+	for i, rating := range ratings {
+		if err := models.UserRatings.Set(rating); err != nil {
+			logger.Error("Failed to set the rating for user "+t.Players[i].Name+":", err)
+		}
+	}
+	if err := models.TeamRatings.Set(teamRating); err != nil {
+		logger.Error("Failed to set the team rating for game "+
+			strconv.Itoa(t.ExtraOptions.DatabaseID)+":", err)
+	}
+}
+
+// variantTotals keeps track of the stats for a variant while the ratings are being rebuilt
+type variantTotals struct {
+	NumGames          int
+	NumNonzeroScores  int
+	TotalNonzeroScore int
+}
+
+// Add counts a game towards the stats for its variant
+// This mirrors the queries in the "VariantStats.Update()" function
+func (totals *variantTotals) Add(gameHistory *GameHistory) {
+	if gameHistory.Options.Speedrun || len(gameHistory.BotNames) > 0 {
+		return
+	}
+
+	totals.NumGames++
+	if gameHistory.Score != 0 {
+		totals.NumNonzeroScores++
+		totals.TotalNonzeroScore += gameHistory.Score
+	}
+}
+
+func (totals *variantTotals) GetVariantStats() VariantStatsRow {
+	variantStats := NewVariantStatsRow()
+	variantStats.NumGames = totals.NumGames
+	if totals.NumNonzeroScores > 0 {
+		variantStats.AverageScore = float64(totals.TotalNonzeroScore) /
+			float64(totals.NumNonzeroScores)
+	}
+	return variantStats
+}
+
+// rebuildRatings recalculates every rating from the game history
+// It is meant to be called in a new goroutine
+func rebuildRatings() {
+	defer rebuildingRatings.UnSet()
+
+	ratingsMutex.Lock()
+	defer ratingsMutex.Unlock()
+
+	var databaseIDs []int
+	if v, err := models.Games.GetAllIDs(); err != nil {
+		logger.Error("Failed to get all of the game IDs:", err)
+		return
+	} else {
+		databaseIDs = v
+	}
+
+	logger.Info("Rebuilding the ratings from " + strconv.Itoa(len(databaseIDs)) + " games.")
+
+	// The variant stats are recalculated as we go so that each game is compared to the average
+	// score at the time that it was played
+	// (which is the same as when the game was originally rated)
+	variantTotalsMap := make(map[int]*variantTotals)
+
+	type userRatingKey struct {
+		UserID       int
+		VariantClass int
+	}
+	type teamRatingKey struct {
+		UserIDs      string // e.g. "[1 5 23]"
+		VariantClass int
+	}
+	userIDsMap := make(map[string]int)
+	ratingsMap := make(map[userRatingKey]*UserRating)
+	teamRatingsMap := make(map[teamRatingKey]*TeamRating)
+	numRatedGames := 0
+	for start := 0; start < len(databaseIDs); start += RatingRebuildChunkSize {
+		end := start + RatingRebuildChunkSize
+		if end > len(databaseIDs) {
+			end = len(databaseIDs)
+		}
+
+		chunk := databaseIDs[start:end]
+
+		var gameHistoryList []*GameHistory
+		if v, err := models.Games.GetHistoryCustomSort(chunk, "id ASC"); err != nil {
+			logger.Error("Failed to get the history for games "+strconv.Itoa(chunk[0])+
+				" through "+strconv.Itoa(chunk[len(chunk)-1])+":", err)
+			return
+		} else {
+			gameHistoryList = v
+		}
+
+		for _, gameHistory := range gameHistoryList {
+			variant := getVariant(gameHistory.Options.VariantName)
+			if variant == nil {
+				continue
+			}
+
+			totals, ok := variantTotalsMap[variant.ID]
+			if !ok {
+				totals = &variantTotals{}
+				variantTotalsMap[variant.ID] = totals
+			}
+
+			// Games with bot accounts are excluded from the ratings in the same way that they are
+			// excluded from the variant stats
+			if len(gameHistory.BotNames) == 0 &&
+				isGameRated(gameHistory.Options, gameHistory.EndCondition) {
+
+				ratingClass := getRatingClass(variant)
+				ratings := make([]*UserRating, 0)
+				userIDs := make([]int, 0)
+				for _, playerName := range gameHistory.PlayerNames {
+					userID, ok := userIDsMap[playerName]
+					if !ok {
+						if exists, v, err := models.Users.Get(playerName); err != nil {
+							logger.Error("Failed to get user \""+playerName+"\":", err)
+							return
+						} else if !exists {
+							logger.Error("User \"" + playerName + "\" does not exist in the " +
+								"database.")
+							return
+						} else {
+							userID = v.ID
+						}
+						userIDsMap[playerName] = userID
+					}
+					userIDs = append(userIDs, userID)
+
+					key := userRatingKey{
+						UserID:       userID,
+						VariantClass: ratingClass,
+					}
+					rating, ok := ratingsMap[key]
+					if !ok {
+						rating = &UserRating{
+							UserID:       userID,
+							VariantClass: ratingClass,
+							Rating:       RatingDefault,
+						}
+						ratingsMap[key] = rating
+					}
+					ratings = append(ratings, rating)
+				}
+
+				teamUserIDs := getTeamUserIDs(userIDs)
+				teamKey := teamRatingKey{
+					UserIDs:      fmt.Sprint(teamUserIDs),
+					VariantClass: ratingClass,
+				}
+				teamRating, ok := teamRatingsMap[teamKey]
+				if !ok {
+					teamRating = &TeamRating{
+						UserIDs:      teamUserIDs,
+						VariantClass: ratingClass,
+						Rating:       RatingDefault,
+					}
+					teamRatingsMap[teamKey] = teamRating
+				}
+
+				variantRating := getVariantRating(variant.MaxScore, totals.GetVariantStats())
+				updateRatings(ratings, variantRating, gameHistory.Score, variant.MaxScore)
+				updateTeamRating(teamRating, variantRating, gameHistory.Score, variant.MaxScore)
+				numRatedGames++
+			}
+
+			totals.Add(gameHistory)
+		}
+	}
+
+	ratings := make([]*UserRating, 0, len(ratingsMap))
+	for _, rating := range ratingsMap {
+		ratings = append(ratings, rating)
+	}
+	sort.Slice(ratings, func(i, j int) bool {
+		if ratings[i].UserID != ratings[j].UserID {
+			return ratings[i].UserID < ratings[j].UserID
+		}
+		return ratings[i].VariantClass < ratings[j].VariantClass
+	})
+
+	teamRatings := make([]*TeamRating, 0, len(teamRatingsMap))
+	for _, teamRating := range teamRatingsMap {
+		teamRatings = append(teamRatings, teamRating)
+	}
+
+	if err := models.UserRatings.ReplaceAll(ratings, teamRatings); err != nil {
+		logger.Error("Failed to write the rebuilt ratings to the database:", err)
+		return
+	}
+
+	if len(databaseIDs) > 0 {
+		ratingsRebuiltThroughGameID = databaseIDs[len(databaseIDs)-1]
+	}
+
+	logger.Info("Finished rebuilding the ratings. (" + strconv.Itoa(numRatedGames) + " games " +
+		"were rated for " + strconv.Itoa(len(userIDsMap)) + " players and " +
+		strconv.Itoa(len(teamRatings)) + " teams.)")
+}
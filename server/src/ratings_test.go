@@ -0,0 +1,221 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const testRatingTolerance = 0.0001
+
+func testNewUserRatings(numPlayers int, rating float64, numGames int) []*UserRating {
+	ratings := make([]*UserRating, 0)
+	for i := 0; i < numPlayers; i++ {
+		ratings = append(ratings, &UserRating{
+			UserID:   i + 1,
+			Rating:   rating,
+			NumGames: numGames,
+		})
+	}
+	return ratings
+}
+
+func testExpectRating(t *testing.T, rating float64, expected float64) {
+	t.Helper()
+
+	if math.Abs(rating-expected) > testRatingTolerance {
+		t.Errorf("The rating is %f, expected %f.", rating, expected)
+	}
+}
+
+func TestGetRatingClass(t *testing.T) {
+	for _, test := range []struct {
+		variantName string
+		expected    int
+	}{
+		{"No Variant", RatingClassNormal},
+		{"6 Suits", RatingClassNormal},
+		{"Rainbow (5 Suits)", RatingClassSpecialSuits},
+		{"Black (6 Suits)", RatingClassSpecialSuits},
+		{"Pink (5 Suits)", RatingClassSpecialSuits},
+		{"Brown (5 Suits)", RatingClassSpecialSuits},
+		{"Rainbow-Ones (5 Suits)", RatingClassSpecialRules},
+		{"Up or Down (5 Suits)", RatingClassSpecialRules},
+		{"Clue Starved (5 Suits)", RatingClassSpecialRules},
+		{"Cow & Pig (5 Suits)", RatingClassSpecialRules},
+	} {
+		variant := getVariant(test.variantName)
+		if variant == nil {
+			t.Fatal("The \"" + test.variantName + "\" variant does not exist.")
+		}
+		if ratingClass := getRatingClass(variant); ratingClass != test.expected {
+			t.Errorf("The rating class of \"%s\" is %d, expected %d.",
+				test.variantName, ratingClass, test.expected)
+		}
+	}
+}
+
+func TestGetVariantRating(t *testing.T) {
+	// Until a variant has enough games, a typical team is assumed to get 75% of the max score
+	variantStats := NewVariantStatsRow()
+	variantStats.NumGames = RatingMinVariantGames - 1
+	variantStats.AverageScore = 25
+	testExpectRating(t, getVariantRating(25, variantStats), 1309.1515)
+
+	// A variant where teams average half of the max score is as strong as a new player
+	variantStats.NumGames = RatingMinVariantGames
+	variantStats.AverageScore = 12.5
+	testExpectRating(t, getVariantRating(25, variantStats), RatingDefault)
+
+	// A harder variant has a higher rating
+	variantStats.AverageScore = 10
+	if getVariantRating(25, variantStats) <= RatingDefault {
+		t.Error("A variant with a low average score has a rating below the default.")
+	}
+}
+
+func TestUpdateRatings(t *testing.T) {
+	// A team that gets exactly the expected score does not gain or lose anything
+	ratings := testNewUserRatings(2, RatingDefault, RatingNumProvisionalGames)
+	updateRatings(ratings, RatingDefault, 10, 20)
+	for _, rating := range ratings {
+		testExpectRating(t, rating.Rating, RatingDefault)
+		if rating.NumGames != RatingNumProvisionalGames+1 {
+			t.Errorf("The number of games is %d, expected %d.",
+				rating.NumGames, RatingNumProvisionalGames+1)
+		}
+	}
+
+	// A 2-player team that gets the max score when half of it was expected gains half of "K"
+	ratings = testNewUserRatings(2, RatingDefault, RatingNumProvisionalGames)
+	updateRatings(ratings, RatingDefault, 25, 25)
+	for _, rating := range ratings {
+		testExpectRating(t, rating.Rating, RatingDefault+RatingK/2)
+	}
+
+	// A strikeout counts as a score of 0
+	ratings = testNewUserRatings(2, RatingDefault, RatingNumProvisionalGames)
+	updateRatings(ratings, RatingDefault, 0, 25)
+	for _, rating := range ratings {
+		testExpectRating(t, rating.Rating, RatingDefault-RatingK/2)
+	}
+}
+
+func TestUpdateRatingsProvisional(t *testing.T) {
+	ratings := []*UserRating{
+		{UserID: 1, Rating: RatingDefault, NumGames: 0},
+		{UserID: 2, Rating: RatingDefault, NumGames: RatingNumProvisionalGames},
+	}
+	updateRatings(ratings, RatingDefault, 25, 25)
+
+	// Players with only a few rated games move faster
+	testExpectRating(t, ratings[0].Rating, RatingDefault+RatingKProvisional/2)
+	testExpectRating(t, ratings[1].Rating, RatingDefault+RatingK/2)
+}
+
+func TestUpdateRatingsPlayerCount(t *testing.T) {
+	// In a 4-player game, each player is half as responsible for the result as in a 2-player game
+	ratings := testNewUserRatings(4, RatingDefault, RatingNumProvisionalGames)
+	updateRatings(ratings, RatingDefault, 25, 25)
+	for _, rating := range ratings {
+		testExpectRating(t, rating.Rating, RatingDefault+RatingK/4)
+	}
+}
+
+func TestUpdateRatingsUsesTheAverageRating(t *testing.T) {
+	// The team has an average rating of 1500, so the result is the same as for two 1500 players
+	ratings := []*UserRating{
+		{UserID: 1, Rating: 1400, NumGames: RatingNumProvisionalGames},
+		{UserID: 2, Rating: 1600, NumGames: RatingNumProvisionalGames},
+	}
+	updateRatings(ratings, RatingDefault, 25, 25)
+	testExpectRating(t, ratings[0].Rating, 1400+RatingK/2)
+	testExpectRating(t, ratings[1].Rating, 1600+RatingK/2)
+}
+
+func TestUpdateRatingsInvalidGame(t *testing.T) {
+	ratings := testNewUserRatings(2, RatingDefault, 0)
+	updateRatings(ratings, RatingDefault, 0, 0)
+	for _, rating := range ratings {
+		if rating.Rating != RatingDefault || rating.NumGames != 0 {
+			t.Error("A game without a max score changed the ratings.")
+		}
+	}
+}
+
+func TestUpdateTeamRating(t *testing.T) {
+	// The whole team is responsible for the result, regardless of the player count
+	rating := &TeamRating{
+		UserIDs:  []int{1, 2, 3, 4},
+		Rating:   RatingDefault,
+		NumGames: RatingNumProvisionalGames,
+	}
+	updateTeamRating(rating, RatingDefault, 25, 25)
+	testExpectRating(t, rating.Rating, RatingDefault+RatingK/2)
+	if rating.NumGames != RatingNumProvisionalGames+1 {
+		t.Errorf("The number of games is %d, expected %d.",
+			rating.NumGames, RatingNumProvisionalGames+1)
+	}
+
+	// New teams move faster
+	rating = &TeamRating{
+		UserIDs: []int{1, 2},
+		Rating:  RatingDefault,
+	}
+	updateTeamRating(rating, RatingDefault, 0, 25)
+	testExpectRating(t, rating.Rating, RatingDefault-RatingKProvisional/2)
+}
+
+func TestGetTeamUserIDs(t *testing.T) {
+	// The same players are the same team regardless of where they sat
+	userIDs := []int{23, 1, 5}
+	teamUserIDs := getTeamUserIDs(userIDs)
+
+	expected := []int{1, 5, 23}
+	for i := range expected {
+		if teamUserIDs[i] != expected[i] {
+			t.Fatalf("The team is %v, expected %v.", teamUserIDs, expected)
+		}
+	}
+	if userIDs[0] != 23 {
+		t.Error("The order of the players at the table was changed.")
+	}
+}
+
+func TestRatingString(t *testing.T) {
+	rating := &UserRating{
+		Rating:   1523.6,
+		NumGames: RatingNumProvisionalGames - 1,
+	}
+	if s := rating.String(); s != "1524 (provisional)" {
+		t.Errorf("The rating is shown as \"%s\", expected \"1524 (provisional)\".", s)
+	}
+
+	rating.NumGames = RatingNumProvisionalGames
+	if s := rating.String(); s != "1524" {
+		t.Errorf("The rating is shown as \"%s\", expected \"1524\".", s)
+	}
+}
+
+func TestVariantTotalsSkipsBotGames(t *testing.T) {
+	// The variant stats that the rebuild compares each game to must match the real variant stats
+	totals := &variantTotals{}
+	for _, gameHistory := range []*GameHistory{
+		{Options: &engine.Options{}, Score: 20},
+		{Options: &engine.Options{}, Score: 0},
+		{Options: &engine.Options{}, Score: 25, BotNames: []string{"Bot"}},
+		{Options: &engine.Options{Speedrun: true}, Score: 25},
+		{Options: &engine.Options{}, Score: 24},
+	} {
+		totals.Add(gameHistory)
+	}
+
+	variantStats := totals.GetVariantStats()
+	if variantStats.NumGames != 3 {
+		t.Errorf("The number of games is %d, expected 3.", variantStats.NumGames)
+	}
+	if variantStats.AverageScore != 22 {
+		t.Errorf("The average score is %f, expected 22.", variantStats.AverageScore)
+	}
+}
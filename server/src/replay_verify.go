@@ -0,0 +1,183 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/tevino/abool"
+)
+
+var (
+	// Used to prevent an administrator from starting two full verifications at the same time
+	verifyingReplays = abool.New()
+)
+
+// ReplayVerification is the result of re-simulating a game from the database
+type ReplayVerification struct {
+	DatabaseID int
+	// Describes every way in which the stored game differs from the simulation
+	// (this is empty if the replay is valid)
+	Problems []string
+}
+
+func (rv *ReplayVerification) String() string {
+	msg := "Game " + strconv.Itoa(rv.DatabaseID) + ": "
+	if len(rv.Problems) == 0 {
+		return msg + "OK"
+	}
+	return msg + strings.Join(rv.Problems, "; ")
+}
+
+// verifyReplay re-creates the deck of a game from its seed, re-applies every action that is stored
+// in the "game_actions" table, and then compares the result of the simulation to the score,
+// the end condition, and the number of turns that were recorded when the game finished
+// An error is only returned if the game could not be loaded from the database
+func verifyReplay(databaseID int) (*ReplayVerification, error) {
+	rv := &ReplayVerification{
+		DatabaseID: databaseID,
+		Problems:   make([]string, 0),
+	}
+
+	var options *engine.Options
+	if v, err := models.Games.GetOptions(databaseID); err != nil {
+		return nil, err
+	} else {
+		options = v
+	}
+
+	var seed string
+	if v, err := models.Games.GetSeed(databaseID); err != nil {
+		return nil, err
+	} else {
+		seed = v
+	}
+
+	var dbPlayers []*DBPlayer
+	if v, err := models.Games.GetPlayers(databaseID); err != nil {
+		return nil, err
+	} else {
+		dbPlayers = v
+	}
+
+	var actions []*engine.GameAction
+	if v, err := models.GameActions.GetAll(databaseID); err != nil {
+		return nil, err
+	} else {
+		actions = v
+	}
+
+	var gameHistory *GameHistory
+	if v, err := models.Games.GetHistory([]int{databaseID}); err != nil {
+		return nil, err
+	} else if len(v) == 0 {
+		rv.Problems = append(rv.Problems, "the game does not exist")
+		return rv, nil
+	} else {
+		gameHistory = v[0]
+	}
+
+	if len(dbPlayers) != options.NumPlayers {
+		rv.Problems = append(rv.Problems, "there are "+strconv.Itoa(len(dbPlayers))+
+			" participants but the game is recorded as having "+
+			strconv.Itoa(options.NumPlayers)+" players")
+		return rv, nil
+	}
+
+	// Re-create the game in the same way that "tableStart()" does for a replay from the database
+	g := engine.NewGame(getVariant(options.VariantName), options, seed)
+	g.InitDeck(nil)
+	g.ShuffleDeck()
+	for _, dbPlayer := range dbPlayers {
+		g.AddPlayer(dbPlayer.Name)
+	}
+	if options.DetrimentalCharacters {
+		characterAssignments := getCharacterAssignmentsFromDBPlayers(dbPlayers)
+		if err := g.SetCharacters(characterAssignments); err != nil {
+			rv.Problems = append(rv.Problems, "failed to set the characters: "+err.Error())
+			return rv, nil
+		}
+	}
+	g.ActivePlayerIndex = options.StartingPlayer
+	g.Deal()
+
+	for i, a := range actions {
+		playerIndex := g.ActivePlayerIndex
+		if a.Type == engine.ActionTypeEndGame && a.Target >= 0 && a.Target < len(g.Players) {
+			// The target of an "end game" action is the player who ended the game
+			// (it is -1 if the game ended from an idle timeout)
+			playerIndex = a.Target
+		}
+
+		if err := g.Apply(playerIndex, a); err != nil {
+			rv.Problems = append(rv.Problems, "the action at index "+strconv.Itoa(i)+
+				" (type "+strconv.Itoa(a.Type)+") failed: "+err.Error())
+			return rv, nil
+		}
+	}
+
+	// Mirror what happens at the end of a live game in "Game.End()"
+	score := g.Score
+	if g.EndCondition > engine.EndConditionNormal {
+		score = 0
+	}
+
+	if score != gameHistory.Score {
+		rv.Problems = append(rv.Problems, "the stored score is "+
+			strconv.Itoa(gameHistory.Score)+" but the simulated score is "+strconv.Itoa(score))
+	}
+	if g.EndCondition != gameHistory.EndCondition {
+		rv.Problems = append(rv.Problems, "the stored end condition is "+
+			strconv.Itoa(gameHistory.EndCondition)+" but the simulated end condition is "+
+			strconv.Itoa(g.EndCondition))
+	}
+	if g.Turn != gameHistory.NumTurns {
+		rv.Problems = append(rv.Problems, "the stored number of turns is "+
+			strconv.Itoa(gameHistory.NumTurns)+" but the simulated number of turns is "+
+			strconv.Itoa(g.Turn))
+	}
+
+	return rv, nil
+}
+
+// verifyAllReplays runs "verifyReplay()" on every game in the database and logs the games that do
+// not match the simulation
+// This takes a long time, so it is meant to be called in a new goroutine
+func verifyAllReplays() {
+	defer verifyingReplays.UnSet()
+
+	var databaseIDs []int
+	if v, err := models.Games.GetAllIDs(); err != nil {
+		logger.Error("Failed to get all of the game IDs:", err)
+		return
+	} else {
+		databaseIDs = v
+	}
+
+	logger.Info("Verifying " + strconv.Itoa(len(databaseIDs)) + " replays.")
+
+	mismatchedGameIDs := make([]int, 0)
+	numDatabaseErrors := 0
+	for _, databaseID := range databaseIDs {
+		var rv *ReplayVerification
+		if v, err := verifyReplay(databaseID); err != nil {
+			logger.Error("Failed to load game "+strconv.Itoa(databaseID)+" from the database:", err)
+			numDatabaseErrors++
+			continue
+		} else {
+			rv = v
+		}
+
+		if len(rv.Problems) > 0 {
+			logger.Warning(rv.String())
+			mismatchedGameIDs = append(mismatchedGameIDs, databaseID)
+		}
+	}
+
+	logger.Info("Finished verifying " + strconv.Itoa(len(databaseIDs)) + " replays. " +
+		"(" + strconv.Itoa(len(mismatchedGameIDs)) + " did not match the simulation and " +
+		strconv.Itoa(numDatabaseErrors) + " could not be loaded.)")
+	if len(mismatchedGameIDs) > 0 {
+		logger.Info("Bad game IDs:", mismatchedGameIDs)
+	}
+}
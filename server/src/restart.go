@@ -33,6 +33,10 @@ func restart() {
 	}
 	logger.Info("Finished writing all tables to disk.")
 
+	// The deadlines will be rescheduled when the tables are restored,
+	// so prevent them from firing on the tables that were just written to disk
+	scheduler.CancelAll()
+
 	sessionsMutex.RLock()
 	for _, s := range sessions {
 		// The sound has to be before the error, since the latter will cause a disconnect
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The names of the tasks that can be scheduled for a table
+// (a table can only have one pending task of each name at a time)
+const (
+	TaskTurnTimer = "turnTimer" // When the active player will run out of time
+	TaskIdle      = "idle"      // When the table will be ended for being idle
+	TaskStartIn   = "startIn"   // When the game will automatically start (from "/startin")
+	TaskTakeback  = "takeback"  // When the takeback vote will expire
+	TaskBotTurn   = "botTurn"   // When the active bot will take its turn
+	// When the players at a matchmaking table will run out of time to confirm that they are ready
+	TaskReadyCheck = "readyCheck"
+)
+
+var (
+	scheduler = NewScheduler()
+)
+
+// Scheduler keeps track of every deadline on the server that belongs to a table
+// (e.g. when a player will run out of time or when an idle table will be ended)
+// Each task can be canceled or rescheduled until it fires
+type Scheduler struct {
+	mutex sync.Mutex
+	tasks map[string]*ScheduledTask
+}
+
+// ScheduledTask is a function that will be run with the table locked once its deadline is reached
+type ScheduledTask struct {
+	Table    *Table
+	Name     string
+	Deadline time.Time
+
+	timer    *time.Timer
+	callback func(t *Table)
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		tasks: make(map[string]*ScheduledTask),
+	}
+}
+
+func getTaskKey(tableID uint64, name string) string {
+	return strconv.FormatUint(tableID, 10) + " " + name
+}
+
+// Schedule runs the callback after the delay, replacing any pending task with the same name on the
+// same table
+// The callback is invoked with the table lock held
+func (sc *Scheduler) Schedule(t *Table, name string, delay time.Duration, callback func(t *Table)) {
+	task := &ScheduledTask{
+		Table:    t,
+		Name:     name,
+		Deadline: time.Now().Add(delay),
+		callback: callback,
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	key := getTaskKey(t.ID, name)
+	if oldTask, ok := sc.tasks[key]; ok {
+		oldTask.timer.Stop()
+	}
+	sc.tasks[key] = task
+	task.timer = time.AfterFunc(delay, func() {
+		sc.run(task)
+	})
+}
+
+// Cancel removes the pending task with the given name on the given table, if any
+func (sc *Scheduler) Cancel(t *Table, name string) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	key := getTaskKey(t.ID, name)
+	if task, ok := sc.tasks[key]; ok && task.Table == t {
+		task.timer.Stop()
+		delete(sc.tasks, key)
+	}
+}
+
+// CancelTable removes every pending task on the given table (e.g. when the table is deleted)
+func (sc *Scheduler) CancelTable(t *Table) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for key, task := range sc.tasks {
+		if task.Table == t {
+			task.timer.Stop()
+			delete(sc.tasks, key)
+		}
+	}
+}
+
+// CancelAll removes every pending task (e.g. when the server is shutting down)
+func (sc *Scheduler) CancelAll() {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for key, task := range sc.tasks {
+		task.timer.Stop()
+		delete(sc.tasks, key)
+	}
+}
+
+// GetTasks returns a copy of every pending task, ordered by deadline
+func (sc *Scheduler) GetTasks() []ScheduledTask {
+	sc.mutex.Lock()
+	tasks := make([]ScheduledTask, 0, len(sc.tasks))
+	for _, task := range sc.tasks {
+		tasks = append(tasks, *task)
+	}
+	sc.mutex.Unlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Deadline.Before(tasks[j].Deadline)
+	})
+
+	return tasks
+}
+
+// run is called by the timer of a task in a new goroutine
+func (sc *Scheduler) run(task *ScheduledTask) {
+	// Check to see if the table still exists
+	t, exists := getTableAndLock(nil, task.Table.ID, true)
+	if !exists {
+		sc.remove(task)
+		return
+	}
+	defer t.Mutex.Unlock()
+	if t != task.Table {
+		sc.remove(task)
+		return
+	}
+
+	// The task might have been canceled or rescheduled while we were waiting for the table lock
+	if !sc.remove(task) {
+		return
+	}
+
+	task.callback(t)
+}
+
+// remove deletes the task from the map and returns true if it was still pending
+func (sc *Scheduler) remove(task *ScheduledTask) bool {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	key := getTaskKey(task.Table.ID, task.Name)
+	if sc.tasks[key] != task {
+		return false
+	}
+	delete(sc.tasks, key)
+
+	return true
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testTaskDelay   = 10 * time.Millisecond
+	testTaskTimeout = time.Second
+)
+
+// testScheduleSignal schedules a task that sends the table to the returned channel when it fires
+func testScheduleSignal(t *Table, name string, delay time.Duration) chan *Table {
+	fired := make(chan *Table, 1)
+	scheduler.Schedule(t, name, delay, func(t *Table) {
+		fired <- t
+	})
+	return fired
+}
+
+func testExpectFired(t *testing.T, fired chan *Table, table *Table) {
+	t.Helper()
+
+	select {
+	case firedTable := <-fired:
+		if firedTable != table {
+			t.Error("The task was run with the wrong table.")
+		}
+	case <-time.After(testTaskTimeout):
+		t.Error("The task did not fire.")
+	}
+}
+
+func testExpectNotFired(t *testing.T, fired chan *Table) {
+	t.Helper()
+
+	select {
+	case <-fired:
+		t.Error("The task fired.")
+	case <-time.After(testTaskDelay * 5):
+	}
+}
+
+func TestSchedulerRunsTask(t *testing.T) {
+	table := testAddTable(t)
+	fired := make(chan bool, 1)
+	scheduler.Schedule(table, TaskIdle, testTaskDelay, func(t *Table) {
+		// The callback is run with the table locked,
+		// so locking it again from another goroutine must block
+		locked := make(chan struct{})
+		go func() {
+			t.Mutex.Lock()
+			t.Mutex.Unlock() // nolint: staticcheck
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			fired <- false
+		case <-time.After(testTaskDelay):
+			fired <- true
+		}
+	})
+
+	select {
+	case wasLocked := <-fired:
+		if !wasLocked {
+			t.Error("The task was run without the table lock.")
+		}
+	case <-time.After(testTaskTimeout):
+		t.Fatal("The task did not fire.")
+	}
+
+	if len(testGetTableTasks(table)) != 0 {
+		t.Error("The task is still pending after it fired.")
+	}
+}
+
+func TestSchedulerReplacesTask(t *testing.T) {
+	table := testAddTable(t)
+	first := testScheduleSignal(table, TaskTurnTimer, testTaskDelay)
+	second := testScheduleSignal(table, TaskTurnTimer, testTaskDelay*2)
+
+	testExpectFired(t, second, table)
+	testExpectNotFired(t, first)
+}
+
+func TestSchedulerKeepsTasksWithDifferentNames(t *testing.T) {
+	table := testAddTable(t)
+	turnTimer := testScheduleSignal(table, TaskTurnTimer, testTaskDelay)
+	idle := testScheduleSignal(table, TaskIdle, testTaskDelay)
+
+	testExpectFired(t, turnTimer, table)
+	testExpectFired(t, idle, table)
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	table := testAddTable(t)
+	canceled := testScheduleSignal(table, TaskTakeback, testTaskDelay)
+	other := testScheduleSignal(table, TaskIdle, testTaskDelay)
+	scheduler.Cancel(table, TaskTakeback)
+
+	testExpectNotFired(t, canceled)
+	testExpectFired(t, other, table)
+}
+
+func TestSchedulerCancelTable(t *testing.T) {
+	table := testAddTable(t)
+	otherTable := testAddTable(t)
+	turnTimer := testScheduleSignal(table, TaskTurnTimer, testTaskDelay)
+	idle := testScheduleSignal(table, TaskIdle, testTaskDelay)
+	other := testScheduleSignal(otherTable, TaskIdle, testTaskDelay)
+	scheduler.CancelTable(table)
+
+	testExpectNotFired(t, turnTimer)
+	testExpectNotFired(t, idle)
+	testExpectFired(t, other, otherTable)
+}
+
+func TestSchedulerSkipsRemovedTables(t *testing.T) {
+	table := testAddTable(t)
+	fired := testScheduleSignal(table, TaskIdle, testTaskDelay)
+
+	// Simulate a table that was removed without canceling its tasks
+	tablesMutex.Lock()
+	delete(tables, table.ID)
+	tablesMutex.Unlock()
+
+	testExpectNotFired(t, fired)
+	if len(testGetTableTasks(table)) != 0 {
+		t.Error("The task of a removed table is still pending.")
+	}
+}
+
+func TestSchedulerGetTasksIsOrderedByDeadline(t *testing.T) {
+	table := testAddTable(t)
+	scheduler.Schedule(table, TaskIdle, time.Hour, func(t *Table) {})
+	scheduler.Schedule(table, TaskTurnTimer, time.Minute, func(t *Table) {})
+	scheduler.Schedule(table, TaskStartIn, 2*time.Minute, func(t *Table) {})
+
+	tasks := testGetTableTasks(table)
+	expected := []string{TaskTurnTimer, TaskStartIn, TaskIdle}
+	if len(tasks) != len(expected) {
+		t.Fatalf("There are %d tasks, expected %d.", len(tasks), len(expected))
+	}
+	for i, name := range expected {
+		if tasks[i].Name != name {
+			t.Errorf("Task %d is \"%s\", expected \"%s\".", i, tasks[i].Name, name)
+		}
+	}
+}
+
+// testGetTableTasks returns the pending tasks of a table (other tests might have pending tasks too)
+func testGetTableTasks(table *Table) []ScheduledTask {
+	tasks := make([]ScheduledTask, 0)
+	for _, task := range scheduler.GetTasks() {
+		if task.Table == table {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+const (
+	// The maximum amount of seeds that can be waiting to be analyzed in the background
+	SeedAnalysisMaxQueued = 100
+)
+
+var (
+	// Seeds created by the server are in the form of "p2v0s1"
+	// (e.g. 2 players, variant 0, seed suffix "1")
+	seedRegExp = regexp.MustCompile(`^p(\d+)v(\d+)s`)
+
+	// Running the solver is expensive, so we only allow one analysis at a time
+	seedAnalysisMutex sync.Mutex
+
+	// The seeds that are waiting to be analyzed in the background (see "queueSeedAnalysis()")
+	seedAnalysesQueued      = make(map[string]struct{})
+	seedAnalysesQueuedMutex sync.Mutex
+)
+
+// getSeedAnalysis returns the perfect-information analysis for a seed,
+// running the solver and caching the result in the database if it has not been analyzed yet
+// It returns nil if the seed cannot be analyzed (e.g. a seed from a JSON game or an "Up or Down"
+// variant)
+// The solver can take a long time, so this must not be called from an HTTP handler
+// (use "getCachedSeedAnalysis()" instead)
+func getSeedAnalysis(seed string) (*SeedAnalysis, error) {
+	seedAnalysisMutex.Lock()
+	defer seedAnalysisMutex.Unlock()
+
+	if exists, analysis, err := models.SeedAnalyses.Get(seed); err != nil {
+		return nil, err
+	} else if exists {
+		return &analysis, nil
+	}
+
+	variant, numPlayers, ok := parseSeedForAnalysis(seed)
+	if !ok {
+		return nil, nil
+	}
+
+	var result *engine.SolverResult
+	if v, err := engine.Solve(variant, numPlayers, seed, engine.SolverDefaultMaxNodes); err != nil {
+		logger.Info("Skipping the analysis of seed \"" + seed + "\": " + err.Error())
+		return nil, nil
+	} else {
+		result = v
+	}
+
+	analysis := SeedAnalysis{
+		MaxScore: result.MaxScore,
+		Complete: result.Complete,
+	}
+	if err := models.SeedAnalyses.Insert(seed, analysis); err != nil {
+		return nil, err
+	}
+	logger.Info("Analyzed seed \"" + seed + "\": max score " + strconv.Itoa(analysis.MaxScore) +
+		", complete: " + strconv.FormatBool(analysis.Complete))
+
+	return &analysis, nil
+}
+
+// getCachedSeedAnalysis returns the analysis for a seed without running the solver
+// If the seed has not been analyzed yet, the analysis is queued in the background and the returned
+// analysis is nil
+// The boolean is false if the seed cannot be analyzed at all
+func getCachedSeedAnalysis(seed string) (*SeedAnalysis, bool, error) {
+	if exists, analysis, err := models.SeedAnalyses.Get(seed); err != nil {
+		return nil, false, err
+	} else if exists {
+		return &analysis, true, nil
+	}
+
+	if _, _, ok := parseSeedForAnalysis(seed); !ok {
+		return nil, false, nil
+	}
+
+	queueSeedAnalysis(seed)
+	return nil, true, nil
+}
+
+// queueSeedAnalysis analyzes a seed in a new goroutine
+// (the analyses still run one at a time because of "seedAnalysisMutex")
+func queueSeedAnalysis(seed string) {
+	seedAnalysesQueuedMutex.Lock()
+	defer seedAnalysesQueuedMutex.Unlock()
+
+	if _, ok := seedAnalysesQueued[seed]; ok {
+		return
+	}
+	if len(seedAnalysesQueued) >= SeedAnalysisMaxQueued {
+		// The seed will be queued again the next time that someone asks for it
+		return
+	}
+	seedAnalysesQueued[seed] = struct{}{}
+
+	go func() {
+		defer func() {
+			seedAnalysesQueuedMutex.Lock()
+			delete(seedAnalysesQueued, seed)
+			seedAnalysesQueuedMutex.Unlock()
+		}()
+
+		if _, err := getSeedAnalysis(seed); err != nil {
+			logger.Error("Failed to get the analysis for seed \""+seed+"\":", err)
+		}
+	}()
+}
+
+// parseSeedForAnalysis gets the variant and the number of players that are encoded in a seed
+func parseSeedForAnalysis(seed string) (*engine.Variant, int, bool) {
+	match := seedRegExp.FindStringSubmatch(seed)
+	if match == nil {
+		return nil, 0, false
+	}
+	numPlayers, _ := strconv.Atoi(match[1])
+	variantID, _ := strconv.Atoi(match[2])
+	if v, ok := variantIDMap[variantID]; !ok {
+		return nil, 0, false
+	} else {
+		return variants[v], numPlayers, true
+	}
+}
+
+// seedAnalysisApplies returns true if a game was played with the rules that the solver assumes
+// (the analysis is cached by seed, so it only describes games with the default options)
+func seedAnalysisApplies(options *engine.Options) bool {
+	return options.GetModifier() == 0 && !options.DetrimentalCharacters
+}
+
+// Description returns a sentence that is suitable to show to players
+func (a *SeedAnalysis) Description(variant *engine.Variant) string {
+	if a.MaxScore == variant.MaxScore {
+		return "This deal is winnable with perfect information."
+	}
+	if a.Complete {
+		return "This deal is unwinnable, even with perfect information. " +
+			"(max achievable: " + strconv.Itoa(a.MaxScore) + ")"
+	}
+	return "With perfect information, a score of at least " + strconv.Itoa(a.MaxScore) +
+		" is achievable on this deal. (the solver gave up before finding a perfect score)"
+}
+
+// announceSeedAnalysis is meant to be called in a new goroutine at the end of a "!seed" game
+// The analysis assumes the default options, so it is not shown for games that change them
+func announceSeedAnalysis(tableID uint64, seed string, options *engine.Options) {
+	if !seedAnalysisApplies(options) {
+		return
+	}
+
+	var analysis *SeedAnalysis
+	if v, err := getSeedAnalysis(seed); err != nil {
+		logger.Error("Failed to get the analysis for seed \""+seed+"\":", err)
+		return
+	} else if v == nil {
+		return
+	} else {
+		analysis = v
+	}
+
+	// The table will have been converted to a shared replay by now
+	t, exists := getTableAndLock(nil, tableID, true)
+	if !exists {
+		return
+	}
+	defer t.Mutex.Unlock()
+
+	chatServerSend(analysis.Description(getVariant(options.VariantName)), t.GetRoomName())
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -92,15 +93,21 @@ func restoreTables() {
 		if t.ChatRead == nil {
 			t.ChatRead = make(map[int]int)
 		}
+		t.Options.SetDefaultLimits()
 
 		// Restore the circular references that could not be represented in JSON
+		// (the engine players are not serialized separately, since they are wrapped by the game
+		// players)
 		g := t.Game
 		g.Table = t
-		g.Options = t.Options
 		g.ExtraOptions = t.ExtraOptions
+		g.Game.Players = make([]*engine.GamePlayer, 0)
 		for _, gp := range g.Players {
 			gp.Game = g
+			g.Game.Players = append(g.Game.Players, gp.GamePlayer)
 		}
+		g.RestoreReferences(getVariant(t.Options.VariantName), t.Options)
+		g.InitActionCallback()
 
 		// Restore the types of the actions
 		for i, a := range g.Actions {
@@ -115,70 +122,70 @@ func restoreTables() {
 			actionType := action["type"].(string)
 
 			if actionType == "cardIdentity" {
-				actionCardIdentity := ActionCardIdentity{}
+				actionCardIdentity := engine.ActionCardIdentity{}
 				if err := mapstructure.Decode(a, &actionCardIdentity); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"cardIdentity\" action.")
 				}
 				g.Actions[i] = actionCardIdentity
 			} else if actionType == "clue" {
-				actionClue := ActionClue{}
+				actionClue := engine.ActionClue{}
 				if err := mapstructure.Decode(a, &actionClue); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"clue\" action.")
 				}
 				g.Actions[i] = actionClue
 			} else if actionType == "discard" {
-				actionDiscard := ActionDiscard{}
+				actionDiscard := engine.ActionDiscard{}
 				if err := mapstructure.Decode(a, &actionDiscard); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"discard\" action.")
 				}
 				g.Actions[i] = actionDiscard
 			} else if actionType == "draw" {
-				actionDraw := ActionDraw{}
+				actionDraw := engine.ActionDraw{}
 				if err := mapstructure.Decode(a, &actionDraw); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"draw\" action.")
 				}
 				g.Actions[i] = actionDraw
 			} else if actionType == "gameOver" {
-				actionGameOver := ActionGameOver{}
+				actionGameOver := engine.ActionGameOver{}
 				if err := mapstructure.Decode(a, &actionGameOver); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"gameOver\" action.")
 				}
 				g.Actions[i] = actionGameOver
 			} else if actionType == "play" {
-				actionPlay := ActionPlay{}
+				actionPlay := engine.ActionPlay{}
 				if err := mapstructure.Decode(a, &actionPlay); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"play\" action.")
 				}
 				g.Actions[i] = actionPlay
 			} else if actionType == "playerTimes" {
-				actionDraw := ActionDraw{}
+				actionDraw := engine.ActionDraw{}
 				if err := mapstructure.Decode(a, &actionDraw); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"playerTimes\" action.")
 				}
 				g.Actions[i] = actionDraw
 			} else if actionType == "strike" {
-				actionStrike := ActionStrike{}
+				actionStrike := engine.ActionStrike{}
 				if err := mapstructure.Decode(a, &actionStrike); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"strike\" action.")
 				}
 				g.Actions[i] = actionStrike
 			} else if actionType == "status" {
-				actionStatus := ActionStatus{}
+				actionStatus := engine.ActionStatus{}
 				if err := mapstructure.Decode(a, &actionStatus); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"status\" action.")
 				}
 				g.Actions[i] = actionStatus
 			} else if actionType == "turn" {
-				actionTurn := ActionTurn{}
+				actionTurn := engine.ActionTurn{}
 				if err := mapstructure.Decode(a, &actionTurn); err != nil {
 					logger.Fatal("Failed to convert the action " + strconv.Itoa(i) + " of table " +
 						strconv.FormatUint(t.ID, 10) + " to a \"turn\" action.")
@@ -201,23 +208,32 @@ func restoreTables() {
 			// forced to refresh
 			g.Players[g.ActivePlayerIndex].Time += 20 * time.Second
 
-			// Players will never run out of time on restored tables because the "CheckTimer()"
-			// function was never initiated; manually do this
-			go g.CheckTimer(g.Turn, g.PauseCount, g.Players[g.ActivePlayerIndex])
+			// Deadlines are not serialized, so the clock of the current player must be rescheduled
+			// (unless the game was paused at the time of the restart)
+			if !g.Paused {
+				g.ScheduleTimer()
+			}
 		}
 
+		// A pending takeback vote would never time out, since the "TaskTakeback" task of the
+		// scheduler is not restored (see "takeback()"), so discard it
+		g.TakebackRequested = false
+
 		tables[t.ID] = t
 		// (we don't need to lock "tablesMutex" because we are still in the synchronous phase of
 		// startup)
 		logger.Info(t.GetName() + "Restored table.")
 
+		// The bot objects were not serialized, so recreate them and catch them up on the game
+		t.StartBots()
+
 		if err := os.Remove(tablePath); err != nil {
 			logger.Fatal("Failed to delete \""+tablePath+"\":", err)
 		}
 
-		// Restored tables will never be automatically terminated due to idleness because the
-		// "CheckIdle()" function was never initiated; manually do this
-		go t.CheckIdle()
+		// Deadlines are not serialized, so restored tables would never be automatically terminated
+		// due to idleness unless we reschedule the idle timeout
+		t.ScheduleIdle()
 	}
 
 	// (we do not need to adjust the "tableIDCounter" variable because
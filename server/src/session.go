@@ -27,6 +27,13 @@ func (s *Session) Emit(command string, d interface{}) {
 		ds = string(dj)
 	}
 
+	// Messages to clients that use sequence numbers go through their stream so that they can be
+	// replayed after a reconnect (see "session_stream.go")
+	if stream := s.Stream(); stream != nil {
+		stream.Send(command, ds)
+		return
+	}
+
 	// Send the message as bytes
 	msg := command + " " + ds
 	bytes := []byte(msg)
@@ -36,6 +43,10 @@ func (s *Session) Emit(command string, d interface{}) {
 	}
 }
 
+type WarningMessage struct {
+	Warning string `json:"warning"`
+}
+
 func (s *Session) Warning(message string) {
 	// Specify a default warning message
 	if message == "" {
@@ -44,14 +55,15 @@ func (s *Session) Warning(message string) {
 
 	logger.Info("Warning - " + message + " - " + s.Username())
 
-	type WarningMessage struct {
-		Warning string `json:"warning"`
-	}
 	s.Emit("warning", &WarningMessage{
 		message,
 	})
 }
 
+type ErrorMessage struct {
+	Error string `json:"error"`
+}
+
 // Sent to the client if either their command was unsuccessful or something else went wrong
 func (s *Session) Error(message string) {
 	// Specify a default error message
@@ -61,9 +73,6 @@ func (s *Session) Error(message string) {
 
 	logger.Info("Error - " + message + " - " + s.Username())
 
-	type ErrorMessage struct {
-		Error string `json:"error"`
-	}
 	s.Emit("error", &ErrorMessage{
 		message,
 	})
@@ -2,6 +2,8 @@ package main
 
 import (
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 /*
@@ -20,6 +22,7 @@ type UserMessage struct {
 	TableID    uint64 `json:"tableID"`
 	Hyphenated bool   `json:"hyphenated"`
 	Inactive   bool   `json:"inactive"`
+	Bot        bool   `json:"bot"`
 }
 
 func makeUserMessage(s *Session) *UserMessage {
@@ -30,6 +33,7 @@ func makeUserMessage(s *Session) *UserMessage {
 		TableID:    s.TableID(),
 		Hyphenated: s.Hyphenated(),
 		Inactive:   s.Inactive(),
+		Bot:        s.Bot(),
 	}
 }
 
@@ -73,6 +77,8 @@ type TableMessage struct {
 	Timed             bool     `json:"timed"`
 	TimeBase          int      `json:"timeBase"`
 	TimePerTurn       int      `json:"timePerTurn"`
+	TimeControl       int      `json:"timeControl"`
+	TurnTimeLimit     int      `json:"turnTimeLimit"`
 	SharedReplay      bool     `json:"sharedReplay"`
 	Progress          int      `json:"progress"`
 	Players           []string `json:"players"`
@@ -104,6 +110,8 @@ func makeTableMessage(s *Session, t *Table) *TableMessage {
 		Timed:             t.Options.Timed,
 		TimeBase:          t.Options.TimeBase,
 		TimePerTurn:       t.Options.TimePerTurn,
+		TimeControl:       t.Options.TimeControl,
+		TurnTimeLimit:     t.Options.TurnTimeLimit,
 		SharedReplay:      t.Replay,
 		Progress:          t.Progress,
 		Players:           players,
@@ -218,6 +226,34 @@ func (s *Session) NotifyConnected(t *Table) {
 	})
 }
 
+// NotifyGameActionList will send someone every action that has happened in the game thus far
+// (e.g. when they load the game or when the action history was rewritten by a takeback)
+func (s *Session) NotifyGameActionList(t *Table) {
+	g := t.Game
+
+	// Check to see if we need to remove some card information
+	scrubbedActions := make([]interface{}, 0)
+	if !t.Replay {
+		for _, action := range g.Actions {
+			scrubbedAction := CheckScrub(t, action, s.UserID())
+			scrubbedActions = append(scrubbedActions, scrubbedAction)
+		}
+	} else {
+		// The person requesting the game state is not an active player
+		// (and not a spectator shadowing a player), so we do not need to hide any information
+		scrubbedActions = g.Actions
+	}
+
+	type GameActionListMessage struct {
+		TableID uint64        `json:"tableID"`
+		List    []interface{} `json:"list"`
+	}
+	s.Emit("gameActionList", &GameActionListMessage{
+		TableID: t.ID,
+		List:    scrubbedActions,
+	})
+}
+
 func (s *Session) NotifyGameAction(t *Table, action interface{}) {
 	scrubbedAction := CheckScrub(t, action, s.UserID())
 
@@ -307,8 +343,8 @@ func (s *Session) NotifyCardIdentities(t *Table) {
 	g := t.Game
 
 	type CardIdentitiesMessage struct {
-		TableID        uint64          `json:"tableID"`
-		CardIdentities []*CardIdentity `json:"cardIdentities"`
+		TableID        uint64                 `json:"tableID"`
+		CardIdentities []*engine.CardIdentity `json:"cardIdentities"`
 	}
 	s.Emit("cardIdentities", &CardIdentitiesMessage{
 		TableID:        t.ID,
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+)
+
+const (
+	// The number of messages that are kept for each user so that they can be replayed after a
+	// reconnect
+	SessionStreamBufferSize = 500
+)
+
+// SessionStream numbers the messages that are sent to a user and keeps the most recent ones
+// It is used by clients that speak a protocol version with sequence numbers (see "protocol.go")
+// When a user resumes their session from a new WebSocket connection, the stream is moved to the new
+// session (see "websocket_resume.go")
+type SessionStream struct {
+	// The session ID of the session that created the stream
+	ID uint64
+
+	mutex    sync.Mutex
+	session  *Session // The session that messages are currently written to
+	seq      uint64   // The sequence number of the most recent message
+	messages [][]byte // The oldest message is first
+	ended    bool
+}
+
+// ResumedMessage is sent before the missed messages are replayed
+type ResumedMessage struct {
+	StreamID  uint64 `json:"streamID"`
+	NumMissed int    `json:"numMissed"`
+}
+
+func NewSessionStream(s *Session) *SessionStream {
+	return &SessionStream{
+		ID:       s.SessionID(),
+		session:  s,
+		messages: make([][]byte, 0),
+	}
+}
+
+// Send numbers a message, stores it, and then writes it to the current session
+// The message is stored even if the write fails so that it can be replayed later
+// (e.g. while the user is disconnected)
+func (ss *SessionStream) Send(command string, data string) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	ss.seq++
+	msg := []byte(command + " " + strconv.FormatUint(ss.seq, 10) + " " + data)
+	ss.messages = append(ss.messages, msg)
+	if len(ss.messages) > SessionStreamBufferSize {
+		ss.messages = ss.messages[1:]
+	}
+
+	if err := ss.session.Write(msg); err != nil {
+		// This can routinely fail if the session is closed
+		// (in which case the message will be replayed if the user resumes their session)
+		return
+	}
+}
+
+// Resume moves the stream to a new session and writes every message after the given sequence
+// number to it
+// It returns false if some of the missed messages are no longer in the buffer
+func (ss *SessionStream) Resume(s *Session, lastSeq uint64) bool {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if ss.ended || lastSeq > ss.seq {
+		return false
+	}
+	numMissed := int(ss.seq - lastSeq)
+	if numMissed > len(ss.messages) {
+		return false
+	}
+
+	ss.session = s
+
+	// The "resumed" message has a sequence number of 0 since it is not stored
+	var data []byte
+	if v, err := json.Marshal(&ResumedMessage{
+		StreamID:  ss.ID,
+		NumMissed: numMissed,
+	}); err != nil {
+		logger.Error("Failed to marshal the resumed message:", err)
+		return false
+	} else {
+		data = v
+	}
+	if err := s.Write([]byte("resumed 0 " + string(data))); err != nil {
+		// The new connection was already closed; they can try to resume again
+		return true
+	}
+	for _, msg := range ss.messages[len(ss.messages)-numMissed:] {
+		if err := s.Write(msg); err != nil {
+			return true
+		}
+	}
+
+	return true
+}
+
+// End prevents the stream from being resumed
+// (e.g. when the server logs the user out)
+func (ss *SessionStream) End() {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	ss.ended = true
+}
+
+func (ss *SessionStream) Ended() bool {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	return ss.ended
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	melody "gopkg.in/olahol/melody.v1"
+)
+
+const (
+	testStreamID      = 123
+	testStreamTimeout = 5 * time.Second
+)
+
+// testConnectSession opens a real WebSocket connection and returns the session on the server side
+// and the connection on the client side
+func testConnectSession(t *testing.T, sessionID uint64) (*Session, *websocket.Conn) {
+	t.Helper()
+
+	// This is configured in the same way as the real router (see "websocketInit()")
+	testMelody := melody.New()
+	testMelody.Config.MessageBufferSize = SessionStreamBufferSize * 2
+	connected := make(chan *melody.Session, 1)
+	testMelody.HandleConnect(func(ms *melody.Session) {
+		connected <- ms
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys := map[string]interface{}{
+			"sessionID": sessionID,
+		}
+		if err := testMelody.HandleRequestWithKeys(w, r, keys); err != nil {
+			t.Error("Failed to handle the WebSocket request:", err)
+		}
+	}))
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	var conn *websocket.Conn
+	if v, _, err := websocket.DefaultDialer.Dial(url, nil); err != nil {
+		t.Fatal("Failed to connect:", err)
+	} else {
+		conn = v
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		if err := testMelody.Close(); err != nil {
+			t.Error("Failed to close the router:", err)
+		}
+		server.Close()
+	})
+
+	select {
+	case ms := <-connected:
+		return &Session{ms}, conn
+	case <-time.After(testStreamTimeout):
+		t.Fatal("The session was not created.")
+		return nil, nil
+	}
+}
+
+// testDisconnect closes the connection from the client side and waits for the server to notice
+func testDisconnect(t *testing.T, s *Session, conn *websocket.Conn) {
+	t.Helper()
+
+	conn.Close()
+	deadline := time.Now().Add(testStreamTimeout)
+	for !s.IsClosed() {
+		if time.Now().After(deadline) {
+			t.Fatal("The session was not closed.")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testReadMessage(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+
+	if err := conn.SetReadDeadline(time.Now().Add(testStreamTimeout)); err != nil {
+		t.Fatal("Failed to set the read deadline:", err)
+	}
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal("Failed to read a message:", err)
+	}
+	return string(msg)
+}
+
+func testExpectMessage(t *testing.T, conn *websocket.Conn, expected string) {
+	t.Helper()
+
+	if msg := testReadMessage(t, conn); msg != expected {
+		t.Fatalf("The message was \"%s\", expected \"%s\".", msg, expected)
+	}
+}
+
+func testExpectResumed(t *testing.T, conn *websocket.Conn, numMissed int) {
+	t.Helper()
+
+	msg := testReadMessage(t, conn)
+	prefix := "resumed 0 "
+	if !strings.HasPrefix(msg, prefix) {
+		t.Fatalf("The message was \"%s\", expected a \"resumed\" message.", msg)
+	}
+
+	var resumed ResumedMessage
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(msg, prefix)), &resumed); err != nil {
+		t.Fatal("Failed to unmarshal the resumed message:", err)
+	}
+	if resumed.StreamID != testStreamID || resumed.NumMissed != numMissed {
+		t.Fatalf("The resumed message was %+v, expected stream %d with %d missed messages.",
+			resumed, testStreamID, numMissed)
+	}
+}
+
+func TestSessionStreamSend(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+
+	stream.Send("chat", `{"msg":"hello"}`)
+	stream.Send("chat", `{"msg":"world"}`)
+	testExpectMessage(t, conn, `chat 1 {"msg":"hello"}`)
+	testExpectMessage(t, conn, `chat 2 {"msg":"world"}`)
+}
+
+func TestSessionStreamResume(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+	if stream.ID != testStreamID {
+		t.Fatalf("The stream ID is %d, expected %d.", stream.ID, testStreamID)
+	}
+
+	for i := 1; i <= 3; i++ {
+		stream.Send("test", strconv.Itoa(i))
+	}
+	for i := 1; i <= 3; i++ {
+		testExpectMessage(t, conn, "test "+strconv.Itoa(i)+" "+strconv.Itoa(i))
+	}
+
+	// The messages that are sent while they are disconnected are kept
+	testDisconnect(t, s, conn)
+	stream.Send("test", "4")
+	stream.Send("test", "5")
+
+	// Only the messages after the last one that they saw are sent to the new connection
+	s2, conn2 := testConnectSession(t, testStreamID+1)
+	if !stream.Resume(s2, 3) {
+		t.Fatal("Failed to resume the stream.")
+	}
+	testExpectResumed(t, conn2, 2)
+	testExpectMessage(t, conn2, "test 4 4")
+	testExpectMessage(t, conn2, "test 5 5")
+
+	// New messages go to the new connection and keep the same numbering
+	stream.Send("test", "6")
+	testExpectMessage(t, conn2, "test 6 6")
+}
+
+func TestSessionStreamResumeWithNothingMissed(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+	stream.Send("test", "1")
+	testDisconnect(t, s, conn)
+
+	s2, conn2 := testConnectSession(t, testStreamID+1)
+	if !stream.Resume(s2, 1) {
+		t.Fatal("Failed to resume the stream.")
+	}
+	testExpectResumed(t, conn2, 0)
+}
+
+func TestSessionStreamResumeFromTheFuture(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+	stream.Send("test", "1")
+	testDisconnect(t, s, conn)
+
+	// The client cannot have seen a message that was never sent
+	s2, _ := testConnectSession(t, testStreamID+1)
+	if stream.Resume(s2, 2) {
+		t.Error("A stream was resumed from a sequence number that was never sent.")
+	}
+}
+
+func TestSessionStreamBufferOverflow(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+	testDisconnect(t, s, conn)
+
+	// Only the most recent messages are kept
+	numMessages := SessionStreamBufferSize + 10
+	for i := 1; i <= numMessages; i++ {
+		stream.Send("test", strconv.Itoa(i))
+	}
+
+	// Some of the messages after the 9th one are gone, so they must reload everything instead
+	s2, _ := testConnectSession(t, testStreamID+1)
+	if stream.Resume(s2, 9) {
+		t.Error("A stream was resumed even though some of the missed messages were gone.")
+	}
+
+	// Every message after the 10th one is still in the buffer
+	s3, conn3 := testConnectSession(t, testStreamID+2)
+	if !stream.Resume(s3, 10) {
+		t.Fatal("Failed to resume the stream.")
+	}
+	testExpectResumed(t, conn3, SessionStreamBufferSize)
+	for i := 11; i <= numMessages; i++ {
+		testExpectMessage(t, conn3, "test "+strconv.Itoa(i)+" "+strconv.Itoa(i))
+	}
+}
+
+func TestSessionStreamEnd(t *testing.T) {
+	s, conn := testConnectSession(t, testStreamID)
+	stream := NewSessionStream(s)
+	stream.Send("test", "1")
+	testDisconnect(t, s, conn)
+
+	// e.g. the user was logged out by the server
+	stream.End()
+	if !stream.Ended() {
+		t.Error("The stream did not end.")
+	}
+
+	s2, _ := testConnectSession(t, testStreamID+1)
+	if stream.Resume(s2, 1) {
+		t.Error("A stream was resumed after it ended.")
+	}
+}
@@ -161,6 +161,20 @@ func (s *Session) FakeUser() bool {
 	}
 }
 
+func (s *Session) Bot() bool {
+	if s == nil {
+		logger.Error("The \"Bot\" method was called for a nil session.")
+		return false
+	}
+
+	if v, exists := s.Get("bot"); !exists {
+		logger.Error("Failed to get \"bot\" from a session.")
+		return false
+	} else {
+		return v.(bool)
+	}
+}
+
 func (s *Session) RateLimitAllowance() float64 {
 	if s == nil {
 		logger.Error("The \"RateLimitAllowance\" method was called for a nil session.")
@@ -202,3 +216,60 @@ func (s *Session) Banned() bool {
 		return v.(bool)
 	}
 }
+
+func (s *Session) ProtocolVersion() int {
+	if s == nil {
+		logger.Error("The \"ProtocolVersion\" method was called for a nil session.")
+		return MinProtocolVersion
+	}
+
+	if v, exists := s.Get("protocolVersion"); !exists {
+		logger.Error("Failed to get \"protocolVersion\" from a session.")
+		return MinProtocolVersion
+	} else {
+		return v.(int)
+	}
+}
+
+// Stream returns nil if the client does not use sequence numbers
+func (s *Session) Stream() *SessionStream {
+	if s == nil {
+		logger.Error("The \"Stream\" method was called for a nil session.")
+		return nil
+	}
+
+	if v, exists := s.Get("stream"); !exists {
+		logger.Error("Failed to get \"stream\" from a session.")
+		return nil
+	} else {
+		return v.(*SessionStream)
+	}
+}
+
+func (s *Session) ResumeStreamID() uint64 {
+	if s == nil {
+		logger.Error("The \"ResumeStreamID\" method was called for a nil session.")
+		return 0
+	}
+
+	if v, exists := s.Get("resumeStreamID"); !exists {
+		logger.Error("Failed to get \"resumeStreamID\" from a session.")
+		return 0
+	} else {
+		return v.(uint64)
+	}
+}
+
+func (s *Session) ResumeLastSeq() uint64 {
+	if s == nil {
+		logger.Error("The \"ResumeLastSeq\" method was called for a nil session.")
+		return 0
+	}
+
+	if v, exists := s.Get("resumeLastSeq"); !exists {
+		logger.Error("Failed to get \"resumeLastSeq\" from a session.")
+		return 0
+	} else {
+		return v.(uint64)
+	}
+}
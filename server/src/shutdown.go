@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Zamiell/hanabi-live/src/engine"
 	"github.com/tevino/abool"
 )
 
@@ -116,9 +117,9 @@ func shutdownWait() {
 				s := t.GetOwnerSession()
 				commandAction(s, &CommandData{ // Manual invocation
 					TableID: t.ID,
-					Type:    ActionTypeEndGame,
+					Type:    engine.ActionTypeEndGame,
 					Target:  -1,
-					Value:   EndConditionTerminated,
+					Value:   engine.EndConditionTerminated,
 					NoLock:  true,
 				})
 				t.Mutex.Unlock()
@@ -157,6 +158,9 @@ func shutdownImmediate() {
 
 	waitForAllWebSocketCommandsToFinish()
 
+	// Prevent timers from firing on tables that are about to disappear
+	scheduler.CancelAll()
+
 	sessionsMutex.RLock()
 	for _, s := range sessions {
 		s.Error("The server is going down for scheduled maintenance.<br />" +
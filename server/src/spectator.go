@@ -1,9 +1,5 @@
 package main
 
-import (
-	"time"
-)
-
 // Spectator is an object that represents either a spectator in an ongoing game
 // or a viewer of a dedicated replay
 // It is sent directly to the client in the "spectators" command,
@@ -14,9 +10,9 @@ type Spectator struct {
 	// The user session corresponding to the spectator is copied here for convenience
 	// The session should always be valid because when a user disconnects,
 	// they will automatically stop spectating all games
-	Session   *Session  `json:"-"`
-	Typing    bool      `json:"-"`
-	LastTyped time.Time `json:"-"`
+	// (users that can resume their session stop spectating once the grace period is over)
+	Session *Session `json:"-"`
+	Typing  bool     `json:"-"`
 
 	// Spectators have the ability to watch a game from a specific player's perspective
 	// Equal to -1 if they are not shadowing a specific player
@@ -5,6 +5,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
 )
 
 // Table describes the container that a player can join, whether it is an unstarted game,
@@ -35,6 +37,8 @@ type Table struct {
 	Replay         bool
 	AutomaticStart int // See "chatTable.go"
 	Progress       int // Displayed as a percentage on the main lobby screen
+	// If true, the game cannot start until every player is ready (see "command_table_ready.go")
+	RequireReady bool
 
 	DatetimeCreated      time.Time
 	DatetimeLastJoined   time.Time
@@ -46,9 +50,13 @@ type Table struct {
 	// All of the game state is contained within the "Game" object
 	Game *Game
 
+	// Tables that were created by the matchmaking queue start automatically once everyone is ready
+	// (this is nil once the ready check is over)
+	ReadyCheck *MatchmakingReadyCheck `json:"-"`
+
 	// The variant and other game settings are contained within the "Options" object
-	Options      *Options      // Options that are stored in the database
-	ExtraOptions *ExtraOptions // Options that are not stored in the database
+	Options      *engine.Options // Options that are stored in the database
+	ExtraOptions *ExtraOptions   // Options that are not stored in the database
 
 	Chat     []*TableChatMessage // All of the in-game chat history
 	ChatRead map[int]int         // A map of which users have read which messages
@@ -104,7 +112,7 @@ func NewTable(name string, owner int) *Table {
 		DatetimeLastJoined: time.Now(),
 		DatetimeLastAction: time.Now(),
 
-		Options:      &Options{},
+		Options:      &engine.Options{},
 		ExtraOptions: &ExtraOptions{},
 
 		Chat:     make([]*TableChatMessage, 0),
@@ -112,35 +120,21 @@ func NewTable(name string, owner int) *Table {
 	}
 }
 
-// CheckIdle is meant to be called in a new goroutine
-func (t *Table) CheckIdle() {
+// ScheduleIdle (re)starts the countdown that will end the table if nothing else happens on it
+func (t *Table) ScheduleIdle() {
 	// Disable idle timeouts in development
 	if isDev {
 		return
 	}
 
 	// Set the last action
-	t.Mutex.Lock()
 	t.DatetimeLastAction = time.Now()
-	t.Mutex.Unlock()
-
-	// We want to clean up idle games, so sleep for a reasonable amount of time
-	time.Sleep(IdleGameTimeout)
-
-	// Check to see if the table still exists
-	t2, exists := getTableAndLock(nil, t.ID, false)
-	if !exists || t != t2 {
-		return
-	}
-	t.Mutex.Lock()
-	defer t.Mutex.Unlock()
 
-	// Don't do anything if there has been an action in the meantime
-	if time.Since(t.DatetimeLastAction) < IdleGameTimeout {
-		return
-	}
-
-	t.EndIdle()
+	// We want to clean up idle games, so end the table after a reasonable amount of time
+	// (this replaces the countdown from the previous action, if any)
+	scheduler.Schedule(t, TaskIdle, IdleGameTimeout, func(t *Table) {
+		t.EndIdle()
+	})
 }
 
 // EndIdle is called when a table has been idle for a while and should be automatically ended
@@ -162,7 +156,7 @@ func (t *Table) EndIdle() {
 			// They might be in the process of reconnecting,
 			// so make a fake session that will represent them
 			s = newFakeSession(sp.ID, sp.Name)
-			logger.Info("Created a new fake session in the \"CheckIdle()\" function.")
+			logger.Info("Created a new fake session in the \"EndIdle()\" function.")
 		}
 		commandTableUnattend(s, &CommandData{ // Manual invocation
 			TableID: t.ID,
@@ -182,9 +176,9 @@ func (t *Table) EndIdle() {
 		// (this will put everyone in a non-shared replay of the idle game)
 		commandAction(s, &CommandData{ // Manual invocation
 			TableID: t.ID,
-			Type:    ActionTypeEndGame,
+			Type:    engine.ActionTypeEndGame,
 			Target:  -1,
-			Value:   EndConditionIdleTimeout,
+			Value:   engine.EndConditionIdleTimeout,
 			NoLock:  true,
 		})
 	} else {
@@ -224,6 +218,34 @@ func (t *Table) GetPlayerIndexFromID(id int) int {
 	return -1
 }
 
+// GetNotReadyNames returns the names of the players who have not marked themselves as ready yet
+func (t *Table) GetNotReadyNames() []string {
+	names := make([]string, 0)
+	for _, p := range t.Players {
+		if !p.Ready {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// ResetReady is called whenever the options for the table change,
+// since the players did not agree to play with the new options
+// It returns true if anyone was ready
+func (t *Table) ResetReady() bool {
+	anyoneReady := false
+	for _, p := range t.Players {
+		if p.Bot != nil {
+			continue
+		}
+		if p.Ready {
+			anyoneReady = true
+		}
+		p.Ready = false
+	}
+	return anyoneReady
+}
+
 func (t *Table) GetSpectatorIndexFromID(id int) int {
 	for i, sp := range t.Spectators {
 		if sp.ID == id {
@@ -1,5 +1,9 @@
 package main
 
+import (
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
 /*
 	Notifications for both before and during a game
 */
@@ -57,6 +61,7 @@ func (t *Table) NotifyPlayerChange() {
 			Name    string       `json:"name"`
 			You     bool         `json:"you"`
 			Present bool         `json:"present"`
+			Ready   bool         `json:"ready"`
 			Stats   PregameStats `json:"stats"`
 		}
 		gamePlayers := make([]*GamePlayerMessage, 0)
@@ -66,6 +71,7 @@ func (t *Table) NotifyPlayerChange() {
 				Name:    p2.Name,
 				You:     p.ID == p2.ID,
 				Present: p2.Present,
+				Ready:   p2.Ready,
 				Stats:   p2.Stats,
 			}
 			gamePlayers = append(gamePlayers, gamePlayer)
@@ -77,8 +83,9 @@ func (t *Table) NotifyPlayerChange() {
 			Name              string               `json:"name"`
 			Owner             int                  `json:"owner"`
 			Players           []*GamePlayerMessage `json:"players"`
-			Options           *Options             `json:"options"`
+			Options           *engine.Options      `json:"options"`
 			PasswordProtected bool                 `json:"passwordProtected"`
+			RequireReady      bool                 `json:"requireReady"`
 		}
 		p.Session.Emit("game", &GameMessage{
 			TableID:           t.ID,
@@ -87,6 +94,7 @@ func (t *Table) NotifyPlayerChange() {
 			Players:           gamePlayers,
 			Options:           t.Options,
 			PasswordProtected: t.PasswordHash != "",
+			RequireReady:      t.RequireReady,
 		})
 	}
 }
@@ -152,7 +160,10 @@ func (t *Table) NotifyGameAction() {
 
 	for _, gp := range g.Players {
 		p := t.Players[gp.Index]
-		if p.Present {
+		if p.Bot != nil {
+			// Bots get the same scrubbed action that a human would get
+			p.Bot.Observe(CheckScrub(t, a, p.ID))
+		} else if p.Present {
 			p.Session.NotifyGameAction(t, a)
 		}
 	}
@@ -163,33 +174,6 @@ func (t *Table) NotifyGameAction() {
 	}
 }
 
-// NotifyStatus appends a new "status" action and alerts everyone
-func (t *Table) NotifyStatus() {
-	g := t.Game
-	g.Actions = append(g.Actions, ActionStatus{
-		Type:     "status",
-		Clues:    g.ClueTokens,
-		Score:    g.Score,
-		MaxScore: g.MaxScore,
-	})
-	t.NotifyGameAction()
-}
-
-// NotifyTurn appends a new "turn" action and alerts everyone
-func (t *Table) NotifyTurn() {
-	g := t.Game
-	currentPlayerIndex := g.ActivePlayerIndex
-	if g.EndCondition > EndConditionInProgress {
-		currentPlayerIndex = -1
-	}
-	g.Actions = append(g.Actions, ActionTurn{
-		Type:               "turn",
-		Num:                g.Turn,
-		CurrentPlayerIndex: currentPlayerIndex,
-	})
-	t.NotifyGameAction()
-}
-
 func (t *Table) NotifyFinishOngoingGame() {
 	type FinishOngoingGameMessage struct {
 		TableID            uint64 `json:"tableID"`
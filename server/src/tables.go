@@ -77,5 +77,8 @@ func deleteTable(t *Table) {
 	t.Deleted = true
 	tablesMutex.Unlock()
 
+	// Any pending deadlines for this table are no longer relevant
+	scheduler.CancelTable(t)
+
 	notifyAllTableGone(t)
 }
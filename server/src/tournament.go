@@ -0,0 +1,229 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+)
+
+var (
+	isTournamentSeedSuffix = regexp.MustCompile(`^t\d+r\d+$`).MatchString
+)
+
+// tournamentInitTable handles tables created with the "!tournament" prefix
+// Every team plays the seed of the current round with the variant and player count of the
+// tournament
+// It returns false if the table should not be created
+func tournamentInitTable(s *Session, d *CommandData, args []string, data *SpecialGameData) bool {
+	if len(args) > 1 {
+		s.Warning("Tournament games must be created in the form: " +
+			"!tournament [tournament ID]")
+		return false
+	}
+
+	var entries []*TournamentEntry
+	if v, err := models.TournamentTeamMembers.GetEntries(s.UserID()); err != nil {
+		logger.Error("Failed to get the tournament entries for \""+s.Username()+"\":", err)
+		s.Error(CreateGameFail)
+		return false
+	} else {
+		entries = v
+	}
+	if len(entries) == 0 {
+		s.Warning("You are not on a team in any tournament that has a round in progress.")
+		return false
+	}
+
+	// Find the tournament that they want to play in
+	var entry *TournamentEntry
+	if len(args) == 0 {
+		if len(entries) > 1 {
+			s.Warning("You are playing in more than one tournament, so you must specify the " +
+				"tournament ID in the form: !tournament [tournament ID]")
+			return false
+		}
+		entry = entries[0]
+	} else {
+		var tournamentID int
+		if v, err := strconv.Atoi(args[0]); err != nil {
+			s.Warning("The tournament ID of \"" + args[0] + "\" is not a number.")
+			return false
+		} else {
+			tournamentID = v
+		}
+
+		for _, e := range entries {
+			if e.TournamentID == tournamentID {
+				entry = e
+				break
+			}
+		}
+		if entry == nil {
+			s.Warning("You are not on a team in tournament #" + strconv.Itoa(tournamentID) +
+				" or it does not have a round in progress.")
+			return false
+		}
+	}
+
+	// Validate that the team has not already played this round
+	if exists, err := models.TournamentGames.Exists(
+		entry.TournamentID,
+		entry.Round,
+		entry.TeamID,
+	); err != nil {
+		logger.Error("Failed to check to see if team "+strconv.Itoa(entry.TeamID)+
+			" has played round "+strconv.Itoa(entry.Round)+":", err)
+		s.Error(CreateGameFail)
+		return false
+	} else if exists {
+		s.Warning("Your team has already played round " + strconv.Itoa(entry.Round) + ".")
+		return false
+	}
+
+	// Validate that the team is not already playing this round at another table
+	alreadyPlaying := false
+	tablesMutex.RLock()
+	for _, t := range tables {
+		if t.ExtraOptions.TournamentTeamID == entry.TeamID &&
+			t.ExtraOptions.TournamentRound == entry.Round &&
+			!t.Replay {
+
+			alreadyPlaying = true
+			break
+		}
+	}
+	tablesMutex.RUnlock()
+	if alreadyPlaying {
+		s.Warning("Your team already has a table for round " + strconv.Itoa(entry.Round) + ".")
+		return false
+	}
+
+	var variantName string
+	if v, ok := getVariantNameFromID(entry.VariantID); !ok {
+		logger.Error("Failed to find a definition for variant " +
+			strconv.Itoa(entry.VariantID) + ".")
+		s.Error(CreateGameFail)
+		return false
+	} else {
+		variantName = v
+	}
+
+	// Every team must play with the same options, so the options from the lobby are ignored
+	d.Options = &engine.Options{
+		VariantName: variantName,
+	}
+	data.CustomNumPlayers = entry.NumPlayers
+	data.SetSeedSuffix = getTournamentSeedSuffix(entry.TournamentID, entry.Round)
+	data.TournamentID = entry.TournamentID
+	data.TournamentRound = entry.Round
+	data.TournamentTeamID = entry.TeamID
+
+	return true
+}
+
+// getTournamentSeedSuffix returns the part of the seed that comes after the player count and
+// variant (e.g. "t1r1" for "p2v0st1r1")
+func getTournamentSeedSuffix(tournamentID int, round int) string {
+	return "t" + strconv.Itoa(tournamentID) + "r" + strconv.Itoa(round)
+}
+
+// tournamentValidateReplay returns false if the user is not allowed to view the game because it
+// was played on a seed of a tournament round that they are participating in
+// (players can always view their own games)
+func tournamentValidateReplay(s *Session, databaseID int) bool {
+	var seed string
+	if v, err := models.Games.GetSeed(databaseID); err != nil {
+		logger.Error("Failed to get the seed for game "+strconv.Itoa(databaseID)+":", err)
+		s.Error(InitGameFail)
+		return false
+	} else {
+		seed = v
+	}
+
+	if hidden, err := models.TournamentRounds.IsSeedHidden(seed, s.UserID()); err != nil {
+		logger.Error("Failed to check to see if seed \""+seed+"\" is hidden:", err)
+		s.Error(InitGameFail)
+		return false
+	} else if !hidden {
+		return true
+	}
+
+	var dbPlayers []*DBPlayer
+	if v, err := models.Games.GetPlayers(databaseID); err != nil {
+		logger.Error("Failed to get the players for game "+strconv.Itoa(databaseID)+":", err)
+		s.Error(InitGameFail)
+		return false
+	} else {
+		dbPlayers = v
+	}
+	for _, dbPlayer := range dbPlayers {
+		if dbPlayer.ID == s.UserID() {
+			return true
+		}
+	}
+
+	s.Warning("That game was played on the seed of a tournament round that is still in " +
+		"progress, so you cannot view it until the round is over.")
+	return false
+}
+
+// tournamentValidateSpectate returns false if the user is participating in the tournament on a
+// different team and the round is still in progress
+func tournamentValidateSpectate(s *Session, t *Table) bool {
+	if isOpen, err := models.TournamentRounds.IsOpen(
+		t.ExtraOptions.TournamentID,
+		t.ExtraOptions.TournamentRound,
+	); err != nil {
+		logger.Error("Failed to check to see if round "+
+			strconv.Itoa(t.ExtraOptions.TournamentRound)+" of tournament "+
+			strconv.Itoa(t.ExtraOptions.TournamentID)+" is open:", err)
+		s.Error(DefaultErrorMsg)
+		return false
+	} else if !isOpen {
+		return true
+	}
+
+	if isRegistered, err := models.TournamentTeamMembers.IsRegistered(
+		t.ExtraOptions.TournamentID,
+		s.UserID(),
+	); err != nil {
+		logger.Error("Failed to check to see if \""+s.Username()+"\" is registered in tournament "+
+			strconv.Itoa(t.ExtraOptions.TournamentID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return false
+	} else if !isRegistered {
+		return true
+	}
+
+	if isMember, err := models.TournamentTeamMembers.IsMember(
+		t.ExtraOptions.TournamentTeamID,
+		s.UserID(),
+	); err != nil {
+		logger.Error("Failed to check to see if \""+s.Username()+"\" is a member of team "+
+			strconv.Itoa(t.ExtraOptions.TournamentTeamID)+":", err)
+		s.Error(DefaultErrorMsg)
+		return false
+	} else if isMember {
+		return true
+	}
+
+	s.Warning("You are playing in this tournament, so you cannot watch the games of the other " +
+		"teams until the round is over.")
+	return false
+}
+
+// WriteTournamentResult records the game for the team
+// (the standings are updated when the round is closed)
+// It must be called after the game has been written to the database
+func (g *Game) WriteTournamentResult() {
+	if err := models.TournamentGames.Insert(
+		g.ExtraOptions.TournamentID,
+		g.ExtraOptions.TournamentRound,
+		g.ExtraOptions.TournamentTeamID,
+		g.ExtraOptions.DatabaseID,
+	); err != nil {
+		logger.Error("Failed to insert the tournament game for game "+
+			strconv.Itoa(g.ExtraOptions.DatabaseID)+":", err)
+	}
+}
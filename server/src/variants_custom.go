@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Zamiell/hanabi-live/src/engine"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// Custom variants are named after their ID in the "custom_variants" table
+	// (e.g. "Custom Variant #3")
+	customVariantNamePrefix = "Custom Variant #"
+
+	// The client cannot draw more than 6 stacks
+	MaxCustomVariantSuits = 6
+)
+
+type CustomVariant struct {
+	Variant *engine.Variant
+	// The definition that the client needs in order to reconstruct the variant
+	// (this also includes the name and the ID of the variant)
+	JSON *engine.VariantJSON
+}
+
+var (
+	// Custom variants are lazily loaded from the database and then cached here
+	// (indexed by the name of the variant)
+	customVariants      = make(map[string]*CustomVariant)
+	customVariantsMutex sync.RWMutex
+)
+
+// getVariant is similar to looking up a name in the "variants" map, but it also handles
+// custom variants
+// It returns nil if the variant does not exist
+func getVariant(variantName string) *engine.Variant {
+	if variant, ok := variants[variantName]; ok {
+		return variant
+	}
+
+	if customVariant := getCustomVariant(variantName); customVariant != nil {
+		return customVariant.Variant
+	}
+
+	return nil
+}
+
+// getCustomVariantJSON returns nil if the variant is not a custom variant
+func getCustomVariantJSON(variantName string) *engine.VariantJSON {
+	if customVariant := getCustomVariant(variantName); customVariant != nil {
+		return customVariant.JSON
+	}
+
+	return nil
+}
+
+func getCustomVariant(variantName string) *CustomVariant {
+	if !strings.HasPrefix(variantName, customVariantNamePrefix) {
+		return nil
+	}
+
+	customVariantsMutex.RLock()
+	customVariant, ok := customVariants[variantName]
+	customVariantsMutex.RUnlock()
+	if ok {
+		return customVariant
+	}
+
+	var id int
+	if v, err := strconv.Atoi(strings.TrimPrefix(variantName, customVariantNamePrefix)); err != nil {
+		return nil
+	} else {
+		id = v
+	}
+
+	var definition string
+	if v, err := models.CustomVariants.Get(id); err == pgx.ErrNoRows {
+		return nil
+	} else if err != nil {
+		logger.Error("Failed to get custom variant "+strconv.Itoa(id)+" from the database:", err)
+		return nil
+	} else {
+		definition = v
+	}
+
+	var variantJSON engine.VariantJSON
+	if err := json.Unmarshal([]byte(definition), &variantJSON); err != nil {
+		logger.Error("Failed to unmarshal the definition of custom variant "+strconv.Itoa(id)+":",
+			err)
+		return nil
+	}
+
+	if v, err := cacheCustomVariant(id, &variantJSON); err != nil {
+		logger.Error("Failed to load custom variant "+strconv.Itoa(id)+":", err)
+		return nil
+	} else {
+		return v
+	}
+}
+
+// getVariantNameFromID is used to find the variant that corresponds to a database entry
+// Custom variants have a negative ID
+func getVariantNameFromID(variantID int) (string, bool) {
+	if variantID < 0 {
+		variantName := customVariantNamePrefix + strconv.Itoa(-variantID)
+		return variantName, getCustomVariant(variantName) != nil
+	}
+
+	variantName, ok := variantIDMap[variantID]
+	return variantName, ok
+}
+
+// createCustomVariant validates a variant that was built by a user and returns it
+// Identical definitions are stored only once, so that they always map to the same stable ID
+// (which is used to generate seeds and to record the game in the database)
+// If the definition is not valid, it warns the user and returns nil
+func createCustomVariant(s *Session, variantJSON *engine.VariantJSON) *engine.Variant {
+	if len(variantJSON.Suits) > MaxCustomVariantSuits {
+		s.Warning("Custom variants cannot have more than " +
+			strconv.Itoa(MaxCustomVariantSuits) + " suits.")
+		return nil
+	}
+	for i, suitName := range variantJSON.Suits {
+		for j := 0; j < i; j++ {
+			if variantJSON.Suits[j] == suitName {
+				s.Warning("Custom variants cannot have the suit of \"" + suitName + "\" twice.")
+				return nil
+			}
+		}
+	}
+
+	// Validate the rest of the definition in the same way as the "variants.json" file
+	// The name and the ID are assigned by the server
+	definition := *variantJSON
+	definition.Name = "custom"
+	definition.ID = 0
+	var variant *engine.Variant
+	if v, err := engine.NewVariant(&definition, suits, colors); err != nil {
+		s.Warning("Invalid custom variant: " + err.Error())
+		return nil
+	} else {
+		variant = v
+	}
+
+	// Normalize the definition so that variants that play identically share the same ID
+	definition.Name = ""
+	definition.ClueColors = &variant.ClueColors
+	definition.ClueRanks = &variant.ClueRanks
+	var definitionString string
+	if v, err := json.Marshal(&definition); err != nil {
+		logger.Error("Failed to marshal a custom variant:", err)
+		s.Error(DefaultErrorMsg)
+		return nil
+	} else {
+		definitionString = string(v)
+	}
+
+	var id int
+	if v, err := models.CustomVariants.GetOrInsert(definitionString); err != nil {
+		logger.Error("Failed to insert a custom variant into the database:", err)
+		s.Error(DefaultErrorMsg)
+		return nil
+	} else {
+		id = v
+	}
+
+	if v, err := cacheCustomVariant(id, &definition); err != nil {
+		logger.Error("Failed to load custom variant "+strconv.Itoa(id)+":", err)
+		s.Error(DefaultErrorMsg)
+		return nil
+	} else {
+		return v.Variant
+	}
+}
+
+func cacheCustomVariant(id int, definition *engine.VariantJSON) (*CustomVariant, error) {
+	variantJSON := *definition
+	variantJSON.Name = customVariantNamePrefix + strconv.Itoa(id)
+	variantJSON.ID = -id
+
+	var variant *engine.Variant
+	if v, err := engine.NewVariant(&variantJSON, suits, colors); err != nil {
+		return nil, err
+	} else {
+		variant = v
+	}
+
+	customVariant := &CustomVariant{
+		Variant: variant,
+		JSON:    &variantJSON,
+	}
+	customVariantsMutex.Lock()
+	customVariants[variant.Name] = customVariant
+	customVariantsMutex.Unlock()
+
+	return customVariant, nil
+}
@@ -34,6 +34,10 @@ func websocketInit() {
 	// Thus, we have to manually increase it
 	m.Config.MaxMessageSize = 8192
 
+	// When a session is resumed, every message that they missed is written at once,
+	// so the outgoing buffer must be able to hold all of them (see "session_stream.go")
+	m.Config.MessageBufferSize = SessionStreamBufferSize * 2
+
 	// Attach some handlers
 	m.HandleConnect(websocketConnect)
 	m.HandleDisconnect(websocketDisconnect)
@@ -30,6 +30,12 @@ func websocketConnect(ms *melody.Session) {
 
 	logger.Debug("Entered the \"websocketConnect()\" function for user: " + s.Username())
 
+	// Clients that use sequence numbers get a stream that numbers and buffers their messages
+	// (this must happen before anything is sent to them)
+	if s.ProtocolVersion() >= ProtocolVersionSequenced {
+		s.Set("stream", NewSessionStream(s))
+	}
+
 	// First, perform all the expensive database retrieval to gather the data we need
 	// We want to do this before we start locking any mutexes (to minimize the lock time)
 	data := websocketConnectGetData(s)
@@ -48,6 +54,12 @@ func websocketConnect(ms *melody.Session) {
 	s2, ok := sessions[s.UserID()]
 	sessionsMutex.RUnlock()
 	if ok {
+		// If they are reconnecting after losing their connection,
+		// then they can pick up where they left off instead of reloading everything
+		if websocketResume(s, s2) {
+			return
+		}
+
 		logger.Info("Closing existing connection for user \"" + s.Username() + "\".")
 		s2.Error("You have logged on from somewhere else, so you have been disconnected here.")
 		if err := s2.Close(); err != nil {
@@ -196,7 +208,14 @@ func websocketConnectWelcomeMessage(s *Session, data *WebsocketConnectData) {
 		ShuttingDown         bool      `json:"shuttingDown"`
 		DatetimeShutdownInit time.Time `json:"datetimeShutdownInit"`
 		MaintenanceMode      bool      `json:"maintenanceMode"`
+		ProtocolVersion      int       `json:"protocolVersion"`
+		StreamID             uint64    `json:"streamID,omitempty"`
 	}
+	var streamID uint64
+	if stream := s.Stream(); stream != nil {
+		streamID = stream.ID
+	}
+
 	s.Emit("welcome", &WelcomeMessage{
 		// Send the user their corresponding user ID
 		UserID: s.UserID(),
@@ -229,6 +248,12 @@ func websocketConnectWelcomeMessage(s *Session, data *WebsocketConnectData) {
 		ShuttingDown:         shuttingDown.IsSet(),
 		DatetimeShutdownInit: datetimeShutdownInit,
 		MaintenanceMode:      maintenanceMode.IsSet(),
+
+		// The version of the WebSocket protocol that was negotiated in "httpWS()"
+		ProtocolVersion: s.ProtocolVersion(),
+
+		// Clients that use sequence numbers need this to resume their session after a disconnect
+		StreamID: streamID,
 	})
 }
 
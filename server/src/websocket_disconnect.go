@@ -2,6 +2,7 @@ package main
 
 import (
 	"strconv"
+	"time"
 
 	melody "gopkg.in/olahol/melody.v1"
 )
@@ -19,15 +20,48 @@ func websocketDisconnect(ms *melody.Session) {
 	logger.Debug("Acquired session connection write lock for user: " + s.Username())
 	defer sessionConnectMutex.Unlock()
 
+	// Clients that use sequence numbers get some time to resume their session before they are
+	// removed from their games (see "websocket_resume.go")
+	if stream := s.Stream(); stream != nil && !stream.Ended() && websocketDisconnectIsCurrent(s) {
+		logger.Info("User \"" + s.Username() + "\" disconnected; keeping their session for " +
+			SessionResumeGracePeriod.String() + " in case they resume it.")
+		time.AfterFunc(SessionResumeGracePeriod, func() {
+			logger.Debug("Acquiring session connection write lock for user: " + s.Username())
+			sessionConnectMutex.Lock()
+			logger.Debug("Acquired session connection write lock for user: " + s.Username())
+			defer sessionConnectMutex.Unlock()
+
+			websocketDisconnectCleanup(s)
+		})
+		return
+	}
+
+	websocketDisconnectCleanup(s)
+}
+
+// websocketDisconnectCleanup removes the user from the map and from their games
+// The session connection lock must be held when calling this function
+func websocketDisconnectCleanup(s *Session) {
 	if !websocketDisconnectRemoveFromMap(s) {
 		return
 	}
 	websocketDisconnectRemoveFromGames(s)
+	matchmakingRemove(s.UserID())
 
 	// Alert everyone that a user has logged out
 	notifyAllUserLeft(s)
 }
 
+// websocketDisconnectIsCurrent returns false if the session has already been replaced
+// (e.g. if they logged on from somewhere else)
+func websocketDisconnectIsCurrent(s *Session) bool {
+	sessionsMutex.RLock()
+	defer sessionsMutex.RUnlock()
+
+	s2, ok := sessions[s.UserID()]
+	return ok && s2.SessionID() == s.SessionID()
+}
+
 // websocketDisconnectRemoveFromMap returns true if the user was removed from the map
 // (in some situations, the session will already be removed from the map by the time the code
 // reaches this function)
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +13,12 @@ import (
 const (
 	RateLimitRate = float64(100) // Number of messages sent
 	RateLimitPer  = float64(2)   // Per seconds
+
+	// Bot accounts have a separate rate limit
+	// Bots are expected to send messages at a steady pace,
+	// so they get a smaller burst than humans but are not banned for exceeding it
+	BotRateLimitRate = float64(20) // Number of messages sent
+	BotRateLimitPer  = float64(1)  // Per seconds
 )
 
 // websocketMessage is fired every time a WebSocket user sends a message to the server
@@ -47,16 +54,35 @@ func websocketMessage(ms *melody.Session, msg []byte) {
 	if !s.FakeUser() {
 		// Validate that the user is not attempting to flood the server
 		// Algorithm from: http://stackoverflow.com/questions/667508
+		rate := RateLimitRate
+		per := RateLimitPer
+		if s.Bot() {
+			rate = BotRateLimitRate
+			per = BotRateLimitPer
+		}
+
 		now := time.Now()
 		timePassed := now.Sub(s.RateLimitLastCheck()).Seconds()
 		s.Set("rateLimitLastCheck", now)
 
-		newRateLimitAllowance := s.RateLimitAllowance() + timePassed*(RateLimitRate/RateLimitPer)
-		if newRateLimitAllowance > RateLimitRate {
-			newRateLimitAllowance = RateLimitRate
+		newRateLimitAllowance := s.RateLimitAllowance() + timePassed*(rate/per)
+		if newRateLimitAllowance > rate {
+			newRateLimitAllowance = rate
 		}
 
 		if newRateLimitAllowance < 1 {
+			if s.Bot() {
+				// A misbehaving bot should not get its IP address banned,
+				// since bots are often hosted on shared servers
+				logger.Warning("Bot \"" + s.Username() + "\" triggered rate-limiting; " +
+					"ignoring the message.")
+				s.Warning("You are sending messages too quickly. " +
+					"Bots are limited to " + strconv.Itoa(int(rate)) + " messages per " +
+					strconv.Itoa(int(per)) + " second(s).")
+				s.Set("rateLimitAllowance", newRateLimitAllowance)
+				return
+			}
+
 			// They are flooding, so automatically ban them
 			logger.Warning("User \"" + s.Username() + "\" triggered rate-limiting; banning them.")
 			ban(s)
@@ -82,13 +108,27 @@ func websocketMessage(ms *melody.Session, msg []byte) {
 	jsonData := []byte(result[1])
 
 	// Check to see if there is a command handler for this command
-	var commandMapFunction func(*Session, *CommandData)
+	var commandMapEntry *Command
 	if v, ok := commandMap[command]; !ok {
 		logger.Error("User \"" + s.Username() + "\" sent an invalid command of " +
 			"\"" + command + "\".")
+		if s.ProtocolVersion() >= ProtocolVersionTyped {
+			s.Warning("The command of \"" + command + "\" does not exist.")
+		}
 		return
 	} else {
-		commandMapFunction = v
+		commandMapEntry = v
+	}
+
+	// Clients that use a typed version of the protocol are told exactly what is wrong with the data
+	// (see "protocol.go")
+	if s.ProtocolVersion() >= ProtocolVersionTyped {
+		if msg := protocolValidate(commandMapEntry, jsonData); msg != "" {
+			logger.Info("User \"" + s.Username() + "\" sent a command of " +
+				"\"" + command + "\" with invalid data: " + string(jsonData))
+			s.Warning("Invalid data for the \"" + command + "\" command: " + msg)
+			return
+		}
 	}
 
 	// Unmarshal the JSON (this code is taken from Golem)
@@ -101,7 +141,7 @@ func websocketMessage(ms *melody.Session, msg []byte) {
 
 	// Call the command handler for this command
 	logger.Info("Command - " + command + " - " + s.Username())
-	commandMapFunction(s, d)
+	commandMapEntry.Handler(s, d)
 }
 
 func ban(s *Session) {
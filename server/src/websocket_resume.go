@@ -0,0 +1,98 @@
+package main
+
+// Clients that use sequence numbers (see "protocol.go") can resume their session after losing their
+// connection, instead of reloading the lobby and any game that they are in
+// When such a client disconnects, their old session is kept for a grace period (see
+// "websocketDisconnect()"), so they stay in their games and the messages that are sent to them are
+// buffered in its stream
+// If they reconnect in time with the ID of the stream and the sequence number of the last message
+// that they received, then the new session takes the place of the old one and only the missed
+// messages are sent
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// How long the session of a disconnected user is kept while waiting for them to resume it
+	SessionResumeGracePeriod = 30 * time.Second
+)
+
+// websocketResume returns true if the new session took the place of the existing session
+// The session connection lock must be held when calling this function
+func websocketResume(s *Session, s2 *Session) bool {
+	stream := s2.Stream()
+	if s.Stream() == nil || stream == nil || s.ResumeStreamID() != stream.ID {
+		return false
+	}
+
+	// Move the stream to the new session and send them the messages that they missed
+	if !stream.Resume(s, s.ResumeLastSeq()) {
+		logger.Info("User \"" + s.Username() + "\" tried to resume stream " +
+			strconv.FormatUint(stream.ID, 10) + " from message " +
+			strconv.FormatUint(s.ResumeLastSeq(), 10) + ", but it can no longer be resumed.")
+		return false
+	}
+	s.Set("stream", stream)
+
+	// The new session inherits the state of the old one
+	s.Set("status", s2.Status())
+	s.Set("tableID", s2.TableID())
+	s.Set("inactive", s2.Inactive())
+
+	// The old connection might still be open if the client noticed that it was dead before the
+	// server did
+	// (when it closes, "websocketDisconnect()" will ignore it since it is no longer in the map)
+	if !s2.IsClosed() {
+		if err := s2.Close(); err != nil {
+			logger.Info("Failed to manually close a WebSocket connection.")
+		}
+	}
+
+	logger.Debug("Acquiring sessions write lock for user: " + s.Username())
+	sessionsMutex.Lock()
+	logger.Debug("Acquired sessions write lock for user: " + s.Username())
+	sessions[s.UserID()] = s
+	sessionsMutex.Unlock()
+
+	websocketResumeReplaceSession(s2, s)
+	matchmakingReplaceSession(s2, s)
+
+	logger.Info("User \"" + s.Username() + "\" resumed their session.")
+	return true
+}
+
+// websocketResumeReplaceSession updates every table that refers to the old session
+func websocketResumeReplaceSession(oldSession *Session, newSession *Session) {
+	tableList := make([]*Table, 0)
+	logger.Debug("Acquiring tables read lock for user: " + newSession.Username())
+	tablesMutex.RLock()
+	logger.Debug("Acquired tables read lock for user: " + newSession.Username())
+	for _, t := range tables {
+		tableList = append(tableList, t)
+	}
+	tablesMutex.RUnlock()
+
+	for _, t := range tableList {
+		t.Mutex.Lock()
+		for _, p := range t.Players {
+			if p.Session != nil && p.Session.Session == oldSession.Session {
+				p.Session = newSession
+			}
+		}
+		for _, sp := range t.Spectators {
+			if sp.Session != nil && sp.Session.Session == oldSession.Session {
+				sp.Session = newSession
+			}
+		}
+		if t.ReadyCheck != nil {
+			for _, entry := range t.ReadyCheck.Entries {
+				if entry.Session != nil && entry.Session.Session == oldSession.Session {
+					entry.Session = newSession
+				}
+			}
+		}
+		t.Mutex.Unlock()
+	}
+}